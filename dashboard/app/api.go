@@ -976,9 +976,11 @@ func saveCrash(c context.Context, ns string, req *dashapi.Crash, bug *Bug, bugKe
 		Maintainers: email.MergeEmailLists(req.Maintainers,
 			GetEmails(req.Recipients, dashapi.To),
 			GetEmails(req.Recipients, dashapi.Cc)),
-		ReproOpts: req.ReproOpts,
-		Flags:     int64(req.Flags),
-		Assets:    assets,
+		ReproOpts:    req.ReproOpts,
+		ReproRuns:    int64(req.ReproRuns),
+		ReproCrashes: int64(req.ReproCrashes),
+		Flags:        int64(req.Flags),
+		Assets:       assets,
 		ReportElements: CrashReportElements{
 			GuiltyFiles: req.GuiltyFiles,
 		},
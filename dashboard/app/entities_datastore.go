@@ -364,6 +364,8 @@ type Crash struct {
 	ReproIsRevoked  bool                // the repro no longer triggers the bug on HEAD
 	ReproLog        int64               // reference to ReproLog text entity
 	LastReproRetest time.Time           // the last time when the repro was re-checked
+	ReproRuns       int64               // the number of repro runs when measuring its reproduction rate
+	ReproCrashes    int64               // how many of ReproRuns runs crashed the kernel
 	MachineInfo     int64               // Reference to MachineInfo text entity.
 	// Custom crash priority for reporting (greater values are higher priority).
 	// For example, a crash in mainline kernel has higher priority than a crash in a side branch.
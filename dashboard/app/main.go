@@ -406,6 +406,8 @@ type uiCrash struct {
 	ReproCLink      string
 	ReproIsRevoked  bool
 	ReproLogLink    string
+	ReproRuns       int64
+	ReproCrashes    int64
 	MachineInfoLink string
 	Assets          []*uiAsset
 	*uiBuild
@@ -2084,6 +2086,8 @@ func makeUICrash(c context.Context, crash *Crash, build *Build) *uiCrash {
 		ReproCLink:      textLink(textReproC, crash.ReproC),
 		ReproLogLink:    textLink(textReproLog, crash.ReproLog),
 		ReproIsRevoked:  crash.ReproIsRevoked,
+		ReproRuns:       crash.ReproRuns,
+		ReproCrashes:    crash.ReproCrashes,
 		MachineInfoLink: textLink(textMachineInfo, crash.MachineInfo),
 		Assets:          makeUIAssets(c, build, crash, true),
 	}
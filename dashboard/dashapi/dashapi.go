@@ -338,6 +338,9 @@ type Crash struct {
 	ReproC        []byte
 	ReproLog      []byte
 	OriginalTitle string // Title before we began bug reproduction.
+	// The repro crashed the kernel ReproCrashes times out of ReproRuns runs (0 runs if not measured).
+	ReproRuns    int
+	ReproCrashes int
 }
 
 type ReportCrashResp struct {
@@ -365,6 +365,60 @@ static void thread_start(void* (*fn)(void*), void* arg)
 #endif
 #endif
 
+#if !GOOS_windows
+// Scheduling perturbations help to reproduce data races that need specific thread interleavings.
+#if SYZ_EXECUTOR || SYZ_SCHED_YIELD || SYZ_SCHED_DELAY
+#include <unistd.h>
+
+static unsigned sched_rand_seq;
+
+// Returns a pseudo-random number that is different in different processes (including forked ones).
+static unsigned sched_rand(void)
+{
+	unsigned x = __atomic_add_fetch(&sched_rand_seq, 1, __ATOMIC_RELAXED) * 2654435761u ^ (unsigned)getpid();
+	x ^= x >> 16;
+	x *= 0x7feb352du;
+	x ^= x >> 15;
+	x *= 0x846ca68bu;
+	x ^= x >> 16;
+	return x;
+}
+#endif
+
+#if SYZ_EXECUTOR || SYZ_SCHED_PIN || SYZ_SCHED_SPREAD
+#include <sched.h>
+#include <unistd.h>
+
+static void sched_pin_thread(int cpu)
+{
+#if GOOS_linux
+	long ncpu = sysconf(_SC_NPROCESSORS_ONLN);
+	cpu_set_t set;
+	CPU_ZERO(&set);
+	CPU_SET(ncpu > 0 ? cpu % ncpu : 0, &set);
+	sched_setaffinity(0, sizeof(set), &set);
+#endif
+}
+#endif
+
+#if SYZ_EXECUTOR || SYZ_SCHED_YIELD
+#include <sched.h>
+
+static void sched_yield_random(void)
+{
+	for (unsigned i = sched_rand() % 4; i > 0; i--)
+		sched_yield();
+}
+#endif
+
+#if SYZ_EXECUTOR || SYZ_SCHED_DELAY
+static void sched_delay_random(void)
+{
+	usleep(sched_rand() % 1000);
+}
+#endif
+#endif
+
 #if GOOS_freebsd || GOOS_darwin || GOOS_netbsd || GOOS_openbsd || GOOS_test
 #if SYZ_EXECUTOR || SYZ_THREADED
 
@@ -538,9 +592,17 @@ static int running;
 static void* thr(void* arg)
 {
 	struct thread_t* th = (struct thread_t*)arg;
+#if SYZ_SCHED_PIN
+	sched_pin_thread(0);
+#elif SYZ_SCHED_SPREAD
+	sched_pin_thread(th - threads);
+#endif
 	for (;;) {
 		event_wait(&th->ready);
 		event_reset(&th->ready);
+#if SYZ_SCHED_YIELD
+		sched_yield_random();
+#endif
 		execute_call(th->call);
 		__atomic_fetch_sub(&running, 1, __ATOMIC_RELAXED);
 		event_set(&th->done);
@@ -577,8 +639,12 @@ static void loop(void)
 			__atomic_fetch_add(&running, 1, __ATOMIC_RELAXED);
 			event_set(&th->ready);
 #if SYZ_ASYNC
-			if (/*{{{ASYNC_CONDITIONS}}}*/)
+			if (/*{{{ASYNC_CONDITIONS}}}*/) {
+#if SYZ_SCHED_DELAY
+				sched_delay_random();
+#endif
 				break;
+			}
 #endif
 			event_timedwait(&th->done, /*{{{CALL_TIMEOUT_MS}}}*/);
 			break;
@@ -10,18 +10,214 @@
 #include <string.h>
 #include <sys/select.h>
 #include <sys/socket.h>
+#include <sys/stat.h>
 
+#include <optional>
 #include <vector>
 
+// Select is a wrapper around select system call.
+class Select
+{
+public:
+	Select()
+	{
+		FD_ZERO(&rdset_);
+	}
+
+	void Arm(int fd)
+	{
+		FD_SET(fd, &rdset_);
+		max_fd_ = std::max(max_fd_, fd);
+	}
+
+	bool Ready(int fd) const
+	{
+		return FD_ISSET(fd, &rdset_);
+	}
+
+	void Wait(int ms)
+	{
+		timespec timeout = {.tv_sec = ms / 1000, .tv_nsec = (ms % 1000) * 1000 * 1000};
+		for (;;) {
+			if (pselect(max_fd_ + 1, &rdset_, nullptr, nullptr, &timeout, nullptr) >= 0)
+				break;
+
+			if (errno != EINTR && errno != EAGAIN)
+				fail("pselect failed");
+		}
+	}
+
+	static void Prepare(int fd)
+	{
+		if (fcntl(fd, F_SETFL, fcntl(fd, F_GETFL, 0) | O_NONBLOCK))
+			fail("fcntl(O_NONBLOCK) failed");
+	}
+
+private:
+	fd_set rdset_;
+	int max_fd_ = -1;
+
+	Select(const Select&) = delete;
+	Select& operator=(const Select&) = delete;
+};
+
+// ShmemRing is one direction of the shared memory transport used by executors running
+// on the same host as the manager (see pkg/flatrpc/shmem_linux.go for the description).
+// The layout must match the Go implementation.
+class ShmemRing
+{
+public:
+	static constexpr uint64 kSize = 8 << 20;
+	static constexpr uint64 kHeader = 128;
+	static constexpr uint64 kTail = 64;
+
+	// bell is notified when the ring becomes non-empty, space_bell is notified when the ring becomes non-full.
+	ShmemRing(char* mem, int bell, int space_bell)
+	    : head_(reinterpret_cast<uint64*>(mem)),
+	      tail_(reinterpret_cast<uint64*>(mem + kTail)),
+	      data_(mem + kHeader),
+	      bell_(bell),
+	      space_bell_(space_bell)
+	{
+		for (int fd : {bell_, space_bell_}) {
+			Select::Prepare(fd);
+			if (fcntl(fd, F_SETFD, FD_CLOEXEC))
+				fail("fcntl(FD_CLOEXEC) failed");
+		}
+	}
+
+	int Bell() const
+	{
+		return bell_;
+	}
+
+	bool Empty() const
+	{
+		return __atomic_load_n(head_, __ATOMIC_SEQ_CST) == __atomic_load_n(tail_, __ATOMIC_SEQ_CST);
+	}
+
+	bool Full() const
+	{
+		return __atomic_load_n(head_, __ATOMIC_SEQ_CST) - __atomic_load_n(tail_, __ATOMIC_SEQ_CST) == kSize;
+	}
+
+	// Write writes up to size bytes and returns the number of written bytes (0 if the ring is full).
+	size_t Write(const char* data, size_t size)
+	{
+		uint64 head = __atomic_load_n(head_, __ATOMIC_SEQ_CST);
+		uint64 n = std::min<uint64>(size, kSize - (head - __atomic_load_n(tail_, __ATOMIC_SEQ_CST)));
+		if (n == 0)
+			return 0;
+		uint64 pos = head % kSize;
+		uint64 first = std::min(n, kSize - pos);
+		memcpy(data_ + pos, data, first);
+		memcpy(data_, data + first, n - first);
+		__atomic_store_n(head_, head + n, __ATOMIC_SEQ_CST);
+		// If the reader has consumed all previous data, it may be blocked on the doorbell.
+		if (__atomic_load_n(tail_, __ATOMIC_SEQ_CST) == head)
+			Ring(bell_);
+		return n;
+	}
+
+	// Read reads up to size bytes and returns the number of read bytes (0 if the ring is empty).
+	size_t Read(char* data, size_t size)
+	{
+		uint64 tail = __atomic_load_n(tail_, __ATOMIC_SEQ_CST);
+		uint64 n = std::min<uint64>(size, __atomic_load_n(head_, __ATOMIC_SEQ_CST) - tail);
+		if (n == 0)
+			return 0;
+		uint64 pos = tail % kSize;
+		uint64 first = std::min(n, kSize - pos);
+		memcpy(data, data_ + pos, first);
+		memcpy(data + first, data_, n - first);
+		__atomic_store_n(tail_, tail + n, __ATOMIC_SEQ_CST);
+		// If the ring was full, the writer may be blocked on the space doorbell.
+		if (__atomic_load_n(head_, __ATOMIC_SEQ_CST) - tail == kSize)
+			Ring(space_bell_);
+		return n;
+	}
+
+	// DrainBell consumes all pending doorbell notifications.
+	void DrainBell()
+	{
+		Drain(bell_);
+	}
+
+	// Wait blocks until new data is written into the ring.
+	void Wait()
+	{
+		while (Empty())
+			Block(bell_);
+	}
+
+	// WaitSpace blocks until some data is consumed from the full ring.
+	void WaitSpace()
+	{
+		while (Full())
+			Block(space_bell_);
+	}
+
+private:
+	uint64* const head_;
+	uint64* const tail_;
+	char* const data_;
+	const int bell_;
+	const int space_bell_;
+
+	static void Ring(int fd)
+	{
+		char b = 0;
+		// EAGAIN means the pipe is full of unconsumed notifications, so the peer will wake up anyway.
+		if (write(fd, &b, 1) != 1 && errno != EAGAIN)
+			fail("shmem doorbell write failed");
+	}
+
+	static void Block(int fd)
+	{
+		pollfd pfd = {.fd = fd, .events = POLLIN};
+		if (poll(&pfd, 1, -1) == -1 && errno != EINTR)
+			fail("shmem doorbell poll failed");
+		Drain(fd);
+	}
+
+	static void Drain(int fd)
+	{
+		char buf[64];
+		for (;;) {
+			ssize_t n = read(fd, buf, sizeof(buf));
+			if (n > 0)
+				continue;
+			if (n == 0)
+				fail("shmem doorbell is closed");
+			if (errno == EINTR)
+				continue;
+			if (errno == EAGAIN)
+				break;
+			fail("shmem doorbell read failed");
+		}
+	}
+
+	ShmemRing(const ShmemRing&) = delete;
+	ShmemRing& operator=(const ShmemRing&) = delete;
+};
+
 // Connection represents a client TCP connection.
 // It connects to the given addr:port and allows to send/receive
 // flatbuffers-encoded messages.
+// If addr is "shmem", then port is the first of 5 file descriptors passed by the manager
+// for the shared memory transport (shared memory, host->executor and executor->host doorbells,
+// host->executor and executor->host space doorbells).
 class Connection
 {
 public:
 	Connection(const char* addr, const char* port)
-	    : fd_(Connect(addr, port))
 	{
+		if (!strcmp(addr, "shmem")) {
+			ConnectShmem(atoi(port));
+			fd_ = recv_ring_->Bell();
+		} else {
+			fd_ = Connect(addr, port);
+		}
 	}
 
 	int FD() const
@@ -29,6 +225,27 @@ public:
 		return fd_;
 	}
 
+	// Arm, Pending and Ready are used to wait for incoming messages in the Select loop:
+	// with the shared memory transport data may be already available without a doorbell notification.
+	void Arm(Select& select) const
+	{
+		select.Arm(fd_);
+	}
+
+	bool Pending() const
+	{
+		return recv_ring_ && !recv_ring_->Empty();
+	}
+
+	bool Ready(const Select& select)
+	{
+		if (!recv_ring_)
+			return select.Ready(fd_);
+		if (select.Ready(fd_))
+			recv_ring_->DrainBell();
+		return Pending();
+	}
+
 	template <typename Msg>
 	void Send(const Msg& msg)
 	{
@@ -55,6 +272,15 @@ public:
 
 	void Send(const void* data, size_t size)
 	{
+		if (send_ring_) {
+			for (size_t sent = 0; sent < size;) {
+				size_t n = send_ring_->Write(static_cast<const char*>(data) + sent, size - sent);
+				if (n == 0)
+					send_ring_->WaitSpace();
+				sent += n;
+			}
+			return;
+		}
 		for (size_t sent = 0; sent < size;) {
 			ssize_t n = write(fd_, static_cast<const char*>(data) + sent, size - sent);
 			if (n > 0) {
@@ -72,12 +298,24 @@ public:
 	}
 
 private:
-	const int fd_;
+	int fd_ = -1;
+	std::optional<ShmemFile> shmem_;
+	std::optional<ShmemRing> send_ring_;
+	std::optional<ShmemRing> recv_ring_;
 	std::vector<char> recv_buf_;
 	flatbuffers::FlatBufferBuilder fbb_;
 
 	void Recv(void* data, size_t size)
 	{
+		if (recv_ring_) {
+			for (size_t recv = 0; recv < size;) {
+				size_t n = recv_ring_->Read(static_cast<char*>(data) + recv, size - recv);
+				if (n == 0)
+					recv_ring_->Wait();
+				recv += n;
+			}
+			return;
+		}
 		for (size_t recv = 0; recv < size;) {
 			ssize_t n = read(fd_, static_cast<char*>(data) + recv, size - recv);
 			if (n > 0) {
@@ -94,6 +332,19 @@ private:
 		}
 	}
 
+	void ConnectShmem(int fd)
+	{
+		const size_t size = 2 * (ShmemRing::kHeader + ShmemRing::kSize);
+		struct stat statbuf;
+		if (fd <= STDERR_FILENO || fstat(fd, &statbuf) || static_cast<size_t>(statbuf.st_size) != size)
+			failmsg("bad shmem fd", "fd=%d", fd);
+		shmem_.emplace(fd, nullptr, size, true);
+		close(fd);
+		char* mem = static_cast<char*>(shmem_->Mem());
+		recv_ring_.emplace(mem, fd + 1, fd + 3);
+		send_ring_.emplace(mem + ShmemRing::kHeader + ShmemRing::kSize, fd + 2, fd + 4);
+	}
+
 	static int Connect(const char* addr, const char* ports)
 	{
 		int port = atoi(ports);
@@ -185,49 +436,3 @@ private:
 		return 0;
 	}
 };
-
-// Select is a wrapper around select system call.
-class Select
-{
-public:
-	Select()
-	{
-		FD_ZERO(&rdset_);
-	}
-
-	void Arm(int fd)
-	{
-		FD_SET(fd, &rdset_);
-		max_fd_ = std::max(max_fd_, fd);
-	}
-
-	bool Ready(int fd) const
-	{
-		return FD_ISSET(fd, &rdset_);
-	}
-
-	void Wait(int ms)
-	{
-		timespec timeout = {.tv_sec = ms / 1000, .tv_nsec = (ms % 1000) * 1000 * 1000};
-		for (;;) {
-			if (pselect(max_fd_ + 1, &rdset_, nullptr, nullptr, &timeout, nullptr) >= 0)
-				break;
-
-			if (errno != EINTR && errno != EAGAIN)
-				fail("pselect failed");
-		}
-	}
-
-	static void Prepare(int fd)
-	{
-		if (fcntl(fd, F_SETFL, fcntl(fd, F_GETFL, 0) | O_NONBLOCK))
-			fail("fcntl(O_NONBLOCK) failed");
-	}
-
-private:
-	fd_set rdset_;
-	int max_fd_ = -1;
-
-	Select(const Select&) = delete;
-	Select& operator=(const Select&) = delete;
-};
@@ -277,6 +277,10 @@ static bool flag_collect_cover;
 static bool flag_collect_signal;
 static bool flag_dedup_cover;
 static bool flag_threaded;
+static bool flag_sched_yield;
+static bool flag_sched_pin;
+static bool flag_sched_spread;
+static bool flag_sched_delay;
 
 // If true, then executor should write the comparisons data to fuzzer.
 static bool flag_comparisons;
@@ -325,6 +329,8 @@ static int running;
 static uint32 completed;
 static bool is_kernel_64_bit;
 static bool use_cover_edges;
+// Send signal/cover in CallInfoRaw.signal_packed/cover_packed.
+static bool use_compact_cover;
 
 static uint8* input_data;
 
@@ -415,6 +421,7 @@ struct handshake_req {
 	uint64 syscall_timeout_ms;
 	uint64 program_timeout_ms;
 	uint64 slowdown_scale;
+	bool compact_cover;
 };
 
 struct execute_req {
@@ -771,6 +778,7 @@ void parse_handshake(const handshake_req& req)
 #endif
 	is_kernel_64_bit = req.is_kernel_64_bit;
 	use_cover_edges = req.use_cover_edges;
+	use_compact_cover = req.compact_cover;
 	procid = req.pid;
 	syscall_timeout_ms = req.syscall_timeout_ms;
 	program_timeout_ms = req.program_timeout_ms;
@@ -814,6 +822,10 @@ void parse_execute(const execute_req& req)
 	flag_dedup_cover = req.exec_flags & (1 << 2);
 	flag_comparisons = req.exec_flags & (1 << 3);
 	flag_threaded = req.exec_flags & (1 << 4);
+	flag_sched_yield = req.exec_flags & (1 << 5);
+	flag_sched_pin = req.exec_flags & (1 << 6);
+	flag_sched_spread = req.exec_flags & (1 << 7);
+	flag_sched_delay = req.exec_flags & (1 << 8);
 	all_call_signal = req.all_call_signal;
 	all_extra_signal = req.all_extra_signal;
 
@@ -1043,6 +1055,10 @@ void execute_one()
 			// Don't wait for an async call to finish. We'll wait at the end.
 			// If we're not in the threaded mode, just ignore the async flag - during repro simplification syzkaller
 			// will anyway try to make it non-threaded.
+#if !GOOS_windows
+			if (flag_sched_delay)
+				sched_delay_random();
+#endif
 		} else if (flag_threaded) {
 			// Wait for call completion.
 			uint64 timeout_ms = syscall_timeout_ms + call->attrs.timeout * slowdown_scale;
@@ -1166,18 +1182,36 @@ thread_t* schedule_call(int call_index, int call_num, uint64 copyout_index, uint
 	return th;
 }
 
+// Scratch buffer for signal/cover in the compact encoding.
+static std::vector<uint8> packed_cover;
+
+// Appends zigzag varint of the difference between pc and prev
+// (see CallInfoRaw.signal_packed for description of the format).
+static void pack_cover(uint64 pc, uint64 prev)
+{
+	uint64 delta = pc - prev;
+	uint64 v = (delta << 1) ^ (0 - (delta >> 63));
+	for (; v >= 0x80; v >>= 7)
+		packed_cover.push_back(static_cast<uint8>(v | 0x80));
+	packed_cover.push_back(static_cast<uint8>(v));
+}
+
 template <typename cover_data_t>
 uint32 write_signal(flatbuffers::FlatBufferBuilder& fbb, int index, cover_t* cov, bool all)
 {
 	// Write out feedback signals.
 	// Currently it is code edges computed as xor of two subsequent basic block PCs.
-	fbb.StartVector(0, sizeof(uint64));
+	if (use_compact_cover)
+		packed_cover.clear();
+	else
+		fbb.StartVector(0, sizeof(uint64));
 	cover_data_t* cover_data = (cover_data_t*)(cov->data + cov->data_offset);
 	if ((char*)(cover_data + cov->size) > cov->data_end)
 		failmsg("too much cover", "cov=%u", cov->size);
 	uint32 nsig = 0;
 	cover_data_t prev_pc = 0;
 	bool prev_filter = true;
+	uint64 prev_sig = 0;
 	for (uint32 i = 0; i < cov->size; i++) {
 		cover_data_t pc = cover_data[i] + cov->pc_offset;
 		uint64 sig = pc;
@@ -1196,9 +1230,16 @@ uint32 write_signal(flatbuffers::FlatBufferBuilder& fbb, int index, cover_t* cov
 			continue;
 		if (!all && max_signal && max_signal->Contains(sig))
 			continue;
-		fbb.PushElement(uint64(sig));
+		if (use_compact_cover) {
+			pack_cover(sig, prev_sig);
+			prev_sig = sig;
+		} else {
+			fbb.PushElement(uint64(sig));
+		}
 		nsig++;
 	}
+	if (use_compact_cover)
+		return fbb.CreateVector(packed_cover).o;
 	return fbb.EndVector(nsig);
 }
 
@@ -1212,6 +1253,16 @@ uint32 write_cover(flatbuffers::FlatBufferBuilder& fbb, cover_t* cov)
 		std::sort(cover_data, end);
 		cover_size = std::unique(cover_data, end) - cover_data;
 	}
+	if (use_compact_cover) {
+		packed_cover.clear();
+		uint64 prev = 0;
+		for (uint32 i = 0; i < cover_size; i++) {
+			uint64 pc = cover_data[i] + cov->pc_offset;
+			pack_cover(pc, prev);
+			prev = pc;
+		}
+		return fbb.CreateVector(packed_cover).o;
+	}
 	fbb.StartVector(cover_size, sizeof(uint64));
 	// Flatbuffer arrays are written backwards, so reverse the order on our side as well.
 	for (uint32 i = 0; i < cover_size; i++)
@@ -1349,9 +1400,13 @@ void write_output(int index, cover_t* cov, rpc::CallFlag flags, uint32 error, bo
 		flags |= rpc::CallFlag::CoverageOverflow;
 	builder.add_flags(flags);
 	builder.add_error(error);
-	if (signal_off)
+	if (signal_off && use_compact_cover)
+		builder.add_signal_packed(signal_off);
+	else if (signal_off)
 		builder.add_signal(signal_off);
-	if (cover_off)
+	if (cover_off && use_compact_cover)
+		builder.add_cover_packed(cover_off);
+	else if (cover_off)
 		builder.add_cover(cover_off);
 	if (comps_off)
 		builder.add_comps(comps_off);
@@ -1475,6 +1530,14 @@ void* worker_thread(void* arg)
 		// because in snapshot mode we don't know coverage mode for precreated threads.
 		if (first && cover_collection_required())
 			cover_enable(&th->cov, flag_comparisons, false);
+#if !GOOS_windows
+		if (flag_sched_pin)
+			sched_pin_thread(0);
+		else if (flag_sched_spread)
+			sched_pin_thread(th->id);
+		if (flag_sched_yield)
+			sched_yield_random();
+#endif
 		execute_call(th);
 		event_set(&th->done);
 	}
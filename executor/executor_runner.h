@@ -82,14 +82,79 @@ private:
 	ProcIDPool& operator=(const ProcIDPool&) = delete;
 };
 
+// Max number of requests we accept in a single ExecBatch message (reported in ConnectRequestRaw.max_batch).
+constexpr int kMaxBatch = 64;
+
+// Max total size of files returned for a single ReadFile request.
+constexpr size_t kMaxReadFileOutput = 256 << 10;
+
+// ResultBatch sends results of finished requests to the host.
+// If the host requested batching during handshake (ConnectReplyRaw.batch_size), results are
+// accumulated and sent in a single ExecBatchResult message once per Runner::Loop iteration
+// (or when the batch is full), instead of sending a message per request.
+class ResultBatch
+{
+public:
+	ResultBatch(Connection& conn)
+	    : conn_(conn)
+	{
+	}
+
+	void SetSize(int size)
+	{
+		size_ = size;
+	}
+
+	// Send sends (or queues) the ExecutorMessageRaw with ExecResult produced by finish_output.
+	void Send(flatbuffers::span<uint8_t> data)
+	{
+		if (size_ <= 1) {
+			conn_.Send(data.data(), data.size());
+			return;
+		}
+		// The message is built from the output of the test process and may be corrupted.
+		// Unpacking it is not safe, so we send it as is and let the host detect the corruption.
+		flatbuffers::Verifier verifier(data.data(), data.size());
+		auto* msg = flatbuffers::GetSizePrefixedRoot<rpc::ExecutorMessageRaw>(data.data());
+		if (!verifier.VerifySizePrefixedBuffer<rpc::ExecutorMessageRaw>(nullptr) || !msg->msg_as_ExecResult()) {
+			Flush();
+			conn_.Send(data.data(), data.size());
+			return;
+		}
+		batch_.results.emplace_back(msg->msg_as_ExecResult()->UnPack());
+		if (batch_.results.size() >= static_cast<size_t>(size_))
+			Flush();
+	}
+
+	void Flush()
+	{
+		if (batch_.results.empty())
+			return;
+		debug("sending %zu batched results\n", batch_.results.size());
+		rpc::ExecutorMessageRawT raw;
+		raw.msg.Set(std::move(batch_));
+		conn_.Send(raw);
+		batch_.results.clear();
+	}
+
+private:
+	Connection& conn_;
+	int size_ = 0;
+	rpc::ExecBatchResultRawT batch_;
+
+	ResultBatch(const ResultBatch&) = delete;
+	ResultBatch& operator=(const ResultBatch&) = delete;
+};
+
 // Proc represents one subprocess that runs tests (re-execed syz-executor with 'exec' argument).
 // The object is persistent and re-starts subprocess when it crashes.
 class Proc
 {
 public:
-	Proc(Connection& conn, const char* bin, ProcIDPool& proc_id_pool, int& restarting, const bool& corpus_triaged, int max_signal_fd, int cover_filter_fd,
-	     bool use_cover_edges, bool is_kernel_64_bit, uint32 slowdown, uint32 syscall_timeout_ms, uint32 program_timeout_ms)
+	Proc(Connection& conn, ResultBatch& results, const char* bin, ProcIDPool& proc_id_pool, int& restarting, const bool& corpus_triaged, int max_signal_fd, int cover_filter_fd,
+	     bool use_cover_edges, bool is_kernel_64_bit, bool compact_cover, uint32 slowdown, uint32 syscall_timeout_ms, uint32 program_timeout_ms)
 	    : conn_(conn),
+	      results_(results),
 	      bin_(bin),
 	      proc_id_pool_(proc_id_pool),
 	      id_(proc_id_pool.Alloc()),
@@ -99,6 +164,7 @@ public:
 	      cover_filter_fd_(cover_filter_fd),
 	      use_cover_edges_(use_cover_edges),
 	      is_kernel_64_bit_(is_kernel_64_bit),
+	      compact_cover_(compact_cover),
 	      slowdown_(slowdown),
 	      syscall_timeout_ms_(syscall_timeout_ms),
 	      program_timeout_ms_(program_timeout_ms),
@@ -193,6 +259,7 @@ private:
 	};
 
 	Connection& conn_;
+	ResultBatch& results_;
 	const char* const bin_;
 	ProcIDPool& proc_id_pool_;
 	int id_;
@@ -202,6 +269,7 @@ private:
 	const int cover_filter_fd_;
 	const bool use_cover_edges_;
 	const bool is_kernel_64_bit_;
+	const bool compact_cover_;
 	const uint32 slowdown_;
 	const uint32 syscall_timeout_ms_;
 	const uint32 program_timeout_ms_;
@@ -365,6 +433,7 @@ private:
 		    .syscall_timeout_ms = syscall_timeout_ms_,
 		    .program_timeout_ms = ProgramTimeoutMs(),
 		    .slowdown_scale = slowdown_,
+		    .compact_cover = compact_cover_,
 		};
 		if (write(req_pipe_, &req, sizeof(req)) != sizeof(req)) {
 			debug("request pipe write failed (errno=%d)\n", errno);
@@ -445,7 +514,7 @@ private:
 		if (msg_->type == rpc::RequestType::Program)
 			num_calls = read_input(&prog_data);
 		auto data = finish_output(resp_mem_, id_, msg_->id, num_calls, elapsed, freshness_++, status, hanged, output);
-		conn_.Send(data.data(), data.size());
+		results_.Send(data);
 
 		resp_mem_->Reset();
 		msg_.reset();
@@ -537,15 +606,16 @@ class Runner
 public:
 	Runner(Connection& conn, int vm_index, const char* bin)
 	    : conn_(conn),
-	      vm_index_(vm_index)
+	      vm_index_(vm_index),
+	      results_(conn)
 	{
 		int num_procs = Handshake();
 		proc_id_pool_.emplace(num_procs);
 		int max_signal_fd = max_signal_ ? max_signal_->FD() : -1;
 		int cover_filter_fd = cover_filter_ ? cover_filter_->FD() : -1;
 		for (int i = 0; i < num_procs; i++)
-			procs_.emplace_back(new Proc(conn, bin, *proc_id_pool_, restarting_, corpus_triaged_,
-						     max_signal_fd, cover_filter_fd, use_cover_edges_, is_kernel_64_bit_, slowdown_,
+			procs_.emplace_back(new Proc(conn, results_, bin, *proc_id_pool_, restarting_, corpus_triaged_,
+						     max_signal_fd, cover_filter_fd, use_cover_edges_, is_kernel_64_bit_, compact_cover_, slowdown_,
 						     syscall_timeout_ms_, program_timeout_ms_));
 
 		for (;;)
@@ -555,6 +625,7 @@ public:
 private:
 	Connection& conn_;
 	const int vm_index_;
+	ResultBatch results_;
 	std::optional<CoverFilter> max_signal_;
 	std::optional<CoverFilter> cover_filter_;
 	std::optional<ProcIDPool> proc_id_pool_;
@@ -565,6 +636,7 @@ private:
 	bool corpus_triaged_ = false;
 	bool use_cover_edges_ = false;
 	bool is_kernel_64_bit_ = false;
+	bool compact_cover_ = false;
 	uint32 slowdown_ = 0;
 	uint32 syscall_timeout_ms_ = 0;
 	uint32 program_timeout_ms_ = 0;
@@ -578,6 +650,7 @@ private:
 		   << " corpus_triaged=" << runner.corpus_triaged_
 		   << " use_cover_edges=" << runner.use_cover_edges_
 		   << " is_kernel_64_bit=" << runner.is_kernel_64_bit_
+		   << " compact_cover=" << runner.compact_cover_
 		   << " slowdown=" << runner.slowdown_
 		   << " syscall_timeout_ms=" << runner.syscall_timeout_ms_
 		   << " program_timeout_ms=" << runner.program_timeout_ms_
@@ -594,19 +667,21 @@ private:
 	void Loop()
 	{
 		Select select;
-		select.Arm(conn_.FD());
+		conn_.Arm(select);
 		for (auto& proc : procs_)
 			proc->Arm(select);
 		// Wait for ready host connection and subprocess pipes.
 		// Timeout is for terminating hanged subprocesses.
-		select.Wait(1000);
+		select.Wait(conn_.Pending() ? 0 : 1000);
 		uint64 now = current_time_ms();
 
-		if (select.Ready(conn_.FD())) {
+		if (conn_.Ready(select)) {
 			rpc::HostMessageRawT raw;
 			conn_.Recv(raw);
 			if (auto* msg = raw.msg.AsExecRequest())
 				Handle(*msg);
+			else if (auto* msg = raw.msg.AsExecBatch())
+				Handle(*msg);
 			else if (auto* msg = raw.msg.AsSignalUpdate())
 				Handle(*msg);
 			else if (auto* msg = raw.msg.AsCorpusTriaged())
@@ -624,6 +699,7 @@ private:
 					requests_.pop_front();
 			}
 		}
+		results_.Flush();
 
 		if (restarting_ < 0 || restarting_ > static_cast<int>(procs_.size()))
 			failmsg("bad restarting", "restarting=%d", restarting_);
@@ -651,6 +727,8 @@ private:
 		conn_req.arch = GOARCH;
 		conn_req.git_revision = GIT_REVISION;
 		conn_req.syz_revision = SYZ_REVISION;
+		conn_req.max_batch = kMaxBatch;
+		conn_req.compact_cover = true;
 		conn_.Send(conn_req);
 
 		rpc::ConnectReplyRawT conn_reply;
@@ -658,13 +736,18 @@ private:
 		if (conn_reply.debug)
 			flag_debug = true;
 		debug("connected to manager: procs=%d cover_edges=%d kernel_64_bit=%d slowdown=%d syscall_timeout=%u"
-		      " program_timeout=%u features=0x%llx\n",
+		      " program_timeout=%u features=0x%llx batch_size=%d compact_cover=%d\n",
 		      conn_reply.procs, conn_reply.cover_edges, conn_reply.kernel_64_bit,
 		      conn_reply.slowdown, conn_reply.syscall_timeout_ms,
-		      conn_reply.program_timeout_ms, static_cast<uint64>(conn_reply.features));
+		      conn_reply.program_timeout_ms, static_cast<uint64>(conn_reply.features),
+		      conn_reply.batch_size, conn_reply.compact_cover);
+		if (conn_reply.batch_size < 0 || conn_reply.batch_size > kMaxBatch)
+			failmsg("bad batch size", "batch_size=%d", conn_reply.batch_size);
+		results_.SetSize(conn_reply.batch_size);
 		leak_frames_ = conn_reply.leak_frames;
 		use_cover_edges_ = conn_reply.cover_edges;
 		is_kernel_64_bit_ = is_kernel_64_bit = conn_reply.kernel_64_bit;
+		compact_cover_ = conn_reply.compact_cover;
 		slowdown_ = conn_reply.slowdown;
 		syscall_timeout_ms_ = conn_reply.syscall_timeout_ms;
 		program_timeout_ms_ = conn_reply.program_timeout_ms;
@@ -741,6 +824,10 @@ private:
 			ExecuteBinary(msg);
 			return;
 		}
+		if (msg.type == rpc::RequestType::ReadFile) {
+			ExecuteReadFile(msg);
+			return;
+		}
 		for (auto& proc : procs_) {
 			if (proc->Execute(msg))
 				return;
@@ -748,6 +835,13 @@ private:
 		requests_.push_back(std::move(msg));
 	}
 
+	void Handle(rpc::ExecBatchRawT& msg)
+	{
+		debug("recv exec batch: requests=%zu\n", msg.requests.size());
+		for (auto& req : msg.requests)
+			Handle(*req);
+	}
+
 	void Handle(const rpc::SignalUpdateRawT& msg)
 	{
 		debug("recv signal update: new=%zu\n", msg.new_max.size());
@@ -806,6 +900,33 @@ private:
 		conn_.Send(raw);
 	}
 
+	void ExecuteReadFile(rpc::ExecRequestRawT& msg)
+	{
+		rpc::ExecutingMessageRawT exec;
+		exec.id = msg.id;
+		rpc::ExecutorMessageRawT raw;
+		raw.msg.Set(std::move(exec));
+		conn_.Send(raw);
+
+		// Data contains 0-terminated file names/glob patterns.
+		std::vector<std::string> files;
+		for (size_t pos = 0; pos < msg.data.size();) {
+			const char* name = reinterpret_cast<const char*>(msg.data.data() + pos);
+			size_t len = strnlen(name, msg.data.size() - pos);
+			if (len != 0)
+				files.emplace_back(name, len);
+			pos += len + 1;
+		}
+		rpc::ExecResultRawT res;
+		res.id = msg.id;
+		// Files are read on the runner loop, which blocks handling of all other requests meanwhile.
+		// So we use non-blocking reads and a small total size limit, so that large, endless
+		// (e.g. /dev/zero) or blocking (e.g. tracing/trace_pipe) files don't stall the runner.
+		res.files = ReadFiles(files, kMaxReadFileOutput, true);
+		raw.msg.Set(std::move(res));
+		conn_.Send(raw);
+	}
+
 	std::tuple<std::string, std::vector<uint8_t>> ExecuteBinaryImpl(rpc::ExecRequestRawT& msg, const char* dir)
 	{
 		// For simplicity we just wait for binary tests to complete blocking everything else.
@@ -903,7 +1024,7 @@ static void FatalHandler(int sig, siginfo_t* info, void* ucontext)
 static void runner(char** argv, int argc)
 {
 	if (argc != 5)
-		fail("usage: syz-executor runner <index> <manager-addr> <manager-port>");
+		fail("usage: syz-executor runner <index> <manager-addr|shmem> <manager-port|shmem-fd>");
 	char* endptr = nullptr;
 	int vm_index = strtol(argv[2], &endptr, 10);
 	if (vm_index < 0 || *endptr != 0)
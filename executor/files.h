@@ -1,6 +1,7 @@
 // Copyright 2024 syzkaller project authors. All rights reserved.
 // Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
 
+#include <algorithm>
 #include <memory>
 #include <string>
 #include <utility>
@@ -11,6 +12,7 @@
 #include <fcntl.h>
 #include <glob.h>
 #include <stdarg.h>
+#include <stdint.h>
 #include <string.h>
 #include <unistd.h>
 
@@ -60,25 +62,39 @@ static std::vector<std::string> Glob(const std::string& pattern)
 	return files;
 }
 
-static std::unique_ptr<rpc::FileInfoRawT> ReadFile(const std::string& file)
+// ReadFile reads at most limit bytes of the file, the rest of the file is not read
+// (files like /dev/zero never end). If nonblock is set, the file is read in non-blocking mode
+// and reading stops once no more data is available, so that files like FIFOs, /dev/kmsg
+// or tracing/trace_pipe don't block the caller (such files are marked as truncated).
+static std::unique_ptr<rpc::FileInfoRawT> ReadFile(const std::string& file, size_t limit = SIZE_MAX, bool nonblock = false)
 {
 	auto info = std::make_unique<rpc::FileInfoRawT>();
 	info->name = file;
-	int fd = open(file.c_str(), O_RDONLY);
+	int fd = open(file.c_str(), O_RDONLY | (nonblock ? O_NONBLOCK : 0));
 	if (fd == -1) {
 		info->exists = errno != EEXIST && errno != ENOENT;
 		info->error = strerror(errno);
 	} else {
 		info->exists = true;
 		for (;;) {
-			constexpr size_t kChunk = 4 << 10;
-			info->data.resize(info->data.size() + kChunk);
-			ssize_t n = read(fd, info->data.data() + info->data.size() - kChunk, kChunk);
+			if (info->data.size() >= limit) {
+				char tmp;
+				if (read(fd, &tmp, 1) > 0)
+					info->error = "file is too large, truncated";
+				break;
+			}
+			const size_t chunk = std::min<size_t>(4 << 10, limit - info->data.size());
+			info->data.resize(info->data.size() + chunk);
+			ssize_t n = read(fd, info->data.data() + info->data.size() - chunk, chunk);
 			if (n < 0) {
-				info->error = strerror(errno);
+				info->data.resize(info->data.size() - chunk);
+				if (nonblock && errno == EAGAIN)
+					info->error = "no more data available, truncated";
+				else
+					info->error = strerror(errno);
 				break;
 			}
-			info->data.resize(info->data.size() - kChunk + n);
+			info->data.resize(info->data.size() - chunk + n);
 			if (n == 0)
 				break;
 		}
@@ -104,16 +120,22 @@ static std::string ReadTextFile(const char* file_fmt, ...)
 	return str;
 }
 
-static std::vector<std::unique_ptr<rpc::FileInfoRawT>> ReadFiles(const std::vector<std::string>& files)
+// ReadFiles reads at most limit bytes in total from all files (see ReadFile for nonblock).
+static std::vector<std::unique_ptr<rpc::FileInfoRawT>> ReadFiles(const std::vector<std::string>& files, size_t limit = SIZE_MAX, bool nonblock = false)
 {
 	std::vector<std::unique_ptr<rpc::FileInfoRawT>> results;
+	size_t total = 0;
+	auto read_file = [&](const std::string& file) {
+		results.push_back(ReadFile(file, limit - total, nonblock));
+		total += results.back()->data.size();
+	};
 	for (const auto& file : files) {
 		if (!strchr(file.c_str(), '*')) {
-			results.push_back(ReadFile(file));
+			read_file(file);
 			continue;
 		}
 		for (const auto& match : Glob(file))
-			results.push_back(ReadFile(match));
+			read_file(match);
 	}
 	return results;
 }
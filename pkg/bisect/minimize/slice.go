@@ -9,6 +9,7 @@ import (
 	"math"
 	"slices"
 	"strings"
+	"sync"
 )
 
 type Config[T any] struct {
@@ -16,13 +17,19 @@ type Config[T any] struct {
 	// If Pred(X) returns true, X is assumed to contain all elements that must stay.
 	Pred func([]T) (bool, error)
 	// MaxSteps is a limit on the number of predicate calls during bisection.
-	// If it's hit, the bisection continues as if Pred() begins to return false.
+	// If it's hit, the bisection continues as if Pred() begins to return false
+	// (with Parallel > 1, the intermediate result is returned right away).
 	// If it's set to 0 (by default), no limit is applied.
 	MaxSteps int
 	// MaxChunks sets a limit on the number of chunks pursued by the bisection algorithm.
 	// If we hit the limit, bisection is stopped and Array() returns ErrTooManyChunks
 	// anongside the intermediate bisection result (a valid, but not fully minimized slice).
 	MaxChunks int
+	// Parallel is the maximum number of concurrent Pred() calls.
+	// If it's greater than 1, Pred() must be safe for concurrent use and the ddmin-style
+	// variant of the algorithm is used: it needs more Pred() calls, but fewer sequential steps.
+	// Logf is never called concurrently.
+	Parallel int
 	// Logf is used for sharing debugging output.
 	Logf func(string, ...interface{})
 }
@@ -43,6 +50,9 @@ func Slice[T any](config Config[T], slice []T) ([]T, error) {
 			},
 		},
 	}
+	if config.Parallel > 1 {
+		return ctx.bisectParallel()
+	}
 	return ctx.bisect()
 }
 
@@ -78,6 +88,7 @@ func SliceWithFixed[T any](config Config[T], slice []T, fixed func(T) bool) ([]T
 	newConfig := Config[int]{
 		MaxSteps:  config.MaxSteps,
 		MaxChunks: config.MaxChunks,
+		Parallel:  config.Parallel,
 		Pred: func(idx []int) (bool, error) {
 			return config.Pred(convert(idx))
 		},
@@ -99,6 +110,7 @@ type sliceCtx[T any] struct {
 type arrayChunk[T any] struct {
 	elements []T
 	final    bool // There's no way to further split this chunk.
+	needed   bool // Pred() fails without this chunk (only used by bisectParallel).
 }
 
 // ErrTooManyChunks is returned if the number of necessary chunks surpassed MaxChunks.
@@ -174,6 +186,147 @@ func (ctx *sliceCtx[T]) splitChunks(someNeeded bool) error {
 	return nil
 }
 
+// bisectParallel() is a ddmin-style variant of bisect() that runs up to Parallel predicates at once.
+// On each step it tries to drop every chunk not yet known to be needed, one chunk per Pred() call.
+// All chunks of a batch that can be dropped are removed at once (if Pred() confirms it, otherwise
+// only the first of them), the chunks that could not be dropped are marked as needed
+// (Pred() is assumed to be monotonic, so they stay needed after other chunks are removed).
+// Once all chunks are needed, they are split in two and the process repeats.
+// This takes more Pred() calls than bisect(), but they are done in fewer sequential steps.
+// Once MaxSteps is reached, the current intermediate result is returned.
+func (ctx *sliceCtx[T]) bisectParallel() ([]T, error) {
+	elements := ctx.chunks[0].elements
+	ctx.chunks = nil
+	for _, part := range splitChunk[T](elements, ctx.initialSplit(len(elements))) {
+		ctx.chunks = append(ctx.chunks, &arrayChunk[T]{elements: part})
+	}
+	for {
+		var probe []int
+		for i, chunk := range ctx.chunks {
+			if !chunk.needed {
+				probe = append(probe, i)
+			}
+		}
+		if len(probe) != 0 {
+			ctx.Logf("parallel: trying to drop %d of chunks %s", len(probe), ctx.chunkInfo())
+			dropped, err := ctx.dropChunks(probe)
+			if err != nil {
+				return nil, err
+			}
+			if len(dropped) != 0 {
+				ctx.Logf("chunks %v can be dropped", dropped)
+				ctx.chunks = ctx.chunksWithout(dropped)
+			}
+			if ctx.stepLimit() {
+				ctx.Logf("we have reached the limit on predicate runs (%d); stop bisection", ctx.MaxSteps)
+				return ctx.elements(), nil
+			}
+			continue
+		}
+		if ctx.MaxChunks > 0 && len(ctx.chunks) > ctx.MaxChunks {
+			return ctx.elements(), ErrTooManyChunks
+		}
+		var newChunks []*arrayChunk[T]
+		for _, chunk := range ctx.chunks {
+			if len(chunk.elements) == 1 {
+				chunk.final = true
+				newChunks = append(newChunks, chunk)
+				continue
+			}
+			for _, part := range splitChunk[T](chunk.elements, 2) {
+				newChunks = append(newChunks, &arrayChunk[T]{elements: part})
+			}
+		}
+		if len(newChunks) == len(ctx.chunks) {
+			// All chunks are final.
+			break
+		}
+		ctx.chunks = newChunks
+	}
+	return ctx.elements(), nil
+}
+
+// dropChunks() runs Pred() without each of the chunks (in batches of Parallel concurrent calls)
+// until it finds chunks that can be dropped. If there are several such chunks in a batch,
+// Pred() is run once more without all of them (the ddmin complement step). If it succeeds,
+// all of them are returned, otherwise only the first one. Returns indices of the chunks to drop.
+// Stops early without marking the remaining chunks once MaxSteps is reached.
+func (ctx *sliceCtx[T]) dropChunks(indices []int) ([]int, error) {
+	for len(indices) != 0 && !ctx.stepLimit() {
+		n := min(len(indices), ctx.Parallel)
+		if ctx.MaxSteps > 0 {
+			n = min(n, ctx.MaxSteps-ctx.predRuns)
+		}
+		batch := indices[:n]
+		indices = indices[n:]
+		results := make([]bool, len(batch))
+		errs := make([]error, len(batch))
+		var wg sync.WaitGroup
+		for j, idx := range batch {
+			ctx.predRuns++
+			elements := ctx.elementsWithout([]int{idx})
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[j], errs[j] = ctx.Pred(elements)
+			}()
+		}
+		wg.Wait()
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+		var canDrop []int
+		for j, idx := range batch {
+			if results[j] {
+				canDrop = append(canDrop, idx)
+			} else {
+				ctx.chunks[idx].needed = true
+			}
+		}
+		if len(canDrop) <= 1 {
+			if len(canDrop) == 1 {
+				return canDrop, nil
+			}
+			continue
+		}
+		if ctx.stepLimit() {
+			return canDrop[:1], nil
+		}
+		ctx.predRuns++
+		ok, err := ctx.Pred(ctx.elementsWithout(canDrop))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return canDrop, nil
+		}
+		ctx.Logf("chunks %v can't be dropped together, drop only the first one", canDrop)
+		return canDrop[:1], nil
+	}
+	return nil, nil
+}
+
+// chunksWithout() returns ctx.chunks except for the ones with the given (sorted) indices.
+func (ctx *sliceCtx[T]) chunksWithout(indices []int) []*arrayChunk[T] {
+	var ret []*arrayChunk[T]
+	for i, chunk := range ctx.chunks {
+		if len(indices) != 0 && indices[0] == i {
+			indices = indices[1:]
+			continue
+		}
+		ret = append(ret, chunk)
+	}
+	return ret
+}
+
+func (ctx *sliceCtx[T]) elementsWithout(indices []int) []T {
+	return mergeChunks(ctx.chunksWithout(indices), nil, nil)
+}
+
+func (ctx *sliceCtx[T]) stepLimit() bool {
+	return ctx.MaxSteps > 0 && ctx.predRuns >= ctx.MaxSteps
+}
+
 // Since Pred() runs can be costly, the objective is to get the most out of the
 // limited number of Pred() calls.
 // We try to achieve it by splitting the initial array in more than 2 elements.
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -110,6 +112,129 @@ func TestBisectRandomSlice(t *testing.T) {
 	}
 }
 
+func TestBisectRandomSliceParallel(t *testing.T) {
+	t.Parallel()
+	r := rand.New(testutil.RandSource(t))
+	for i := 0; i < testutil.IterCount(); i++ {
+		size := r.Intn(50)
+		subset := r.Intn(size + 1)
+		parallel := 2 + r.Intn(8)
+		array := make([]int, size)
+		for _, j := range r.Perm(size)[:subset] {
+			array[j] = j + 1
+		}
+		var expect []int
+		for _, j := range array {
+			if j > 0 {
+				expect = append(expect, j)
+			}
+		}
+		var mu sync.Mutex
+		running, maxRunning := 0, 0
+		ret, err := Slice(Config[int]{
+			Parallel: parallel,
+			Pred: func(arr []int) (bool, error) {
+				mu.Lock()
+				running++
+				maxRunning = max(maxRunning, running)
+				mu.Unlock()
+				defer func() {
+					mu.Lock()
+					running--
+					mu.Unlock()
+				}()
+				nonZero := 0
+				for _, x := range arr {
+					if x > 0 {
+						nonZero++
+					}
+				}
+				return nonZero == subset, nil
+			},
+			Logf: t.Logf,
+		}, array)
+		assert.NoError(t, err)
+		assert.EqualValues(t, expect, ret)
+		assert.LessOrEqual(t, maxRunning, parallel)
+	}
+}
+
+func TestBisectSliceParallelMaxChunks(t *testing.T) {
+	t.Parallel()
+	array := make([]int, 100)
+	for i := range array {
+		array[i] = i
+	}
+	ret, err := Slice(Config[int]{
+		Parallel:  4,
+		MaxChunks: 2,
+		Pred: func(arr []int) (bool, error) {
+			values := map[int]bool{}
+			for _, v := range arr {
+				values[v] = true
+			}
+			return values[10] && values[50] && values[90], nil
+		},
+		Logf: t.Logf,
+	}, array)
+	assert.ErrorIs(t, err, ErrTooManyChunks)
+	assert.Subset(t, ret, []int{10, 50, 90})
+}
+
+func TestBisectSliceParallelDropMany(t *testing.T) {
+	t.Parallel()
+	array := make([]int, 256)
+	for i := range array {
+		array[i] = i
+	}
+	var calls atomic.Int64
+	ret, err := Slice(Config[int]{
+		Parallel: 64,
+		Pred: func(arr []int) (bool, error) {
+			calls.Add(1)
+			needed := 0
+			for _, v := range arr {
+				if v%16 == 0 {
+					needed++
+				}
+			}
+			return needed == 16, nil
+		},
+		Logf: t.Logf,
+	}, array)
+	assert.NoError(t, err)
+	assert.Len(t, ret, 16)
+	// Chunks that can be dropped in the same batch are dropped together,
+	// so the remaining chunks are not probed again and again.
+	assert.LessOrEqual(t, calls.Load(), int64(200))
+}
+
+func TestBisectSliceParallelMaxSteps(t *testing.T) {
+	t.Parallel()
+	array := make([]int, 100)
+	for i := range array {
+		array[i] = i
+	}
+	var calls atomic.Int64
+	ret, err := Slice(Config[int]{
+		Parallel: 4,
+		MaxSteps: 10,
+		Pred: func(arr []int) (bool, error) {
+			calls.Add(1)
+			values := map[int]bool{}
+			for _, v := range arr {
+				values[v] = true
+			}
+			return values[10] && values[50] && values[90], nil
+		},
+		Logf: t.Logf,
+	}, array)
+	assert.NoError(t, err)
+	assert.Subset(t, ret, []int{10, 50, 90})
+	assert.Less(t, len(ret), len(array))
+	assert.LessOrEqual(t, calls.Load(), int64(10))
+}
+
 func BenchmarkSplits(b *testing.B) {
 	for _, guilty := range []int{1, 2, 3, 4} {
 		b.Run(fmt.Sprintf("%d_guilty", guilty), func(b *testing.B) {
@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"maps"
 	"sync"
+	"sync/atomic"
 
+	"github.com/google/syzkaller/pkg/corpus/schedule"
 	"github.com/google/syzkaller/pkg/cover"
 	"github.com/google/syzkaller/pkg/hash"
 	"github.com/google/syzkaller/pkg/signal"
@@ -32,6 +34,14 @@ type Corpus struct {
 	StatCover  *stat.Val
 
 	focusAreas []*focusAreaState
+
+	schedule schedule.Schedule
+	seeds    map[*prog.Prog]*seedState
+	rare     *rareSignal // only for schedule.Rare
+	chosen   atomic.Uint64
+	// PC -> call graph distance to the directed fuzzing targets.
+	distances   map[uint64]uint32
+	maxDistance uint32
 }
 
 type focusAreaState struct {
@@ -57,6 +67,7 @@ func NewFocusedCorpus(ctx context.Context, updates chan<- NewItemEvent, areas []
 	corpus := &Corpus{
 		ctx:          ctx,
 		progsMap:     make(map[string]*Item),
+		seeds:        make(map[*prog.Prog]*seedState),
 		updates:      updates,
 		ProgramsList: &ProgramsList{},
 	}
@@ -103,12 +114,40 @@ type Item struct {
 	Updates []ItemUpdate
 
 	areas map[*focusAreaState]struct{}
+	seed  *seedState
 }
 
 func (item Item) StringCall() string {
 	return item.Prog.CallName(item.Call)
 }
 
+// Mutations returns the number of executed mutants of the program.
+func (item Item) Mutations() uint64 {
+	if item.seed == nil {
+		return 0
+	}
+	return item.seed.mutations.Load()
+}
+
+// NewSignalMutations returns the number of mutants of the program that have produced new signal.
+func (item Item) NewSignalMutations() uint64 {
+	if item.seed == nil {
+		return 0
+	}
+	return item.seed.newSignal.Load()
+}
+
+// Distance returns the minimal call graph distance to the directed fuzzing targets
+// among the PCs covered by the program. It returns false if the program does not get
+// close to the targets, or if the corpus is not directed.
+func (item Item) Distance() (uint32, bool) {
+	if item.seed == nil {
+		return 0, false
+	}
+	distance := item.seed.distance.Load()
+	return distance, distance != noDistance
+}
+
 type NewInput struct {
 	Prog     *prog.Prog
 	Call     int
@@ -125,6 +164,21 @@ type NewItemEvent struct {
 }
 
 func (corpus *Corpus) Save(inp NewInput) {
+	corpus.save(inp, true)
+}
+
+// Restore adds a previously saved input to the corpus together with its mutation statistics
+// (see Item.Mutations and Item.NewSignalMutations).
+// Unlike Save, it does not send NewItemEvent since the input is already known to the caller.
+func (corpus *Corpus) Restore(inp NewInput, mutations, newSignal uint64) {
+	seed := corpus.save(inp, false)
+	seed.mutations.Add(mutations)
+	seed.newSignal.Add(newSignal)
+}
+
+// save adds the input to the corpus and returns the state of the corresponding corpus item,
+// which may be an already existing item with the same program.
+func (corpus *Corpus) save(inp NewInput, notify bool) *seedState {
 	progData := inp.Prog.Serialize()
 	sig := hash.String(progData)
 
@@ -136,6 +190,7 @@ func (corpus *Corpus) Save(inp NewInput) {
 		RawCover: inp.RawCover,
 	}
 	exists := false
+	var seed *seedState
 	if old, ok := corpus.progsMap[sig]; ok {
 		exists = true
 		newSignal := old.Signal.Copy()
@@ -152,13 +207,19 @@ func (corpus *Corpus) Save(inp NewInput) {
 			Cover:   newCover.Serialize(),
 			Updates: append([]ItemUpdate{}, old.Updates...),
 			areas:   maps.Clone(old.areas),
+			seed:    old.seed,
 		}
 		const maxUpdates = 32
 		if len(newItem.Updates) < maxUpdates {
 			newItem.Updates = append(newItem.Updates, update)
 		}
 		corpus.progsMap[sig] = newItem
+		if corpus.rare != nil {
+			corpus.rare.add(newItem.seed, newSignal, old.Signal)
+		}
+		newItem.seed.distance.Store(min(newItem.seed.distance.Load(), corpus.coverDistance(inp.Cover)))
 		corpus.applyFocusAreas(newItem, inp.Cover)
+		seed = newItem.seed
 	} else {
 		item := &Item{
 			Sig:     sig,
@@ -168,14 +229,21 @@ func (corpus *Corpus) Save(inp NewInput) {
 			Signal:  inp.Signal,
 			Cover:   inp.Cover,
 			Updates: []ItemUpdate{update},
+			seed:    &seedState{sig: sig},
 		}
+		item.seed.distance.Store(corpus.coverDistance(inp.Cover))
 		corpus.progsMap[sig] = item
+		corpus.seeds[inp.Prog] = item.seed
+		if corpus.rare != nil {
+			corpus.rare.add(item.seed, inp.Signal, nil)
+		}
 		corpus.applyFocusAreas(item, inp.Cover)
-		corpus.saveProgram(inp.Prog, inp.Signal)
+		corpus.saveProgram(inp.Prog, corpus.prio(inp.Prog, inp.Signal))
+		seed = item.seed
 	}
 	corpus.signal.Merge(inp.Signal)
 	newCover := corpus.cover.MergeDiff(inp.Cover)
-	if corpus.updates != nil {
+	if corpus.updates != nil && notify {
 		select {
 		case <-corpus.ctx.Done():
 		case corpus.updates <- NewItemEvent{
@@ -186,6 +254,7 @@ func (corpus *Corpus) Save(inp NewInput) {
 		}:
 		}
 	}
+	return seed
 }
 
 func (corpus *Corpus) applyFocusAreas(item *Item, coverDelta []uint64) {
@@ -200,7 +269,7 @@ func (corpus *Corpus) applyFocusAreas(item *Item, coverDelta []uint64) {
 		if !matches {
 			continue
 		}
-		area.saveProgram(item.Prog, item.Signal)
+		area.saveProgram(item.Prog, corpus.prio(item.Prog, item.Signal))
 		if item.areas == nil {
 			item.areas = make(map[*focusAreaState]struct{})
 			item.areas[area] = struct{}{}
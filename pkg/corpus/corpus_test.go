@@ -76,6 +76,27 @@ func TestCorpusCoverage(t *testing.T) {
 	assert.Equal(t, corpus.StatCover.Val(), 3)
 }
 
+func TestCorpusRestore(t *testing.T) {
+	target := getTarget(t, targets.TestOS, targets.TestArch64)
+	corpus := NewCorpus(context.Background())
+	rs := rand.NewSource(0)
+
+	inp := generateInput(target, rs, 5)
+	corpus.Restore(inp, 10, 2)
+	// The same program restored for another call is a different *prog.Prog object,
+	// but its statistics must be added to the existing item.
+	p, err := target.Deserialize(inp.Prog.Serialize(), prog.NonStrict)
+	assert.NoError(t, err)
+	inp.Prog = p
+	inp.Call = (inp.Call + 1) % len(p.Calls)
+	corpus.Restore(inp, 5, 1)
+
+	items := corpus.Items()
+	assert.Len(t, items, 1)
+	assert.Equal(t, uint64(15), items[0].Mutations())
+	assert.Equal(t, uint64(3), items[0].NewSignalMutations())
+}
+
 func TestCorpusSaveConcurrency(t *testing.T) {
 	target := getTarget(t, targets.TestOS, targets.TestArch64)
 	corpus := NewCorpus(context.Background())
@@ -98,6 +119,29 @@ func TestCorpusSaveConcurrency(t *testing.T) {
 	}
 }
 
+func TestDistill(t *testing.T) {
+	target := getTarget(t, targets.TestOS, targets.TestArch64)
+	rs := rand.NewSource(0)
+	progs := []*prog.Prog{
+		target.Generate(rs, 1, target.DefaultChoiceTable()),
+		target.Generate(rs, 1, target.DefaultChoiceTable()),
+		target.Generate(rs, 10, target.DefaultChoiceTable()),
+		target.Generate(rs, 1, target.DefaultChoiceTable()),
+		target.Generate(rs, 1, target.DefaultChoiceTable()),
+	}
+	inputs := []DistillInput{
+		{Prog: progs[0], Cover: []uint64{1, 2, 3}},
+		{Prog: progs[1], Cover: []uint64{4, 5, 6}},
+		// The large program covers everything, but it's too expensive.
+		{Prog: progs[2], Cover: []uint64{1, 2, 3, 4, 5, 6, 7}},
+		{Prog: progs[3], Cover: []uint64{7}},
+		// Fully covered by the others.
+		{Prog: progs[4], Cover: []uint64{2, 3}},
+	}
+	assert.Equal(t, []int{0, 1, 3}, Distill(inputs))
+	assert.Empty(t, Distill(nil))
+}
+
 func generateInput(target *prog.Target, rs rand.Source, sizeSig int) NewInput {
 	return generateRangedInput(target, rs, 1, sizeSig)
 }
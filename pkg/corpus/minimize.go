@@ -4,9 +4,11 @@
 package corpus
 
 import (
+	"container/heap"
 	"sort"
 
 	"github.com/google/syzkaller/pkg/signal"
+	"github.com/google/syzkaller/prog"
 )
 
 func (corpus *Corpus) Minimize(cover bool) {
@@ -38,18 +40,108 @@ func (corpus *Corpus) Minimize(cover bool) {
 	})
 
 	corpus.progsMap = make(map[string]*Item)
+	corpus.seeds = make(map[*prog.Prog]*seedState)
 
 	// Overwrite the program lists.
 	corpus.ProgramsList = &ProgramsList{}
 	for _, area := range corpus.focusAreas {
 		area.ProgramsList = &ProgramsList{}
 	}
-	for _, ctx := range signal.Minimize(inputs) {
+	minimized := signal.Minimize(inputs)
+	for _, ctx := range minimized {
 		inp := ctx.(*Item)
 		corpus.progsMap[inp.Sig] = inp
-		corpus.saveProgram(inp.Prog, inp.Signal)
+		corpus.seeds[inp.Prog] = inp.seed
+	}
+	if corpus.rare != nil {
+		corpus.resetRareSignal()
+	}
+	// Priorities of the rare schedule depend on the whole corpus, so they are calculated
+	// only after all the remaining items are known.
+	for _, ctx := range minimized {
+		inp := ctx.(*Item)
+		prio := corpus.prio(inp.Prog, inp.Signal)
+		corpus.saveProgram(inp.Prog, prio)
 		for area := range inp.areas {
-			area.saveProgram(inp.Prog, inp.Signal)
+			area.saveProgram(inp.Prog, prio)
+		}
+	}
+}
+
+// DistillInput is a program with its coverage for Distill.
+type DistillInput struct {
+	Prog  *prog.Prog
+	Cover []uint64
+}
+
+// Distill returns indices (in increasing order) of a subset of inputs that together
+// cover all PCs covered by the inputs.
+// It's a greedy weighted set cover: on every step it takes the input with the largest
+// number of not yet covered PCs per call, so smaller programs are preferred.
+func Distill(inputs []DistillInput) []int {
+	// Weights only decrease as more PCs get covered, so we use lazy evaluation:
+	// the input on top of the heap is re-evaluated and taken only if it's still the best one.
+	h := &distillHeap{}
+	for i, inp := range inputs {
+		if len(inp.Cover) != 0 {
+			h.items = append(h.items, distillItem{idx: i, gain: distillGain(inp, nil)})
+		}
+	}
+	heap.Init(h)
+	covered := make(map[uint64]bool)
+	var res []int
+	for h.Len() != 0 {
+		top := &h.items[0]
+		gain := distillGain(inputs[top.idx], covered)
+		if gain == 0 {
+			heap.Pop(h)
+			continue
+		}
+		if gain < top.gain {
+			top.gain = gain
+			heap.Fix(h, 0)
+			continue
+		}
+		for _, pc := range inputs[top.idx].Cover {
+			covered[pc] = true
 		}
+		res = append(res, top.idx)
+		heap.Pop(h)
 	}
+	sort.Ints(res)
+	return res
+}
+
+func distillGain(inp DistillInput, covered map[uint64]bool) float64 {
+	count := 0
+	for _, pc := range inp.Cover {
+		if !covered[pc] {
+			count++
+		}
+	}
+	return float64(count) / float64(max(len(inp.Prog.Calls), 1))
+}
+
+type distillItem struct {
+	idx  int
+	gain float64
+}
+
+type distillHeap struct {
+	items []distillItem
+}
+
+func (h *distillHeap) Len() int { return len(h.items) }
+func (h *distillHeap) Less(i, j int) bool {
+	if h.items[i].gain != h.items[j].gain {
+		return h.items[i].gain > h.items[j].gain
+	}
+	return h.items[i].idx < h.items[j].idx
+}
+func (h *distillHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *distillHeap) Push(x any)    { h.items = append(h.items, x.(distillItem)) }
+func (h *distillHeap) Pop() any {
+	x := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return x
 }
@@ -4,13 +4,78 @@
 package corpus
 
 import (
+	"math"
 	"math/rand"
 	"sort"
+	"sync/atomic"
 
+	"github.com/google/syzkaller/pkg/corpus/schedule"
 	"github.com/google/syzkaller/pkg/signal"
 	"github.com/google/syzkaller/prog"
 )
 
+const (
+	// For dynamic schedules priorities are recalculated after this many chosen programs.
+	prioUpdatePeriod = 10000
+	// In the directed mode, programs that reach the targets are chosen up to 2^directedBoost
+	// times more often than programs that don't get close to them.
+	directedBoost = 4
+	noDistance    = math.MaxUint32
+	// Signal elements that are covered by more corpus programs are not considered rare,
+	// and don't contribute to priorities of the rare schedule. It bounds the cost
+	// of keeping the priorities up to date as programs are added.
+	rareThreshold = 16
+)
+
+// seedState tracks how productive the program has been as a mutation seed.
+type seedState struct {
+	sig       string
+	mutations atomic.Uint64
+	newSignal atomic.Uint64
+	// The minimal distance to the directed fuzzing targets among the covered PCs.
+	distance atomic.Uint32
+	// The sum of 1/N over the rare signal elements of the program, where N is the number
+	// of corpus programs that have the element. Only for schedule.Rare, protected by Corpus.mu.
+	rarity float64
+}
+
+// rareSignal keeps rarity of the corpus programs up to date for schedule.Rare.
+type rareSignal struct {
+	freq  map[uint64]int          // number of corpus programs per signal element
+	seeds map[uint64][]*seedState // programs that have the element, only while it's rare
+}
+
+func newRareSignal() *rareSignal {
+	return &rareSignal{
+		freq:  make(map[uint64]int),
+		seeds: make(map[uint64][]*seedState),
+	}
+}
+
+// add accounts elements of sig that are not present in old for the program.
+func (rs *rareSignal) add(seed *seedState, sig, old signal.Signal) {
+	for elem := range sig {
+		if _, ok := old[elem]; ok {
+			continue
+		}
+		e := uint64(elem)
+		n := rs.freq[e] + 1
+		rs.freq[e] = n
+		if n <= rareThreshold {
+			for _, other := range rs.seeds[e] {
+				other.rarity += 1/float64(n) - 1/float64(n-1)
+			}
+			rs.seeds[e] = append(rs.seeds[e], seed)
+			seed.rarity += 1 / float64(n)
+		} else if n == rareThreshold+1 {
+			for _, other := range rs.seeds[e] {
+				other.rarity -= 1 / float64(n-1)
+			}
+			delete(rs.seeds, e)
+		}
+	}
+}
+
 type ProgramsList struct {
 	progs    []*prog.Prog
 	sumPrios int64
@@ -28,17 +93,189 @@ func (pl *ProgramsList) chooseProgram(r *rand.Rand) *prog.Prog {
 	return pl.progs[idx]
 }
 
-func (pl *ProgramsList) saveProgram(p *prog.Prog, signal signal.Signal) {
-	prio := int64(len(signal))
-	if prio == 0 {
-		prio = 1
-	}
+func (pl *ProgramsList) saveProgram(p *prog.Prog, prio int64) {
 	pl.sumPrios += prio
 	pl.accPrios = append(pl.accPrios, pl.sumPrios)
 	pl.progs = append(pl.progs, p)
 }
 
+func (pl *ProgramsList) updatePrios(prio func(p *prog.Prog) int64) {
+	pl.sumPrios = 0
+	for i, p := range pl.progs {
+		pl.sumPrios += prio(p)
+		pl.accPrios[i] = pl.sumPrios
+	}
+}
+
+func (corpus *Corpus) prio(p *prog.Prog, signal signal.Signal) int64 {
+	var prio float64
+	switch corpus.schedule {
+	case schedule.Signal:
+		prio = float64(len(signal))
+	case schedule.Explore:
+		prio = float64(len(signal)) * seedFactor(corpus.seeds[p])
+	case schedule.Rare:
+		// Each rare signal element contributes inversely proportionally to the number
+		// of corpus programs that have it, so programs with unique signal stand out.
+		if seed := corpus.seeds[p]; seed != nil {
+			prio = max(seed.rarity, 0) * seedFactor(seed)
+		}
+	}
+	if corpus.schedule != schedule.Signal || corpus.distances != nil {
+		// Preserve some precision for the fractional priorities.
+		prio *= 16
+	}
+	if seed := corpus.seeds[p]; seed != nil && corpus.distances != nil {
+		prio *= corpus.directedFactor(seed.distance.Load())
+	}
+	return max(int64(math.Round(prio)), 1)
+}
+
+// directedFactor gives exponentially more weight to programs that get closer to the targets.
+func (corpus *Corpus) directedFactor(distance uint32) float64 {
+	if distance == noDistance {
+		return 1
+	}
+	closeness := 1 - float64(distance)/float64(corpus.maxDistance+1)
+	return math.Exp2(directedBoost * closeness)
+}
+
+// SetDistances enables directed fuzzing: programs that cover code closer to the targets
+// are chosen for mutation more often, and their syscalls are preferred in the choice table.
+// The distances map coverage PCs to the call graph distance from their functions
+// to the target functions, PCs that can't reach the targets must not be present.
+func (corpus *Corpus) SetDistances(distances map[uint64]uint32) {
+	corpus.mu.Lock()
+	defer corpus.mu.Unlock()
+	corpus.distances = distances
+	corpus.maxDistance = 0
+	for _, distance := range distances {
+		corpus.maxDistance = max(corpus.maxDistance, distance)
+	}
+	for _, item := range corpus.progsMap {
+		item.seed.distance.Store(corpus.coverDistance(item.Cover))
+	}
+	corpus.updatePrios()
+}
+
+// Directed returns whether directed fuzzing distances were set.
+func (corpus *Corpus) Directed() bool {
+	corpus.mu.RLock()
+	defer corpus.mu.RUnlock()
+	return corpus.distances != nil
+}
+
+func (corpus *Corpus) coverDistance(cover []uint64) uint32 {
+	distance := uint32(noDistance)
+	if corpus.distances == nil {
+		return distance
+	}
+	for _, pc := range cover {
+		if d, ok := corpus.distances[pc]; ok {
+			distance = min(distance, d)
+		}
+	}
+	return distance
+}
+
+// DirectedCallWeights returns weights of syscalls for prog.BuildWeightedChoiceTable
+// that prefer syscalls that have reached closer to the directed fuzzing targets.
+// Returns nil if the corpus is not directed.
+func (corpus *Corpus) DirectedCallWeights() map[*prog.Syscall]int32 {
+	corpus.mu.RLock()
+	defer corpus.mu.RUnlock()
+	if corpus.distances == nil {
+		return nil
+	}
+	weights := make(map[*prog.Syscall]int32)
+	for _, item := range corpus.progsMap {
+		distance := item.seed.distance.Load()
+		if distance == noDistance || item.Call < 0 {
+			continue
+		}
+		call := item.Prog.Calls[item.Call].Meta
+		weight := int32(math.Round(corpus.directedFactor(distance)))
+		weights[call] = max(weights[call], weight)
+	}
+	return weights
+}
+
+// seedFactor reduces priority of programs that were already mutated many times,
+// unless their mutations keep producing new signal.
+func seedFactor(seed *seedState) float64 {
+	if seed == nil {
+		return 1
+	}
+	mutations := float64(seed.mutations.Load())
+	newSignal := float64(seed.newSignal.Load())
+	return (1 + 100*newSignal/(mutations+100)) / (1 + math.Log2(1+mutations))
+}
+
+// SetSchedule changes the program selection schedule.
+func (corpus *Corpus) SetSchedule(sched schedule.Schedule) {
+	corpus.mu.Lock()
+	defer corpus.mu.Unlock()
+	if corpus.schedule == sched {
+		return
+	}
+	corpus.schedule = sched
+	corpus.rare = nil
+	if sched == schedule.Rare {
+		corpus.resetRareSignal()
+	}
+	corpus.updatePrios()
+}
+
+func (corpus *Corpus) Schedule() schedule.Schedule {
+	corpus.mu.RLock()
+	defer corpus.mu.RUnlock()
+	return corpus.schedule
+}
+
+// resetRareSignal recalculates rarity of all corpus programs.
+func (corpus *Corpus) resetRareSignal() {
+	corpus.rare = newRareSignal()
+	for _, item := range corpus.progsMap {
+		item.seed.rarity = 0
+	}
+	for _, item := range corpus.progsMap {
+		corpus.rare.add(item.seed, item.Signal, nil)
+	}
+}
+
+func (corpus *Corpus) updatePrios() {
+	prio := func(p *prog.Prog) int64 {
+		return corpus.prio(p, corpus.progsMap[corpus.seeds[p].sig].Signal)
+	}
+	corpus.ProgramsList.updatePrios(prio)
+	for _, area := range corpus.focusAreas {
+		area.ProgramsList.updatePrios(prio)
+	}
+}
+
+// RecordMutation records the result of execution of a mutant of the corpus program p.
+func (corpus *Corpus) RecordMutation(p *prog.Prog, newSignal bool) {
+	corpus.mu.RLock()
+	seed := corpus.seeds[p]
+	corpus.mu.RUnlock()
+	if seed == nil {
+		// The program has been removed from the corpus since then.
+		return
+	}
+	seed.mutations.Add(1)
+	if newSignal {
+		seed.newSignal.Add(1)
+	}
+}
+
 func (corpus *Corpus) ChooseProgram(r *rand.Rand) *prog.Prog {
+	if corpus.chosen.Add(1)%prioUpdatePeriod == 0 {
+		corpus.mu.Lock()
+		if corpus.schedule != schedule.Signal || corpus.distances != nil {
+			corpus.updatePrios()
+		}
+		corpus.mu.Unlock()
+	}
 	corpus.mu.RLock()
 	defer corpus.mu.RUnlock()
 	if len(corpus.progsMap) == 0 {
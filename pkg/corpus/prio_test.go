@@ -9,6 +9,8 @@ import (
 	"math/rand"
 	"testing"
 
+	"github.com/google/syzkaller/pkg/corpus/schedule"
+	"github.com/google/syzkaller/pkg/hash"
 	"github.com/google/syzkaller/prog"
 	"github.com/google/syzkaller/sys/targets"
 	"github.com/stretchr/testify/assert"
@@ -117,3 +119,139 @@ func TestFocusAreas(t *testing.T) {
 	assert.InDelta(t, secondCount, TOTAL*0.3, TOTAL/25)
 	assert.InDelta(t, thirdCount, TOTAL*0.6, TOTAL/25)
 }
+
+func TestScheduleExplore(t *testing.T) {
+	target := getTarget(t, targets.TestOS, targets.TestArch64)
+	corpus := NewCorpus(context.Background())
+	corpus.SetSchedule(schedule.Explore)
+	rs := rand.NewSource(0)
+	stale := generateRangedInput(target, rs, 0, 9)
+	fresh := generateRangedInput(target, rs, 10, 19)
+	productive := generateRangedInput(target, rs, 20, 29)
+	corpus.Save(stale)
+	corpus.Save(fresh)
+	corpus.Save(productive)
+	for i := 0; i < 1000; i++ {
+		corpus.RecordMutation(stale.Prog, false)
+		corpus.RecordMutation(productive.Prog, i%10 == 0)
+	}
+	item := corpus.Item(hash.String(productive.Prog.Serialize()))
+	assert.Equal(t, uint64(1000), item.Mutations())
+	assert.Equal(t, uint64(100), item.NewSignalMutations())
+
+	corpus.mu.Lock()
+	corpus.updatePrios()
+	corpus.mu.Unlock()
+	counters := make(map[*prog.Prog]int)
+	r := rand.New(rs)
+	for i := 0; i < 1000; i++ {
+		counters[corpus.chooseProgram(r)]++
+	}
+	assert.Greater(t, counters[fresh.Prog], counters[productive.Prog])
+	assert.Greater(t, counters[productive.Prog], counters[stale.Prog])
+}
+
+func TestScheduleRare(t *testing.T) {
+	target := getTarget(t, targets.TestOS, targets.TestArch64)
+	corpus := NewCorpus(context.Background())
+	rs := rand.NewSource(0)
+	rare := generateRangedInput(target, rs, 0, 9)
+	corpus.Save(rare)
+	var common []*prog.Prog
+	for i := 0; i < 5; i++ {
+		inp := generateRangedInput(target, rs, 10, 29)
+		corpus.Save(inp)
+		common = append(common, inp.Prog)
+	}
+	// The schedule can be changed after the programs were added.
+	corpus.SetSchedule(schedule.Rare)
+	assert.Equal(t, schedule.Rare, corpus.Schedule())
+	counters := make(map[*prog.Prog]int)
+	r := rand.New(rs)
+	for i := 0; i < 1000; i++ {
+		counters[corpus.chooseProgram(r)]++
+	}
+	for _, p := range common {
+		assert.Greater(t, counters[rare.Prog], counters[p])
+	}
+}
+
+func TestScheduleRareIncremental(t *testing.T) {
+	target := getTarget(t, targets.TestOS, targets.TestArch64)
+	corpus := NewCorpus(context.Background())
+	corpus.SetSchedule(schedule.Rare)
+	rs := rand.NewSource(0)
+	r := rand.New(rs)
+	var inputs []NewInput
+	for i := 0; i < 100; i++ {
+		from := r.Intn(100)
+		inp := generateRangedInput(target, rs, from, from+r.Intn(20))
+		if len(inputs) != 0 && r.Intn(4) == 0 {
+			// New signal of an existing program.
+			inp.Prog = inputs[r.Intn(len(inputs))].Prog
+		}
+		corpus.Save(inp)
+		inputs = append(inputs, inp)
+	}
+	freq := make(map[uint64]int)
+	for _, item := range corpus.Items() {
+		for _, elem := range item.Signal.ToRaw() {
+			freq[elem]++
+		}
+	}
+	for _, item := range corpus.Items() {
+		rarity := 0.0
+		for _, elem := range item.Signal.ToRaw() {
+			if freq[elem] <= rareThreshold {
+				rarity += 1 / float64(freq[elem])
+			}
+		}
+		assert.InDelta(t, rarity, item.seed.rarity, 1e-9)
+	}
+}
+
+func TestDirected(t *testing.T) {
+	target := getTarget(t, targets.TestOS, targets.TestArch64)
+	corpus := NewCorpus(context.Background())
+	rs := rand.NewSource(0)
+	near := generateRangedInput(target, rs, 0, 9)
+	far := generateRangedInput(target, rs, 10, 19)
+	unrelated := generateRangedInput(target, rs, 20, 29)
+	corpus.Save(near)
+	corpus.Save(far)
+	corpus.Save(unrelated)
+	distances := map[uint64]uint32{5: 0, 6: 1, 15: 4}
+	corpus.SetDistances(distances)
+	// Distances of the newly added programs are calculated as well.
+	mid := generateRangedInput(target, rs, 6, 6)
+	corpus.Save(mid)
+	for _, test := range []struct {
+		inp      NewInput
+		distance uint32
+		ok       bool
+	}{
+		{near, 0, true},
+		{far, 4, true},
+		{unrelated, 0, false},
+		{mid, 1, true},
+	} {
+		distance, ok := corpus.Item(hash.String(test.inp.Prog.Serialize())).Distance()
+		assert.Equal(t, test.ok, ok)
+		if ok {
+			assert.Equal(t, test.distance, distance)
+		}
+	}
+
+	counters := make(map[*prog.Prog]int)
+	r := rand.New(rs)
+	for i := 0; i < 1000; i++ {
+		counters[corpus.chooseProgram(r)]++
+	}
+	assert.Greater(t, counters[near.Prog], counters[far.Prog])
+	assert.Greater(t, counters[far.Prog], counters[unrelated.Prog])
+
+	weights := corpus.DirectedCallWeights()
+	nearCall := near.Prog.Calls[near.Call].Meta
+	assert.Equal(t, int32(16), weights[nearCall])
+	assert.Nil(t, NewCorpus(context.Background()).DirectedCallWeights())
+}
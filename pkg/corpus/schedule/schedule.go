@@ -0,0 +1,49 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package schedule defines how programs are chosen from the corpus for mutation.
+// It's separate from pkg/corpus, so that pkg/mgrconfig can validate schedule names.
+package schedule
+
+import (
+	"fmt"
+)
+
+// Schedule determines how programs are chosen from the corpus for mutation.
+type Schedule int
+
+const (
+	// Signal chooses programs proportionally to their signal size.
+	Signal Schedule = iota
+	// Explore additionally prefers programs that were mutated fewer times,
+	// and programs whose mutations produced new signal (similar to AFL's "fast" power schedule).
+	Explore
+	// Rare is like Explore, but instead of the signal size it prefers programs
+	// that cover signal rarely covered by other corpus programs (similar to Entropic).
+	Rare
+	count
+)
+
+var names = [count]string{
+	Signal:  "signal",
+	Explore: "explore",
+	Rare:    "rare",
+}
+
+func (s Schedule) String() string {
+	return names[s]
+}
+
+// Names returns names of all schedules accepted by Parse.
+func Names() []string {
+	return names[:]
+}
+
+func Parse(name string) (Schedule, error) {
+	for s, str := range names {
+		if str == name {
+			return Schedule(s), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown corpus schedule %q", name)
+}
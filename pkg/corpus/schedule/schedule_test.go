@@ -0,0 +1,21 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package schedule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	for _, schedule := range []Schedule{Signal, Explore, Rare} {
+		parsed, err := Parse(schedule.String())
+		assert.NoError(t, err)
+		assert.Equal(t, schedule, parsed)
+	}
+	assert.Equal(t, []string{"signal", "explore", "rare"}, Names())
+	_, err := Parse("foo")
+	assert.Error(t, err)
+}
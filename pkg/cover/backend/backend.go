@@ -33,6 +33,10 @@ type Symbol struct {
 	Start      uint64
 	End        uint64
 	Symbolized bool
+	// Functions directly called by this function.
+	// Currently only calls within the core kernel image on amd64/arm64 are discovered,
+	// and only if directed fuzzing is configured.
+	Callees []*Symbol
 }
 
 // ObjectUnit represents either CompileUnit or Symbol.
@@ -87,7 +91,9 @@ func Make(cfg *mgrconfig.Config, modules []*vminfo.KernelModule) (*Impl, error)
 		// details.
 		delimiters = []string{"/aosp/", "/private/"}
 	}
-	return makeELF(target, kernelDirs, delimiters, moduleObj, modules)
+	// The call graph is only used by directed fuzzing, and it's costly to build for large kernels.
+	callGraph := !cfg.Experimental.DirectedTargets.Empty()
+	return makeELF(target, kernelDirs, delimiters, moduleObj, modules, callGraph)
 }
 
 func GetPCBase(cfg *mgrconfig.Config) (uint64, error) {
@@ -37,6 +37,8 @@ type dwarfParams struct {
 	readModuleCoverPoints func(*targets.Target, *vminfo.KernelModule, *symbolInfo) ([2][]uint64, error)
 	readTextRanges        func(*vminfo.KernelModule) ([]pcRange, []*CompileUnit, error)
 	getCompilerVersion    func(string) string
+	// Collect direct calls and fill Symbol.Callees (only needed for directed fuzzing).
+	callGraph bool
 }
 
 type Arch struct {
@@ -103,6 +105,13 @@ func makeDWARF(params *dwarfParams) (impl *Impl, err error) {
 type Result struct {
 	CoverPoints [2][]uint64
 	Symbols     []*Symbol
+	Calls       []callEdge
+}
+
+// callEdge is a direct call instruction at PC that calls Target.
+type callEdge struct {
+	pc     uint64
+	target uint64
 }
 
 func processModule(params *dwarfParams, module *vminfo.KernelModule, info *symbolInfo,
@@ -114,6 +123,7 @@ func processModule(params *dwarfParams, module *vminfo.KernelModule, info *symbo
 
 	var data []byte
 	var coverPoints [2][]uint64
+	var calls []callEdge
 	if target.Arch != targets.AMD64 && target.Arch != targets.ARM64 {
 		coverPoints, err = objdump(target, module)
 	} else if module.Name == "" {
@@ -121,7 +131,7 @@ func processModule(params *dwarfParams, module *vminfo.KernelModule, info *symbo
 		if err != nil {
 			return nil, err
 		}
-		coverPoints, err = readCoverPoints(target, info, data)
+		coverPoints, calls, err = readCoverPoints(target, info, data, params.callGraph)
 	} else {
 		coverPoints, err = params.readModuleCoverPoints(target, module, info)
 	}
@@ -132,6 +142,7 @@ func processModule(params *dwarfParams, module *vminfo.KernelModule, info *symbo
 	result := &Result{
 		Symbols:     symbols,
 		CoverPoints: coverPoints,
+		Calls:       calls,
 	}
 	return result, nil
 }
@@ -148,10 +159,12 @@ func makeDWARFUnsafe(params *dwarfParams) (*Impl, error) {
 	var allSymbols []*Symbol
 	var allRanges []pcRange
 	var allUnits []*CompileUnit
+	var allCalls []callEdge
 	preciseCoverage := true
 	type binResult struct {
 		symbols     []*Symbol
 		coverPoints [2][]uint64
+		calls       []callEdge
 		ranges      []pcRange
 		units       []*CompileUnit
 		err         error
@@ -180,7 +193,8 @@ func makeDWARFUnsafe(params *dwarfParams) (*Impl, error) {
 				binC <- binResult{err: err}
 				return
 			}
-			binC <- binResult{symbols: result.Symbols, coverPoints: result.CoverPoints, ranges: ranges, units: units}
+			binC <- binResult{symbols: result.Symbols, coverPoints: result.CoverPoints, calls: result.Calls,
+				ranges: ranges, units: units}
 		}()
 		if isKcovBrokenInCompiler(params.getCompilerVersion(module.Path)) {
 			preciseCoverage = false
@@ -196,6 +210,7 @@ func makeDWARFUnsafe(params *dwarfParams) (*Impl, error) {
 		allCoverPoints[1] = append(allCoverPoints[1], result.coverPoints[1]...)
 		allRanges = append(allRanges, result.ranges...)
 		allUnits = append(allUnits, result.units...)
+		allCalls = append(allCalls, result.calls...)
 	}
 	log.Logf(1, "discovered %v source files, %v symbols", len(allUnits), len(allSymbols))
 	// TODO: need better way to remove symbols having the same Start
@@ -222,6 +237,7 @@ func makeDWARFUnsafe(params *dwarfParams) (*Impl, error) {
 	}
 
 	allSymbols = buildSymbols(allSymbols, allRanges, allCoverPoints)
+	buildCallGraph(allSymbols, allCalls)
 	nunit := 0
 	for _, unit := range allUnits {
 		if len(unit.PCs) == 0 {
@@ -309,6 +325,43 @@ func buildSymbols(symbols []*Symbol, ranges []pcRange, coverPoints [2][]uint64)
 	return symbols
 }
 
+// buildCallGraph fills Symbol.Callees based on the call instructions found in the code.
+// Symbols must be sorted by Start.
+func buildCallGraph(symbols []*Symbol, calls []callEdge) {
+	if len(calls) == 0 {
+		return
+	}
+	byStart := make(map[uint64]*Symbol, len(symbols))
+	for _, s := range symbols {
+		byStart[s.Start] = s
+	}
+	sort.Slice(calls, func(i, j int) bool {
+		return calls[i].pc < calls[j].pc
+	})
+	symbolIdx := 0
+	seen := make(map[*Symbol]bool)
+	for _, call := range calls {
+		callee := byStart[call.target]
+		if callee == nil {
+			// Not a call of a known function. On amd64 the code is scanned byte-by-byte,
+			// so this also filters out most of the bogus "calls" in the middle of other instructions.
+			continue
+		}
+		for ; symbolIdx < len(symbols) && call.pc >= symbols[symbolIdx].End; symbolIdx++ {
+			clear(seen)
+		}
+		if symbolIdx == len(symbols) {
+			break
+		}
+		caller := symbols[symbolIdx]
+		if call.pc < caller.Start || seen[callee] {
+			continue
+		}
+		seen[callee] = true
+		caller.Callees = append(caller.Callees, callee)
+	}
+}
+
 // Regexps to parse compiler version string in isKcovBrokenInCompiler.
 // Some targets (e.g. NetBSD) use g++ instead of gcc.
 var gccRE = regexp.MustCompile(`gcc|GCC|g\+\+`)
@@ -608,12 +661,15 @@ func nextCallTarget(arch *Arch, textAddr uint64, data []byte, pos *int) (uint64,
 }
 
 // readCoverPoints finds all coverage points (calls of __sanitizer_cov_trace_*) in the object file.
+// If callGraph is set, all other call instructions are returned as well, they are used to build the call graph.
 // Currently it is [amd64|arm64]-specific: looks for opcode and correct offset.
 // Running objdump on the whole object file is too slow.
-func readCoverPoints(target *targets.Target, info *symbolInfo, data []byte) ([2][]uint64, error) {
+func readCoverPoints(target *targets.Target, info *symbolInfo, data []byte,
+	callGraph bool) ([2][]uint64, []callEdge, error) {
 	var pcs [2][]uint64
+	var calls []callEdge
 	if len(info.tracePC) == 0 {
-		return pcs, fmt.Errorf("no __sanitizer_cov_trace_pc symbol in the object file")
+		return pcs, nil, fmt.Errorf("no __sanitizer_cov_trace_pc symbol in the object file")
 	}
 
 	i := 0
@@ -627,9 +683,11 @@ func readCoverPoints(target *targets.Target, info *symbolInfo, data []byte) ([2]
 			pcs[0] = append(pcs[0], pc)
 		} else if info.traceCmp[callTarget] {
 			pcs[1] = append(pcs[1], pc)
+		} else if callGraph {
+			calls = append(calls, callEdge{pc: pc, target: callTarget})
 		}
 	}
-	return pcs, nil
+	return pcs, calls, nil
 }
 
 // Source files for Android may be split between two subdirectories: the common AOSP kernel
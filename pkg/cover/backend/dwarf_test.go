@@ -174,3 +174,31 @@ func TestNextCallTargetAMD64(t *testing.T) {
 		runNextCallTarget(t, test)
 	}
 }
+
+func TestBuildCallGraph(t *testing.T) {
+	foo := &Symbol{ObjectUnit: ObjectUnit{Name: "foo"}, Start: 0x100, End: 0x200}
+	bar := &Symbol{ObjectUnit: ObjectUnit{Name: "bar"}, Start: 0x200, End: 0x300}
+	baz := &Symbol{ObjectUnit: ObjectUnit{Name: "baz"}, Start: 0x400, End: 0x500}
+	buildCallGraph([]*Symbol{foo, bar, baz}, []callEdge{
+		{pc: 0x410, target: 0x100},
+		{pc: 0x110, target: 0x200},
+		{pc: 0x120, target: 0x400},
+		{pc: 0x130, target: 0x200}, // duplicate
+		{pc: 0x140, target: 0x210}, // not a function start
+		{pc: 0x350, target: 0x100}, // not inside a function
+	})
+	checkCallees := func(sym *Symbol, want ...*Symbol) {
+		t.Helper()
+		if len(sym.Callees) != len(want) {
+			t.Fatalf("%v: got %v callees, want %v", sym.Name, len(sym.Callees), len(want))
+		}
+		for i := range want {
+			if sym.Callees[i] != want[i] {
+				t.Fatalf("%v: callee %v is %v, want %v", sym.Name, i, sym.Callees[i].Name, want[i].Name)
+			}
+		}
+	}
+	checkCallees(foo, bar, baz)
+	checkCallees(bar)
+	checkCallees(baz, foo)
+}
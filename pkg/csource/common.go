@@ -126,6 +126,10 @@ func commonDefines(p *prog.Prog, opts Options) map[string]bool {
 		"SYZ_802154":                    opts.IEEE802154,
 		"SYZ_SYSCTL":                    opts.Sysctl,
 		"SYZ_SWAP":                      opts.Swap,
+		"SYZ_SCHED_YIELD":               opts.Sched == SchedYield,
+		"SYZ_SCHED_PIN":                 opts.Sched == SchedPin,
+		"SYZ_SCHED_SPREAD":              opts.Sched == SchedSpread,
+		"SYZ_SCHED_DELAY":               opts.Sched == SchedDelay && features.Async,
 		"SYZ_EXECUTOR_USES_FORK_SERVER": sysTarget.ExecutorUsesForkServer,
 	}
 }
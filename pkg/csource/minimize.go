@@ -0,0 +1,480 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package csource
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/syzkaller/pkg/bisect/minimize"
+)
+
+// Minimize shrinks C source src produced by Write.
+// Option simplification can only turn off whole features, so the source still contains setup code
+// that the crash does not need. Minimize removes standalone calls of helper functions (e.g. tun,
+// cgroups and sysctl setup) as long as pred returns true for the resulting source. Then it removes
+// functions, types, variables and defines that became unused, and inlines defines that are used once.
+// pred must return whether the source still reproduces the crash.
+// Minimize runs pred at most maxMinimizeSteps+2 times. If maxTime is not 0, the bisection stops
+// (as if pred began to return false) and the inlining is skipped once pred runs took more than maxTime.
+// If the minimized source does not reproduce, it returns src.
+func Minimize(src []byte, maxTime time.Duration, pred func([]byte) (bool, error),
+	logf func(string, ...interface{})) ([]byte, error) {
+	if logf == nil {
+		logf = func(string, ...interface{}) {}
+	}
+	start := time.Now()
+	outOfTime := func() bool {
+		return maxTime != 0 && time.Since(start) > maxTime
+	}
+	items := parseItems(string(src))
+	calls := helperCalls(items)
+	logf("%v removable helper calls", len(calls))
+	kept := calls
+	if len(calls) != 0 {
+		var err error
+		kept, err = minimize.Slice(minimize.Config[*helperCall]{
+			Pred: func(kept []*helperCall) (bool, error) {
+				if outOfTime() {
+					return false, nil
+				}
+				return pred(renderItems(items, calls, kept, false))
+			},
+			MaxSteps: maxMinimizeSteps,
+			Logf:     logf,
+		}, calls)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Bisection does not test the final set of calls if it keeps all of them or runs out of steps,
+	// and removal of unused items is not tested at all if there are no calls.
+	res := renderItems(items, calls, kept, false)
+	if string(res) != string(src) {
+		ok, err := pred(res)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			logf("the minimized C source does not reproduce, keeping the original one")
+			return src, nil
+		}
+	}
+	inlined := renderItems(items, calls, kept, true)
+	if string(inlined) != string(res) && !outOfTime() {
+		ok, err := pred(inlined)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			res = inlined
+		}
+	}
+	logf("minimized C source from %v to %v lines", strings.Count(string(src), "\n"), strings.Count(string(res), "\n"))
+	return res, nil
+}
+
+// maxMinimizeSteps limits the number of pred runs during bisection of helper calls.
+const maxMinimizeSteps = 30
+
+// srcItem is a top-level entity of the source: a function, a declaration, a preprocessor directive
+// or an empty line.
+type srcItem struct {
+	lines []string
+	// The names defined by the item. Items without names are always kept.
+	names []string
+	// For "#define name value" with a single token value.
+	value string
+	fn    bool
+}
+
+// helperCall is a statement that consists of a helper function call, e.g. "\tsetup_cgroups();\n".
+type helperCall struct {
+	item  int
+	line  int
+	count int
+}
+
+// Functions that implement the program execution itself.
+// Their calls and calls inside of them are never removed.
+var coreFunctions = map[string]bool{
+	"loop":         true,
+	"execute_one":  true,
+	"execute_call": true,
+	"thr":          true,
+}
+
+var (
+	identRe        = regexp.MustCompile(`[A-Za-z_]\w*`)
+	funcNameRe     = regexp.MustCompile(`([A-Za-z_]\w*)\s*\(([^*]|$)`)
+	defineRe       = regexp.MustCompile(`^#(define|undef) ([A-Za-z_]\w*)(\(| |$)\s*(.*?)\s*$`)
+	constValueRe   = regexp.MustCompile(`^(0x[0-9a-fA-F]+|[0-9]+)[uUlL]*$|^[A-Za-z_]\w*$`)
+	enumValueRe    = regexp.MustCompile(`(?m)^\s*([A-Za-z_]\w*)\s*(=.*)?,?\s*$`)
+	callStmtRe     = regexp.MustCompile(`^\t+([A-Za-z_]\w*)\(.*\);$`)
+	reasonStmtRe   = regexp.MustCompile(`^\t+if \(\(reason = ([A-Za-z_]\w*)\(\)\)\)$`)
+	emptyPPBlockRe = regexp.MustCompile(`(?m)^#if.*\n(\n)*(#else.*\n(\n)*)?#endif.*\n`)
+)
+
+var nonNames = map[string]bool{
+	"struct": true, "union": true, "enum": true, "static": true, "const": true,
+	"volatile": true, "typedef": true, "int": true, "void": true, "char": true,
+}
+
+func parseItems(src string) []*srcItem {
+	var items []*srcItem
+	var cur *srcItem
+	depth, directive := 0, false
+	for _, line := range strings.SplitAfter(src, "\n") {
+		if line == "" {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if cur == nil {
+			cur = new(srcItem)
+			items = append(items, cur)
+			directive = strings.HasPrefix(trimmed, "#")
+		}
+		cur.lines = append(cur.lines, line)
+		if len(cur.lines) == 1 && strings.HasPrefix(trimmed, "//") {
+			cur = nil
+			continue
+		}
+		if directive {
+			if !strings.HasSuffix(trimmed, "\\") {
+				cur = nil
+			}
+			continue
+		}
+		depth += braceDelta(line)
+		if depth == 0 && (trimmed == "" || strings.HasSuffix(trimmed, ";") || strings.HasSuffix(trimmed, "}")) {
+			cur = nil
+		}
+	}
+	for _, item := range items {
+		var names []string
+		names, item.value, item.fn = itemNames(item)
+		for _, name := range names {
+			if !nonNames[name] {
+				item.names = append(item.names, name)
+			}
+		}
+	}
+	return items
+}
+
+func itemNames(item *srcItem) ([]string, string, bool) {
+	text := strings.Join(item.lines, "")
+	last := strings.TrimSpace(item.lines[len(item.lines)-1])
+	switch {
+	case strings.HasPrefix(text, "//"):
+		return nil, "", false
+	case strings.HasPrefix(text, "#"):
+		match := defineRe.FindStringSubmatch(strings.TrimSpace(item.lines[0]))
+		if match == nil || strings.HasPrefix(match[2], "_") {
+			return nil, "", false
+		}
+		value := ""
+		if match[1] == "define" && match[3] != "(" && len(item.lines) == 1 && constValueRe.MatchString(match[4]) {
+			value = match[4]
+		}
+		return []string{match[2]}, value, false
+	case strings.HasPrefix(text, "typedef"):
+		decl := text[strings.LastIndexByte(text, '}')+1:]
+		idents := identRe.FindAllString(decl, -1)
+		if len(idents) == 0 || strings.Contains(decl, "(") {
+			return nil, "", false
+		}
+		return idents[len(idents)-1:], "", false
+	case strings.HasPrefix(text, "enum"):
+		// The enum is used if either the tag or any of the values is used.
+		start, end := strings.IndexByte(text, '{'), strings.LastIndexByte(text, '}')
+		if start == -1 || end < start || last != "};" {
+			return nil, "", false
+		}
+		names := identRe.FindAllString(text[:start], -1)
+		for _, match := range enumValueRe.FindAllStringSubmatch(text[start+1:end], -1) {
+			names = append(names, match[1])
+		}
+		return names, "", false
+	}
+	header := text
+	pos := strings.IndexAny(text, "{=;[")
+	if pos != -1 {
+		header = text[:pos]
+	}
+	if strings.Contains(header, "(") {
+		match := funcNameRe.FindStringSubmatch(header)
+		if match == nil {
+			return nil, "", false
+		}
+		return match[1:2], "", true
+	}
+	if pos != -1 && text[pos] == '{' && last != "};" {
+		// A struct/union definition that also declares variables or has attributes.
+		return nil, "", false
+	}
+	idents := identRe.FindAllString(header, -1)
+	if len(idents) == 0 {
+		return nil, "", false
+	}
+	return idents[len(idents)-1:], "", false
+}
+
+// braceDelta returns the difference between the number of opening and closing braces in the line
+// ignoring braces in string and character literals and comments.
+func braceDelta(line string) int {
+	delta := 0
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; c {
+		case '{':
+			delta++
+		case '}':
+			delta--
+		case '"', '\'':
+			for i++; i < len(line) && line[i] != c; i++ {
+				if line[i] == '\\' {
+					i++
+				}
+			}
+		case '/':
+			if i+1 < len(line) && line[i+1] == '/' {
+				return delta
+			}
+			if i+1 < len(line) && line[i+1] == '*' {
+				end := strings.Index(line[i+2:], "*/")
+				if end == -1 {
+					return delta
+				}
+				i += end + 3
+			}
+		}
+	}
+	return delta
+}
+
+// statementStart returns whether line j of a function starts a statement,
+// i.e. it's not a body of an if/for/while statement without braces.
+func statementStart(lines []string, j int) bool {
+	prev := ""
+	for i := j - 1; i >= 0 && prev == ""; i-- {
+		prev = strings.TrimSpace(lines[i])
+	}
+	return strings.HasSuffix(prev, ";") || strings.HasSuffix(prev, "{") || strings.HasSuffix(prev, "}")
+}
+
+func helperCalls(items []*srcItem) []*helperCall {
+	funcs := make(map[string]bool)
+	for _, item := range items {
+		if item.fn && len(item.names) != 0 {
+			funcs[item.names[0]] = true
+		}
+	}
+	removable := func(name string) bool {
+		return funcs[name] && !coreFunctions[name] && !strings.HasPrefix(name, "do_sandbox_")
+	}
+	var calls []*helperCall
+	for i, item := range items {
+		if !item.fn || len(item.names) == 0 || coreFunctions[item.names[0]] {
+			continue
+		}
+		for j := 1; j < len(item.lines); j++ {
+			if !statementStart(item.lines, j) {
+				continue
+			}
+			line := strings.TrimRight(item.lines[j], "\n")
+			if match := callStmtRe.FindStringSubmatch(line); match != nil && removable(match[1]) {
+				calls = append(calls, &helperCall{item: i, line: j, count: 1})
+			} else if match := reasonStmtRe.FindStringSubmatch(line); match != nil && removable(match[1]) &&
+				j+1 < len(item.lines) && strings.HasSuffix(strings.TrimSpace(item.lines[j+1]), ";") {
+				calls = append(calls, &helperCall{item: i, line: j, count: 2})
+			}
+		}
+	}
+	return calls
+}
+
+// renderItems produces the source without the calls that are not in kept,
+// and without items that become unused after that.
+func renderItems(items []*srcItem, calls, kept []*helperCall, inline bool) []byte {
+	keep := make(map[*helperCall]bool)
+	for _, call := range kept {
+		keep[call] = true
+	}
+	cur := make([]*srcItem, len(items))
+	for i, item := range items {
+		cur[i] = &srcItem{
+			lines: append([]string{}, item.lines...),
+			names: item.names,
+			value: item.value,
+			fn:    item.fn,
+		}
+	}
+	for _, call := range calls {
+		if keep[call] {
+			continue
+		}
+		for j := 0; j < call.count; j++ {
+			cur[call.item].lines[call.line+j] = ""
+		}
+	}
+	removeEmptyCalls(cur)
+	cur = removeUnused(cur)
+	if inline {
+		cur = removeUnused(inlineConstants(cur))
+	}
+	buf := new(strings.Builder)
+	for _, item := range cur {
+		for _, line := range item.lines {
+			buf.WriteString(line)
+		}
+	}
+	res := buf.String()
+	if !strings.Contains(res, "(reason = ") {
+		res = strings.Replace(res, "\tconst char* reason;\n\t(void)reason;\n", "", 1)
+	}
+	for {
+		newRes := emptyPPBlockRe.ReplaceAllString(res, "")
+		newRes = strings.ReplaceAll(newRes, "\n\n\n", "\n\n")
+		if newRes == res {
+			break
+		}
+		res = newRes
+	}
+	return []byte(res)
+}
+
+// removeEmptyCalls removes calls of void functions without arguments that do nothing
+// (e.g. setup_loop after all its statements were removed).
+// Functions with several definitions (in different #if branches) are never considered empty.
+func removeEmptyCalls(items []*srcItem) {
+	defs := make(map[string]int)
+	for _, item := range items {
+		if item.fn && len(item.names) != 0 {
+			defs[item.names[0]]++
+		}
+	}
+	for removed := true; removed; {
+		removed = false
+		for _, fn := range items {
+			if !fn.fn || len(fn.names) == 0 || defs[fn.names[0]] != 1 || !strings.HasPrefix(fn.lines[0], "static void ") ||
+				strings.Join(strings.Fields(strings.Join(fn.lines[1:], "")), "") != "{}" {
+				continue
+			}
+			call := fmt.Sprintf("%v();", fn.names[0])
+			for _, item := range items {
+				if !item.fn {
+					continue
+				}
+				for j := 1; j < len(item.lines); j++ {
+					if strings.TrimSpace(item.lines[j]) == call && statementStart(item.lines, j) {
+						item.lines[j] = ""
+						removed = true
+					}
+				}
+			}
+		}
+	}
+}
+
+// removeUnused removes named items that are not reachable from main and unnamed items.
+func removeUnused(items []*srcItem) []*srcItem {
+	defs := make(map[string][]int)
+	for i, item := range items {
+		for _, name := range item.names {
+			defs[name] = append(defs[name], i)
+		}
+	}
+	alive := make([]bool, len(items))
+	var queue []int
+	for i, item := range items {
+		if len(item.names) == 0 || item.fn && item.names[0] == "main" {
+			alive[i] = true
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) != 0 {
+		item := items[queue[0]]
+		queue = queue[1:]
+		for _, line := range item.lines {
+			for _, ident := range identRe.FindAllString(line, -1) {
+				for _, def := range defs[ident] {
+					if !alive[def] {
+						alive[def] = true
+						queue = append(queue, def)
+					}
+				}
+			}
+		}
+	}
+	var res []*srcItem
+	for i, item := range items {
+		if alive[i] {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// countUses returns the number of references to each name from items that don't define the name.
+func countUses(items []*srcItem) map[string]int {
+	defined := make(map[string]bool)
+	for _, item := range items {
+		for _, name := range item.names {
+			defined[name] = true
+		}
+	}
+	uses := make(map[string]int)
+	for _, item := range items {
+		for _, line := range item.lines {
+			for _, ident := range identRe.FindAllString(line, -1) {
+				if defined[ident] && !item.defines(ident) {
+					uses[ident]++
+				}
+			}
+		}
+	}
+	return uses
+}
+
+func (item *srcItem) defines(name string) bool {
+	for _, name1 := range item.names {
+		if name1 == name {
+			return true
+		}
+	}
+	return false
+}
+
+// inlineConstants replaces defines with a single token value that are used once with the value.
+func inlineConstants(items []*srcItem) []*srcItem {
+	uses := countUses(items)
+	defs := make(map[string]int)
+	for _, item := range items {
+		for _, name := range item.names {
+			defs[name]++
+		}
+	}
+	for _, def := range items {
+		if def.value == "" || uses[def.names[0]] != 1 || defs[def.names[0]] != 1 {
+			continue
+		}
+		name := def.names[0]
+		re := regexp.MustCompile(fmt.Sprintf(`\b%v\b`, name))
+		for _, item := range items {
+			if item.defines(name) || !re.MatchString(strings.Join(item.lines, "")) {
+				continue
+			}
+			if strings.HasPrefix(item.lines[0], "#") {
+				// Values of other defines may be inlined themselves.
+				break
+			}
+			for i, line := range item.lines {
+				item.lines[i] = re.ReplaceAllLiteralString(line, def.value)
+			}
+		}
+	}
+	return items
+}
@@ -0,0 +1,318 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package csource
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/sys/targets"
+)
+
+func TestMinimize(t *testing.T) {
+	target, err := prog.GetTarget(targets.Linux, targets.AMD64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := targets.Get(targets.Linux, targets.AMD64).BrokenCompiler; err != "" {
+		t.Skipf("target compiler is broken: %v", err)
+	}
+	p, err := target.Deserialize([]byte(`
+r0 = openat(0xffffffffffffff9c, &(0x7f0000000000)='./file0\x00', 0x42, 0x0)
+write(r0, &(0x7f0000000040)="aa", 0x2)
+`), prog.Strict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := Options{
+		Threaded:     true,
+		Repeat:       true,
+		Procs:        2,
+		Slowdown:     1,
+		Sandbox:      "none",
+		NetInjection: true,
+		NetDevices:   true,
+		NetReset:     true,
+		Cgroups:      true,
+		BinfmtMisc:   true,
+		CloseFDs:     true,
+		UseTmpDir:    true,
+		HandleSegv:   true,
+		Sysctl:       true,
+		Swap:         true,
+	}
+	src, err := Write(p, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fn := range []string{"setup_sysctl()", "initialize_tun()", "setup_cgroups()", "use_temporary_dir()"} {
+		if !bytes.Contains(src, []byte(fn)) {
+			t.Fatalf("the original source does not contain %v", fn)
+		}
+	}
+	// Pretend that the crash needs the temporary dir and the program itself.
+	pred := func(src []byte) (bool, error) {
+		return bytes.Contains(src, []byte("\tuse_temporary_dir();\n")) &&
+			bytes.Contains(src, []byte("syscall(__NR_openat")), nil
+	}
+	res, err := Minimize(src, 0, pred, t.Logf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := pred(res); !ok {
+		t.Fatalf("the minimized source does not reproduce:\n%s", res)
+	}
+	for _, fn := range []string{"setup_sysctl", "initialize_tun", "setup_cgroups", "netlink_init"} {
+		if bytes.Contains(res, []byte(fn)) {
+			t.Errorf("the minimized source still contains %v", fn)
+		}
+	}
+	if lines, origLines := bytes.Count(res, []byte("\n")), bytes.Count(src, []byte("\n")); lines > origLines/2 {
+		t.Errorf("the source was minimized only from %v to %v lines", origLines, lines)
+	}
+	bin, err := Build(target, res)
+	if err != nil {
+		t.Fatalf("the minimized source does not build: %v\n%s", err, res)
+	}
+	os.Remove(bin)
+}
+
+const testMinimizeSrc = `// autogenerated by syzkaller (https://github.com/google/syzkaller)
+
+#define _GNU_SOURCE
+#include <unistd.h>
+
+#define SETUP_VALUE 0x10
+#define UNUSED_VALUE 42
+
+struct helper_t {
+	int x;
+};
+
+static struct helper_t helper;
+
+static void setup_helper(void)
+{
+	helper.x = SETUP_VALUE;
+}
+
+static void setup_other(void)
+{
+	if (getpid())
+		setup_helper();
+}
+
+int main(void)
+{
+	setup_helper();
+	setup_other();
+	return 0;
+}
+`
+
+func TestMinimizeItems(t *testing.T) {
+	items := parseItems(testMinimizeSrc)
+	var names []string
+	for _, item := range items {
+		names = append(names, item.names...)
+	}
+	if got, want := strings.Join(names, " "),
+		"SETUP_VALUE UNUSED_VALUE helper_t helper setup_helper setup_other main"; got != want {
+		t.Fatalf("got names %q, want %q", got, want)
+	}
+	// The call inside the if body must not be removed.
+	calls := helperCalls(items)
+	if len(calls) != 2 {
+		t.Fatalf("got %v helper calls, want 2", len(calls))
+	}
+	res := renderItems(items, calls, calls[1:], true)
+	want := `// autogenerated by syzkaller (https://github.com/google/syzkaller)
+
+#define _GNU_SOURCE
+#include <unistd.h>
+
+struct helper_t {
+	int x;
+};
+
+static struct helper_t helper;
+
+static void setup_helper(void)
+{
+	helper.x = 0x10;
+}
+
+static void setup_other(void)
+{
+	if (getpid())
+		setup_helper();
+}
+
+int main(void)
+{
+	setup_other();
+	return 0;
+}
+`
+	if string(res) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", res, want)
+	}
+}
+
+const testMinimizePPSrc = `#define _GNU_SOURCE
+#include <unistd.h>
+
+#if defined(__NR_mmap)
+#define MMAP_NR __NR_mmap
+#endif
+
+#define HELPER_MACRO(x) \
+	do {                \
+		(x)++;          \
+	} while (0)
+
+static long helper_open(volatile long a0,
+			volatile long a1,
+			volatile long a2)
+{
+	long x = a0 + a1 + a2;
+	HELPER_MACRO(x);
+	return x;
+}
+
+#if SYZ_EXECUTOR || __NR_syz_foo
+static void setup_foo(int x,
+		      int y)
+{
+	if (x) {
+		helper_open(x, y, 0);
+	}
+}
+#endif
+
+#if SYZ_SANDBOX_NONE
+static void setup_bar(void)
+{
+	setup_foo(1, 2);
+}
+#else
+static void setup_bar(void)
+{
+}
+#endif
+
+int main(void)
+{
+	setup_foo(1,
+		  2);
+	setup_bar();
+	return 0;
+}
+`
+
+func TestMinimizeItemsPreprocessor(t *testing.T) {
+	items := parseItems(testMinimizePPSrc)
+	var names []string
+	for _, item := range items {
+		names = append(names, item.names...)
+		if item.fn && len(item.lines) < 3 {
+			t.Errorf("function %v is split into several items", item.names)
+		}
+	}
+	if got, want := strings.Join(names, " "),
+		"MMAP_NR HELPER_MACRO helper_open setup_foo setup_bar setup_bar main"; got != want {
+		t.Fatalf("got names %q, want %q", got, want)
+	}
+	// The multi-line call of setup_foo in main is not recognized and is never removed.
+	calls := helperCalls(items)
+	var callLines []string
+	for _, call := range calls {
+		callLines = append(callLines, strings.TrimSpace(items[call.item].lines[call.line]))
+	}
+	if got, want := strings.Join(callLines, " "),
+		"helper_open(x, y, 0); setup_foo(1, 2); setup_bar();"; got != want {
+		t.Fatalf("got helper calls %q, want %q", got, want)
+	}
+	// setup_bar is still defined in both branches since it's called from main,
+	// the unused define is removed together with its #if block.
+	res := renderItems(items, calls, calls[1:], false)
+	want := `#define _GNU_SOURCE
+#include <unistd.h>
+
+#if SYZ_EXECUTOR || __NR_syz_foo
+static void setup_foo(int x,
+		      int y)
+{
+	if (x) {
+	}
+}
+#endif
+
+#if SYZ_SANDBOX_NONE
+static void setup_bar(void)
+{
+	setup_foo(1, 2);
+}
+#else
+static void setup_bar(void)
+{
+}
+#endif
+
+int main(void)
+{
+	setup_foo(1,
+		  2);
+	setup_bar();
+	return 0;
+}
+`
+	if string(res) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", res, want)
+	}
+	// Once helper_open is called, the multi-line define it uses is kept as well.
+	res = renderItems(items, calls, calls, false)
+	if !strings.Contains(string(res), "#define HELPER_MACRO(x) \\\n") ||
+		!strings.Contains(string(res), "static long helper_open(volatile long a0,\n") {
+		t.Fatalf("helper_open or its macro was removed:\n%s", res)
+	}
+}
+
+func TestMinimizeNoRepro(t *testing.T) {
+	// Removal of unused items is never tested during bisection, so the final source must be tested.
+	runs := 0
+	pred := func(src []byte) (bool, error) {
+		runs++
+		return bytes.Contains(src, []byte("UNUSED_VALUE")), nil
+	}
+	res, err := Minimize([]byte(testMinimizeSrc), 0, pred, t.Logf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res) != testMinimizeSrc {
+		t.Fatalf("got a source that does not reproduce:\n%s", res)
+	}
+	if runs > maxMinimizeSteps+2 {
+		t.Fatalf("too many pred runs: %v", runs)
+	}
+}
+
+func TestMinimizeMaxTime(t *testing.T) {
+	// Once out of time, only the final source is tested.
+	runs := 0
+	pred := func(src []byte) (bool, error) {
+		runs++
+		return true, nil
+	}
+	if _, err := Minimize([]byte(testMinimizeSrc), time.Nanosecond, pred, t.Logf); err != nil {
+		t.Fatal(err)
+	}
+	if runs > 1 {
+		t.Fatalf("too many pred runs: %v", runs)
+	}
+}
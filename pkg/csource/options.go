@@ -49,10 +49,24 @@ type Options struct {
 	UseTmpDir  bool `json:"tmpdir,omitempty"`
 	HandleSegv bool `json:"segv,omitempty"`
 
+	// Scheduling perturbation of the threads that execute calls (one of Sched* values).
+	Sched string `json:"sched,omitempty"`
+
 	Trace bool `json:"trace,omitempty"`
 	LegacyOptions
 }
 
+// Scheduling perturbations (Options.Sched) that help to reproduce data races
+// that need specific thread interleavings.
+const (
+	SchedYield  = "yield"  // yield the CPU a random number of times before calls
+	SchedPin    = "pin"    // pin all threads to a single CPU
+	SchedSpread = "spread" // pin threads to different CPUs
+	SchedDelay  = "delay"  // random delays after starting async calls
+)
+
+var SchedPerturbations = []string{SchedYield, SchedPin, SchedSpread, SchedDelay}
+
 // These are legacy options, they remain only for the sake of backward compatibility.
 type LegacyOptions struct {
 	Collide   bool `json:"collide,omitempty"`
@@ -74,6 +88,12 @@ func (opts Options) Check(OS string) error {
 		// Collide requires threaded.
 		return errors.New("option Collide without Threaded")
 	}
+	if _, err := flatrpc.SchedToFlags(opts.Sched); err != nil {
+		return err
+	}
+	if !opts.Threaded && opts.Sched != "" {
+		return errors.New("option Sched without Threaded")
+	}
 	if !opts.Repeat {
 		if opts.Procs > 1 {
 			// This does not affect generated code.
@@ -133,6 +153,9 @@ func (opts Options) checkLinuxOnly(OS string) error {
 		opts.Sandbox == sandboxAndroid {
 		return fmt.Errorf("option Sandbox=%v is not supported on %v", opts.Sandbox, OS)
 	}
+	if opts.Sched != "" {
+		return fmt.Errorf("option Sched is not supported on %v", OS)
+	}
 	for name, opt := range map[string]*bool{
 		"NetDevices":    &opts.NetDevices,
 		"NetReset":      &opts.NetReset,
@@ -247,6 +247,11 @@ func enumerateField(OS string, opt Options, field int) []Options {
 			fld.SetString(sandbox)
 			opts = append(opts, opt)
 		}
+	} else if fldName == "Sched" {
+		for _, sched := range append([]string{""}, SchedPerturbations...) {
+			fld.SetString(sched)
+			opts = append(opts, opt)
+		}
 	} else if fldName == "SandboxArg" {
 		for _, sandboxArg := range []int64{math.MinInt, math.MaxInt} {
 			fld.SetInt(sandboxArg)
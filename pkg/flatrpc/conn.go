@@ -86,7 +86,7 @@ func (s *Serv) Close() error {
 }
 
 type Conn struct {
-	conn net.Conn
+	conn io.ReadWriteCloser
 
 	sendMu  sync.Mutex
 	builder *flatbuffers.Builder
@@ -97,6 +97,10 @@ type Conn struct {
 }
 
 func NewConn(conn net.Conn) *Conn {
+	return newConn(conn)
+}
+
+func newConn(conn io.ReadWriteCloser) *Conn {
 	return &Conn{
 		conn:    conn,
 		builder: flatbuffers.NewBuilder(0),
@@ -225,7 +229,8 @@ func verifyExecutorMessage(raw *ExecutorMessageRaw, rawSize int) error {
 	switch typ := raw.MsgType(); typ {
 	case ExecutorMessagesRawExecResult,
 		ExecutorMessagesRawExecuting,
-		ExecutorMessagesRawState:
+		ExecutorMessagesRawState,
+		ExecutorMessagesRawExecBatchResult:
 	default:
 		return fmt.Errorf("bad executor message type %v", typ)
 	}
@@ -233,19 +238,53 @@ func verifyExecutorMessage(raw *ExecutorMessageRaw, rawSize int) error {
 	if !raw.Msg(&tab) {
 		return errors.New("received no message")
 	}
-	// Only ExecResult has arrays.
-	if raw.MsgType() == ExecutorMessagesRawExecResult {
+	// Only ExecResult and ExecBatchResult have arrays.
+	switch raw.MsgType() {
+	case ExecutorMessagesRawExecResult:
 		var res ExecResultRaw
 		res.Init(tab.Bytes, tab.Pos)
 		return verifyExecResult(&res, rawSize)
+	case ExecutorMessagesRawExecBatchResult:
+		var batch ExecBatchResultRaw
+		batch.Init(tab.Bytes, tab.Pos)
+		return verifyExecBatchResult(&batch, rawSize)
 	}
 	return nil
 }
 
 func verifyExecResult(res *ExecResultRaw, rawSize int) error {
+	return verifySize(execResultSize(res), rawSize)
+}
+
+func verifyExecBatchResult(batch *ExecBatchResultRaw, rawSize int) error {
+	// Each element of the vector takes at least a 4-byte offset.
+	if n := batch.ResultsLength(); n > rawSize/flatbuffers.SizeUOffsetT {
+		return fmt.Errorf("corrupted message: total size %v, %v results", rawSize, n)
+	}
+	size := 0
+	var res ExecResultRaw
+	for i := 0; i < batch.ResultsLength(); i++ {
+		if batch.Results(&res, i) {
+			size += execResultSize(&res)
+		}
+	}
+	return verifySize(size, rawSize)
+}
+
+func verifySize(size, rawSize int) error {
+	if size > rawSize {
+		return fmt.Errorf("corrupted message: total size %v, size of elements %v",
+			rawSize, size)
+	}
+	return nil
+}
+
+func execResultSize(res *ExecResultRaw) int {
+	// Each file takes at least a 4-byte offset in the message (name/data are referenced directly).
+	filesSize := res.FilesLength() * flatbuffers.SizeUOffsetT
 	info := res.Info(nil)
 	if info == nil {
-		return nil
+		return filesSize
 	}
 	var tmp ComparisonRaw
 	// It's hard to impose good limit on each individual signal/cover/comps array,
@@ -264,6 +303,7 @@ func verifyExecResult(res *ExecResultRaw, rawSize int) error {
 		if call.CompsLength() != 0 {
 			size += min(maxSize, call.CompsLength()) * int(unsafe.Sizeof(call.Comps(&tmp, 0)))
 		}
+		size += min(maxSize, call.SignalPackedLength()) + min(maxSize, call.CoverPackedLength())
 		return size
 	}
 	size := 0
@@ -281,9 +321,5 @@ func verifyExecResult(res *ExecResultRaw, rawSize int) error {
 	if info.Extra(&call) != nil {
 		size += callSize(&call)
 	}
-	if size > rawSize {
-		return fmt.Errorf("corrupted message: total size %v, size of elements %v",
-			rawSize, size)
-	}
-	return nil
+	return size + filesSize
 }
@@ -24,17 +24,21 @@ func TestConn(t *testing.T) {
 		Cookie: 1,
 	}
 	connectReq := &ConnectRequest{
-		Cookie:      73856093,
-		Id:          1,
-		Arch:        "arch",
-		GitRevision: "rev1",
-		SyzRevision: "rev2",
+		Cookie:       73856093,
+		Id:           1,
+		Arch:         "arch",
+		GitRevision:  "rev1",
+		SyzRevision:  "rev2",
+		MaxBatch:     16,
+		CompactCover: true,
 	}
 	connectReply := &ConnectReply{
-		LeakFrames: []string{"foo", "bar"},
-		RaceFrames: []string{"bar", "baz"},
-		Features:   FeatureCoverage | FeatureLeak,
-		Files:      []string{"file1"},
+		LeakFrames:   []string{"foo", "bar"},
+		RaceFrames:   []string{"bar", "baz"},
+		Features:     FeatureCoverage | FeatureLeak,
+		Files:        []string{"file1"},
+		BatchSize:    8,
+		CompactCover: true,
 	}
 	executorMsg := &ExecutorMessage{
 		Msg: &ExecutorMessages{
@@ -211,9 +215,19 @@ func FuzzRecv(f *testing.F) {
 			},
 		},
 	}
-	builder := flatbuffers.NewBuilder(0)
-	builder.FinishSizePrefixed(msg.Pack(builder))
-	f.Add(builder.FinishedBytes())
+	batchMsg := &ExecutorMessage{
+		Msg: &ExecutorMessages{
+			Type: ExecutorMessagesRawExecBatchResult,
+			Value: &ExecBatchResult{
+				Results: []*ExecResult{msg.Msg.Value.(*ExecResult), {Id: 2}},
+			},
+		},
+	}
+	for _, msg := range []*ExecutorMessage{msg, batchMsg} {
+		builder := flatbuffers.NewBuilder(0)
+		builder.FinishSizePrefixed(msg.Pack(builder))
+		f.Add(builder.FinishedBytes())
+	}
 	f.Fuzz(func(t *testing.T, data []byte) {
 		memoryLimitOnce.Do(func() {
 			debug.SetMemoryLimit(64 << 20)
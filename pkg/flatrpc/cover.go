@@ -0,0 +1,69 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package flatrpc
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// PackCover encodes signal/cover in the compact form used in CallInfoRaw.signal_packed/cover_packed:
+// each element is encoded as zigzag varint of the difference with the previous element.
+// PCs that are close to each other (in particular sorted deduplicated cover) take 1-3 bytes
+// instead of 8 bytes.
+func PackCover(pcs []uint64) []byte {
+	res := make([]byte, 0, len(pcs)*2)
+	prev := uint64(0)
+	for _, pc := range pcs {
+		res = binary.AppendVarint(res, int64(pc-prev))
+		prev = pc
+	}
+	return res
+}
+
+// UnpackCover decodes data produced by PackCover.
+func UnpackCover(data []byte) ([]uint64, error) {
+	n := 0
+	for _, b := range data {
+		if b < 0x80 {
+			n++
+		}
+	}
+	res := make([]uint64, 0, n)
+	prev := uint64(0)
+	for len(data) != 0 {
+		delta, size := binary.Varint(data)
+		if size <= 0 {
+			return nil, fmt.Errorf("corrupted packed cover at element %v", len(res))
+		}
+		data = data[size:]
+		prev += uint64(delta)
+		res = append(res, prev)
+	}
+	return res, nil
+}
+
+// UnpackCover replaces packed signal/cover with the decoded signal/cover.
+func (ci *CallInfo) UnpackCover() error {
+	if ci == nil {
+		return nil
+	}
+	if ci.SignalPacked != nil {
+		signal, err := UnpackCover(ci.SignalPacked)
+		if err != nil {
+			return fmt.Errorf("signal: %w", err)
+		}
+		ci.Signal = signal
+		ci.SignalPacked = nil
+	}
+	if ci.CoverPacked != nil {
+		cover, err := UnpackCover(ci.CoverPacked)
+		if err != nil {
+			return fmt.Errorf("cover: %w", err)
+		}
+		ci.Cover = cover
+		ci.CoverPacked = nil
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package flatrpc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackCover(t *testing.T) {
+	tests := [][]uint64{
+		nil,
+		{0},
+		{1, 2, 3},
+		{0xffffffff81000000, 0xffffffff81000010, 0xffffffff81000004, 0xffffffff82345678},
+		{math.MaxUint64, 0, math.MaxUint64, 1 << 63},
+	}
+	for _, pcs := range tests {
+		data := PackCover(pcs)
+		got, err := UnpackCover(data)
+		assert.NoError(t, err)
+		assert.Equal(t, len(pcs), len(got))
+		for i := range pcs {
+			assert.Equal(t, pcs[i], got[i])
+		}
+	}
+	// Sorted kernel PCs should take few bytes per PC.
+	var pcs []uint64
+	for pc := uint64(0xffffffff81000000); len(pcs) < 1000; pc += 17 {
+		pcs = append(pcs, pc)
+	}
+	assert.Less(t, len(PackCover(pcs)), 2*len(pcs)+16)
+}
+
+func TestUnpackCoverCorrupted(t *testing.T) {
+	_, err := UnpackCover([]byte{0x02, 0x80})
+	assert.Error(t, err)
+	_, err = UnpackCover([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01})
+	assert.Error(t, err)
+}
+
+func TestCallInfoUnpackCover(t *testing.T) {
+	ci := &CallInfo{
+		SignalPacked: PackCover([]uint64{10, 5, 20}),
+		CoverPacked:  PackCover([]uint64{100, 101}),
+	}
+	assert.NoError(t, ci.UnpackCover())
+	assert.Equal(t, []uint64{10, 5, 20}, ci.Signal)
+	assert.Equal(t, []uint64{100, 101}, ci.Cover)
+	assert.Nil(t, ci.SignalPacked)
+	assert.Nil(t, ci.CoverPacked)
+}
@@ -127,6 +127,7 @@ const (
 	HostMessagesRawSignalUpdate  HostMessagesRaw = 2
 	HostMessagesRawCorpusTriaged HostMessagesRaw = 3
 	HostMessagesRawStateRequest  HostMessagesRaw = 4
+	HostMessagesRawExecBatch     HostMessagesRaw = 5
 )
 
 var EnumNamesHostMessagesRaw = map[HostMessagesRaw]string{
@@ -135,6 +136,7 @@ var EnumNamesHostMessagesRaw = map[HostMessagesRaw]string{
 	HostMessagesRawSignalUpdate:  "SignalUpdate",
 	HostMessagesRawCorpusTriaged: "CorpusTriaged",
 	HostMessagesRawStateRequest:  "StateRequest",
+	HostMessagesRawExecBatch:     "ExecBatch",
 }
 
 var EnumValuesHostMessagesRaw = map[string]HostMessagesRaw{
@@ -143,6 +145,7 @@ var EnumValuesHostMessagesRaw = map[string]HostMessagesRaw{
 	"SignalUpdate":  HostMessagesRawSignalUpdate,
 	"CorpusTriaged": HostMessagesRawCorpusTriaged,
 	"StateRequest":  HostMessagesRawStateRequest,
+	"ExecBatch":     HostMessagesRawExecBatch,
 }
 
 func (v HostMessagesRaw) String() string {
@@ -170,6 +173,8 @@ func (t *HostMessagesRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffse
 		return t.Value.(*CorpusTriagedRawT).Pack(builder)
 	case HostMessagesRawStateRequest:
 		return t.Value.(*StateRequestRawT).Pack(builder)
+	case HostMessagesRawExecBatch:
+		return t.Value.(*ExecBatchRawT).Pack(builder)
 	}
 	return 0
 }
@@ -188,6 +193,9 @@ func (rcv HostMessagesRaw) UnPack(table flatbuffers.Table) *HostMessagesRawT {
 	case HostMessagesRawStateRequest:
 		x := StateRequestRaw{_tab: table}
 		return &HostMessagesRawT{Type: HostMessagesRawStateRequest, Value: x.UnPack()}
+	case HostMessagesRawExecBatch:
+		x := ExecBatchRaw{_tab: table}
+		return &HostMessagesRawT{Type: HostMessagesRawExecBatch, Value: x.UnPack()}
 	}
 	return nil
 }
@@ -195,24 +203,27 @@ func (rcv HostMessagesRaw) UnPack(table flatbuffers.Table) *HostMessagesRawT {
 type ExecutorMessagesRaw byte
 
 const (
-	ExecutorMessagesRawNONE       ExecutorMessagesRaw = 0
-	ExecutorMessagesRawExecResult ExecutorMessagesRaw = 1
-	ExecutorMessagesRawExecuting  ExecutorMessagesRaw = 2
-	ExecutorMessagesRawState      ExecutorMessagesRaw = 3
+	ExecutorMessagesRawNONE            ExecutorMessagesRaw = 0
+	ExecutorMessagesRawExecResult      ExecutorMessagesRaw = 1
+	ExecutorMessagesRawExecuting       ExecutorMessagesRaw = 2
+	ExecutorMessagesRawState           ExecutorMessagesRaw = 3
+	ExecutorMessagesRawExecBatchResult ExecutorMessagesRaw = 4
 )
 
 var EnumNamesExecutorMessagesRaw = map[ExecutorMessagesRaw]string{
-	ExecutorMessagesRawNONE:       "NONE",
-	ExecutorMessagesRawExecResult: "ExecResult",
-	ExecutorMessagesRawExecuting:  "Executing",
-	ExecutorMessagesRawState:      "State",
+	ExecutorMessagesRawNONE:            "NONE",
+	ExecutorMessagesRawExecResult:      "ExecResult",
+	ExecutorMessagesRawExecuting:       "Executing",
+	ExecutorMessagesRawState:           "State",
+	ExecutorMessagesRawExecBatchResult: "ExecBatchResult",
 }
 
 var EnumValuesExecutorMessagesRaw = map[string]ExecutorMessagesRaw{
-	"NONE":       ExecutorMessagesRawNONE,
-	"ExecResult": ExecutorMessagesRawExecResult,
-	"Executing":  ExecutorMessagesRawExecuting,
-	"State":      ExecutorMessagesRawState,
+	"NONE":            ExecutorMessagesRawNONE,
+	"ExecResult":      ExecutorMessagesRawExecResult,
+	"Executing":       ExecutorMessagesRawExecuting,
+	"State":           ExecutorMessagesRawState,
+	"ExecBatchResult": ExecutorMessagesRawExecBatchResult,
 }
 
 func (v ExecutorMessagesRaw) String() string {
@@ -238,6 +249,8 @@ func (t *ExecutorMessagesRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UO
 		return t.Value.(*ExecutingMessageRawT).Pack(builder)
 	case ExecutorMessagesRawState:
 		return t.Value.(*StateResultRawT).Pack(builder)
+	case ExecutorMessagesRawExecBatchResult:
+		return t.Value.(*ExecBatchResultRawT).Pack(builder)
 	}
 	return 0
 }
@@ -253,6 +266,9 @@ func (rcv ExecutorMessagesRaw) UnPack(table flatbuffers.Table) *ExecutorMessages
 	case ExecutorMessagesRawState:
 		x := StateResultRaw{_tab: table}
 		return &ExecutorMessagesRawT{Type: ExecutorMessagesRawState, Value: x.UnPack()}
+	case ExecutorMessagesRawExecBatchResult:
+		x := ExecBatchResultRaw{_tab: table}
+		return &ExecutorMessagesRawT{Type: ExecutorMessagesRawExecBatchResult, Value: x.UnPack()}
 	}
 	return nil
 }
@@ -260,21 +276,24 @@ func (rcv ExecutorMessagesRaw) UnPack(table flatbuffers.Table) *ExecutorMessages
 type RequestType uint64
 
 const (
-	RequestTypeProgram RequestType = 0
-	RequestTypeBinary  RequestType = 1
-	RequestTypeGlob    RequestType = 2
+	RequestTypeProgram  RequestType = 0
+	RequestTypeBinary   RequestType = 1
+	RequestTypeGlob     RequestType = 2
+	RequestTypeReadFile RequestType = 3
 )
 
 var EnumNamesRequestType = map[RequestType]string{
-	RequestTypeProgram: "Program",
-	RequestTypeBinary:  "Binary",
-	RequestTypeGlob:    "Glob",
+	RequestTypeProgram:  "Program",
+	RequestTypeBinary:   "Binary",
+	RequestTypeGlob:     "Glob",
+	RequestTypeReadFile: "ReadFile",
 }
 
 var EnumValuesRequestType = map[string]RequestType{
-	"Program": RequestTypeProgram,
-	"Binary":  RequestTypeBinary,
-	"Glob":    RequestTypeGlob,
+	"Program":  RequestTypeProgram,
+	"Binary":   RequestTypeBinary,
+	"Glob":     RequestTypeGlob,
+	"ReadFile": RequestTypeReadFile,
 }
 
 func (v RequestType) String() string {
@@ -388,6 +407,10 @@ const (
 	ExecFlagDedupCover    ExecFlag = 4
 	ExecFlagCollectComps  ExecFlag = 8
 	ExecFlagThreaded      ExecFlag = 16
+	ExecFlagSchedYield    ExecFlag = 32
+	ExecFlagSchedPin      ExecFlag = 64
+	ExecFlagSchedSpread   ExecFlag = 128
+	ExecFlagSchedDelay    ExecFlag = 256
 )
 
 var EnumNamesExecFlag = map[ExecFlag]string{
@@ -396,6 +419,10 @@ var EnumNamesExecFlag = map[ExecFlag]string{
 	ExecFlagDedupCover:    "DedupCover",
 	ExecFlagCollectComps:  "CollectComps",
 	ExecFlagThreaded:      "Threaded",
+	ExecFlagSchedYield:    "SchedYield",
+	ExecFlagSchedPin:      "SchedPin",
+	ExecFlagSchedSpread:   "SchedSpread",
+	ExecFlagSchedDelay:    "SchedDelay",
 }
 
 var EnumValuesExecFlag = map[string]ExecFlag{
@@ -404,6 +431,10 @@ var EnumValuesExecFlag = map[string]ExecFlag{
 	"DedupCover":    ExecFlagDedupCover,
 	"CollectComps":  ExecFlagCollectComps,
 	"Threaded":      ExecFlagThreaded,
+	"SchedYield":    ExecFlagSchedYield,
+	"SchedPin":      ExecFlagSchedPin,
+	"SchedSpread":   ExecFlagSchedSpread,
+	"SchedDelay":    ExecFlagSchedDelay,
 }
 
 func (v ExecFlag) String() string {
@@ -561,11 +592,13 @@ func ConnectHelloRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 }
 
 type ConnectRequestRawT struct {
-	Cookie      uint64 `json:"cookie"`
-	Id          int64  `json:"id"`
-	Arch        string `json:"arch"`
-	GitRevision string `json:"git_revision"`
-	SyzRevision string `json:"syz_revision"`
+	Cookie       uint64 `json:"cookie"`
+	Id           int64  `json:"id"`
+	Arch         string `json:"arch"`
+	GitRevision  string `json:"git_revision"`
+	SyzRevision  string `json:"syz_revision"`
+	MaxBatch     int32  `json:"max_batch"`
+	CompactCover bool   `json:"compact_cover"`
 }
 
 func (t *ConnectRequestRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
@@ -581,6 +614,8 @@ func (t *ConnectRequestRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOff
 	ConnectRequestRawAddArch(builder, archOffset)
 	ConnectRequestRawAddGitRevision(builder, gitRevisionOffset)
 	ConnectRequestRawAddSyzRevision(builder, syzRevisionOffset)
+	ConnectRequestRawAddMaxBatch(builder, t.MaxBatch)
+	ConnectRequestRawAddCompactCover(builder, t.CompactCover)
 	return ConnectRequestRawEnd(builder)
 }
 
@@ -590,6 +625,8 @@ func (rcv *ConnectRequestRaw) UnPackTo(t *ConnectRequestRawT) {
 	t.Arch = string(rcv.Arch())
 	t.GitRevision = string(rcv.GitRevision())
 	t.SyzRevision = string(rcv.SyzRevision())
+	t.MaxBatch = rcv.MaxBatch()
+	t.CompactCover = rcv.CompactCover()
 }
 
 func (rcv *ConnectRequestRaw) UnPack() *ConnectRequestRawT {
@@ -676,8 +713,32 @@ func (rcv *ConnectRequestRaw) SyzRevision() []byte {
 	return nil
 }
 
+func (rcv *ConnectRequestRaw) MaxBatch() int32 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		return rcv._tab.GetInt32(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *ConnectRequestRaw) MutateMaxBatch(n int32) bool {
+	return rcv._tab.MutateInt32Slot(14, n)
+}
+
+func (rcv *ConnectRequestRaw) CompactCover() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *ConnectRequestRaw) MutateCompactCover(n bool) bool {
+	return rcv._tab.MutateBoolSlot(16, n)
+}
+
 func ConnectRequestRawStart(builder *flatbuffers.Builder) {
-	builder.StartObject(5)
+	builder.StartObject(7)
 }
 func ConnectRequestRawAddCookie(builder *flatbuffers.Builder, cookie uint64) {
 	builder.PrependUint64Slot(0, cookie, 0)
@@ -694,6 +755,12 @@ func ConnectRequestRawAddGitRevision(builder *flatbuffers.Builder, gitRevision f
 func ConnectRequestRawAddSyzRevision(builder *flatbuffers.Builder, syzRevision flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(4, flatbuffers.UOffsetT(syzRevision), 0)
 }
+func ConnectRequestRawAddMaxBatch(builder *flatbuffers.Builder, maxBatch int32) {
+	builder.PrependInt32Slot(5, maxBatch, 0)
+}
+func ConnectRequestRawAddCompactCover(builder *flatbuffers.Builder, compactCover bool) {
+	builder.PrependBoolSlot(6, compactCover, false)
+}
 func ConnectRequestRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -711,6 +778,8 @@ type ConnectReplyRawT struct {
 	RaceFrames       []string `json:"race_frames"`
 	Features         Feature  `json:"features"`
 	Files            []string `json:"files"`
+	BatchSize        int32    `json:"batch_size"`
+	CompactCover     bool     `json:"compact_cover"`
 }
 
 func (t *ConnectReplyRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
@@ -769,6 +838,8 @@ func (t *ConnectReplyRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffse
 	ConnectReplyRawAddRaceFrames(builder, raceFramesOffset)
 	ConnectReplyRawAddFeatures(builder, t.Features)
 	ConnectReplyRawAddFiles(builder, filesOffset)
+	ConnectReplyRawAddBatchSize(builder, t.BatchSize)
+	ConnectReplyRawAddCompactCover(builder, t.CompactCover)
 	return ConnectReplyRawEnd(builder)
 }
 
@@ -797,6 +868,8 @@ func (rcv *ConnectReplyRaw) UnPackTo(t *ConnectReplyRawT) {
 	for j := 0; j < filesLength; j++ {
 		t.Files[j] = string(rcv.Files(j))
 	}
+	t.BatchSize = rcv.BatchSize()
+	t.CompactCover = rcv.CompactCover()
 }
 
 func (rcv *ConnectReplyRaw) UnPack() *ConnectReplyRawT {
@@ -994,8 +1067,32 @@ func (rcv *ConnectReplyRaw) FilesLength() int {
 	return 0
 }
 
+func (rcv *ConnectReplyRaw) BatchSize() int32 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(28))
+	if o != 0 {
+		return rcv._tab.GetInt32(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *ConnectReplyRaw) MutateBatchSize(n int32) bool {
+	return rcv._tab.MutateInt32Slot(28, n)
+}
+
+func (rcv *ConnectReplyRaw) CompactCover() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(30))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *ConnectReplyRaw) MutateCompactCover(n bool) bool {
+	return rcv._tab.MutateBoolSlot(30, n)
+}
+
 func ConnectReplyRawStart(builder *flatbuffers.Builder) {
-	builder.StartObject(12)
+	builder.StartObject(14)
 }
 func ConnectReplyRawAddDebug(builder *flatbuffers.Builder, debug bool) {
 	builder.PrependBoolSlot(0, debug, false)
@@ -1042,6 +1139,12 @@ func ConnectReplyRawAddFiles(builder *flatbuffers.Builder, files flatbuffers.UOf
 func ConnectReplyRawStartFilesVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
 	return builder.StartVector(4, numElems, 4)
 }
+func ConnectReplyRawAddBatchSize(builder *flatbuffers.Builder, batchSize int32) {
+	builder.PrependInt32Slot(12, batchSize, 0)
+}
+func ConnectReplyRawAddCompactCover(builder *flatbuffers.Builder, compactCover bool) {
+	builder.PrependBoolSlot(13, compactCover, false)
+}
 func ConnectReplyRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -2197,6 +2300,111 @@ func ExecRequestRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
 
+type ExecBatchRawT struct {
+	Requests []*ExecRequestRawT `json:"requests"`
+}
+
+func (t *ExecBatchRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	requestsOffset := flatbuffers.UOffsetT(0)
+	if t.Requests != nil {
+		requestsLength := len(t.Requests)
+		requestsOffsets := make([]flatbuffers.UOffsetT, requestsLength)
+		for j := 0; j < requestsLength; j++ {
+			requestsOffsets[j] = t.Requests[j].Pack(builder)
+		}
+		ExecBatchRawStartRequestsVector(builder, requestsLength)
+		for j := requestsLength - 1; j >= 0; j-- {
+			builder.PrependUOffsetT(requestsOffsets[j])
+		}
+		requestsOffset = builder.EndVector(requestsLength)
+	}
+	ExecBatchRawStart(builder)
+	ExecBatchRawAddRequests(builder, requestsOffset)
+	return ExecBatchRawEnd(builder)
+}
+
+func (rcv *ExecBatchRaw) UnPackTo(t *ExecBatchRawT) {
+	requestsLength := rcv.RequestsLength()
+	t.Requests = make([]*ExecRequestRawT, requestsLength)
+	for j := 0; j < requestsLength; j++ {
+		x := ExecRequestRaw{}
+		rcv.Requests(&x, j)
+		t.Requests[j] = x.UnPack()
+	}
+}
+
+func (rcv *ExecBatchRaw) UnPack() *ExecBatchRawT {
+	if rcv == nil {
+		return nil
+	}
+	t := &ExecBatchRawT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
+type ExecBatchRaw struct {
+	_tab flatbuffers.Table
+}
+
+func GetRootAsExecBatchRaw(buf []byte, offset flatbuffers.UOffsetT) *ExecBatchRaw {
+	n := flatbuffers.GetUOffsetT(buf[offset:])
+	x := &ExecBatchRaw{}
+	x.Init(buf, n+offset)
+	return x
+}
+
+func GetSizePrefixedRootAsExecBatchRaw(buf []byte, offset flatbuffers.UOffsetT) *ExecBatchRaw {
+	n := flatbuffers.GetUOffsetT(buf[offset+flatbuffers.SizeUint32:])
+	x := &ExecBatchRaw{}
+	x.Init(buf, n+offset+flatbuffers.SizeUint32)
+	return x
+}
+
+func (rcv *ExecBatchRaw) Init(buf []byte, i flatbuffers.UOffsetT) {
+	rcv._tab.Bytes = buf
+	rcv._tab.Pos = i
+}
+
+func (rcv *ExecBatchRaw) Table() flatbuffers.Table {
+	return rcv._tab
+}
+
+func (rcv *ExecBatchRaw) Requests(obj *ExecRequestRaw, j int) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		x := rcv._tab.Vector(o)
+		x += flatbuffers.UOffsetT(j) * 4
+		x = rcv._tab.Indirect(x)
+		obj.Init(rcv._tab.Bytes, x)
+		return true
+	}
+	return false
+}
+
+func (rcv *ExecBatchRaw) RequestsLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func ExecBatchRawStart(builder *flatbuffers.Builder) {
+	builder.StartObject(1)
+}
+func ExecBatchRawAddRequests(builder *flatbuffers.Builder, requests flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(requests), 0)
+}
+func ExecBatchRawStartRequestsVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(4, numElems, 4)
+}
+func ExecBatchRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	return builder.EndObject()
+}
+
 type SignalUpdateRawT struct {
 	NewMax []uint64 `json:"new_max"`
 }
@@ -2546,11 +2754,13 @@ func ExecutingMessageRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 }
 
 type CallInfoRawT struct {
-	Flags  CallFlag          `json:"flags"`
-	Error  int32             `json:"error"`
-	Signal []uint64          `json:"signal"`
-	Cover  []uint64          `json:"cover"`
-	Comps  []*ComparisonRawT `json:"comps"`
+	Flags        CallFlag          `json:"flags"`
+	Error        int32             `json:"error"`
+	Signal       []uint64          `json:"signal"`
+	Cover        []uint64          `json:"cover"`
+	Comps        []*ComparisonRawT `json:"comps"`
+	SignalPacked []byte            `json:"signal_packed"`
+	CoverPacked  []byte            `json:"cover_packed"`
 }
 
 func (t *CallInfoRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
@@ -2584,12 +2794,22 @@ func (t *CallInfoRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 		}
 		compsOffset = builder.EndVector(compsLength)
 	}
+	signalPackedOffset := flatbuffers.UOffsetT(0)
+	if t.SignalPacked != nil {
+		signalPackedOffset = builder.CreateByteString(t.SignalPacked)
+	}
+	coverPackedOffset := flatbuffers.UOffsetT(0)
+	if t.CoverPacked != nil {
+		coverPackedOffset = builder.CreateByteString(t.CoverPacked)
+	}
 	CallInfoRawStart(builder)
 	CallInfoRawAddFlags(builder, t.Flags)
 	CallInfoRawAddError(builder, t.Error)
 	CallInfoRawAddSignal(builder, signalOffset)
 	CallInfoRawAddCover(builder, coverOffset)
 	CallInfoRawAddComps(builder, compsOffset)
+	CallInfoRawAddSignalPacked(builder, signalPackedOffset)
+	CallInfoRawAddCoverPacked(builder, coverPackedOffset)
 	return CallInfoRawEnd(builder)
 }
 
@@ -2613,6 +2833,8 @@ func (rcv *CallInfoRaw) UnPackTo(t *CallInfoRawT) {
 		rcv.Comps(&x, j)
 		t.Comps[j] = x.UnPack()
 	}
+	t.SignalPacked = rcv.SignalPackedBytes()
+	t.CoverPacked = rcv.CoverPackedBytes()
 }
 
 func (rcv *CallInfoRaw) UnPack() *CallInfoRawT {
@@ -2746,8 +2968,76 @@ func (rcv *CallInfoRaw) CompsLength() int {
 	return 0
 }
 
+func (rcv *CallInfoRaw) SignalPacked(j int) byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.GetByte(a + flatbuffers.UOffsetT(j*1))
+	}
+	return 0
+}
+
+func (rcv *CallInfoRaw) SignalPackedLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *CallInfoRaw) SignalPackedBytes() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CallInfoRaw) MutateSignalPacked(j int, n byte) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.MutateByte(a+flatbuffers.UOffsetT(j*1), n)
+	}
+	return false
+}
+
+func (rcv *CallInfoRaw) CoverPacked(j int) byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.GetByte(a + flatbuffers.UOffsetT(j*1))
+	}
+	return 0
+}
+
+func (rcv *CallInfoRaw) CoverPackedLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *CallInfoRaw) CoverPackedBytes() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CallInfoRaw) MutateCoverPacked(j int, n byte) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.MutateByte(a+flatbuffers.UOffsetT(j*1), n)
+	}
+	return false
+}
+
 func CallInfoRawStart(builder *flatbuffers.Builder) {
-	builder.StartObject(5)
+	builder.StartObject(7)
 }
 func CallInfoRawAddFlags(builder *flatbuffers.Builder, flags CallFlag) {
 	builder.PrependByteSlot(0, byte(flags), 0)
@@ -2773,6 +3063,18 @@ func CallInfoRawAddComps(builder *flatbuffers.Builder, comps flatbuffers.UOffset
 func CallInfoRawStartCompsVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
 	return builder.StartVector(32, numElems, 8)
 }
+func CallInfoRawAddSignalPacked(builder *flatbuffers.Builder, signalPacked flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(5, flatbuffers.UOffsetT(signalPacked), 0)
+}
+func CallInfoRawStartSignalPackedVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(1, numElems, 1)
+}
+func CallInfoRawAddCoverPacked(builder *flatbuffers.Builder, coverPacked flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(6, flatbuffers.UOffsetT(coverPacked), 0)
+}
+func CallInfoRawStartCoverPackedVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(1, numElems, 1)
+}
 func CallInfoRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -3067,12 +3369,13 @@ func ProgInfoRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 }
 
 type ExecResultRawT struct {
-	Id     int64         `json:"id"`
-	Proc   int32         `json:"proc"`
-	Output []byte        `json:"output"`
-	Hanged bool          `json:"hanged"`
-	Error  string        `json:"error"`
-	Info   *ProgInfoRawT `json:"info"`
+	Id     int64           `json:"id"`
+	Proc   int32           `json:"proc"`
+	Output []byte          `json:"output"`
+	Hanged bool            `json:"hanged"`
+	Error  string          `json:"error"`
+	Info   *ProgInfoRawT   `json:"info"`
+	Files  []*FileInfoRawT `json:"files"`
 }
 
 func (t *ExecResultRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
@@ -3085,6 +3388,19 @@ func (t *ExecResultRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT
 	}
 	errorOffset := builder.CreateString(t.Error)
 	infoOffset := t.Info.Pack(builder)
+	filesOffset := flatbuffers.UOffsetT(0)
+	if t.Files != nil {
+		filesLength := len(t.Files)
+		filesOffsets := make([]flatbuffers.UOffsetT, filesLength)
+		for j := 0; j < filesLength; j++ {
+			filesOffsets[j] = t.Files[j].Pack(builder)
+		}
+		ExecResultRawStartFilesVector(builder, filesLength)
+		for j := filesLength - 1; j >= 0; j-- {
+			builder.PrependUOffsetT(filesOffsets[j])
+		}
+		filesOffset = builder.EndVector(filesLength)
+	}
 	ExecResultRawStart(builder)
 	ExecResultRawAddId(builder, t.Id)
 	ExecResultRawAddProc(builder, t.Proc)
@@ -3092,6 +3408,7 @@ func (t *ExecResultRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT
 	ExecResultRawAddHanged(builder, t.Hanged)
 	ExecResultRawAddError(builder, errorOffset)
 	ExecResultRawAddInfo(builder, infoOffset)
+	ExecResultRawAddFiles(builder, filesOffset)
 	return ExecResultRawEnd(builder)
 }
 
@@ -3102,6 +3419,13 @@ func (rcv *ExecResultRaw) UnPackTo(t *ExecResultRawT) {
 	t.Hanged = rcv.Hanged()
 	t.Error = string(rcv.Error())
 	t.Info = rcv.Info(nil).UnPack()
+	filesLength := rcv.FilesLength()
+	t.Files = make([]*FileInfoRawT, filesLength)
+	for j := 0; j < filesLength; j++ {
+		x := FileInfoRaw{}
+		rcv.Files(&x, j)
+		t.Files[j] = x.UnPack()
+	}
 }
 
 func (rcv *ExecResultRaw) UnPack() *ExecResultRawT {
@@ -3231,8 +3555,28 @@ func (rcv *ExecResultRaw) Info(obj *ProgInfoRaw) *ProgInfoRaw {
 	return nil
 }
 
+func (rcv *ExecResultRaw) Files(obj *FileInfoRaw, j int) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		x := rcv._tab.Vector(o)
+		x += flatbuffers.UOffsetT(j) * 4
+		x = rcv._tab.Indirect(x)
+		obj.Init(rcv._tab.Bytes, x)
+		return true
+	}
+	return false
+}
+
+func (rcv *ExecResultRaw) FilesLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
 func ExecResultRawStart(builder *flatbuffers.Builder) {
-	builder.StartObject(6)
+	builder.StartObject(7)
 }
 func ExecResultRawAddId(builder *flatbuffers.Builder, id int64) {
 	builder.PrependInt64Slot(0, id, 0)
@@ -3255,10 +3599,121 @@ func ExecResultRawAddError(builder *flatbuffers.Builder, error flatbuffers.UOffs
 func ExecResultRawAddInfo(builder *flatbuffers.Builder, info flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(5, flatbuffers.UOffsetT(info), 0)
 }
+func ExecResultRawAddFiles(builder *flatbuffers.Builder, files flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(6, flatbuffers.UOffsetT(files), 0)
+}
+func ExecResultRawStartFilesVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(4, numElems, 4)
+}
 func ExecResultRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
 
+type ExecBatchResultRawT struct {
+	Results []*ExecResultRawT `json:"results"`
+}
+
+func (t *ExecBatchResultRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	resultsOffset := flatbuffers.UOffsetT(0)
+	if t.Results != nil {
+		resultsLength := len(t.Results)
+		resultsOffsets := make([]flatbuffers.UOffsetT, resultsLength)
+		for j := 0; j < resultsLength; j++ {
+			resultsOffsets[j] = t.Results[j].Pack(builder)
+		}
+		ExecBatchResultRawStartResultsVector(builder, resultsLength)
+		for j := resultsLength - 1; j >= 0; j-- {
+			builder.PrependUOffsetT(resultsOffsets[j])
+		}
+		resultsOffset = builder.EndVector(resultsLength)
+	}
+	ExecBatchResultRawStart(builder)
+	ExecBatchResultRawAddResults(builder, resultsOffset)
+	return ExecBatchResultRawEnd(builder)
+}
+
+func (rcv *ExecBatchResultRaw) UnPackTo(t *ExecBatchResultRawT) {
+	resultsLength := rcv.ResultsLength()
+	t.Results = make([]*ExecResultRawT, resultsLength)
+	for j := 0; j < resultsLength; j++ {
+		x := ExecResultRaw{}
+		rcv.Results(&x, j)
+		t.Results[j] = x.UnPack()
+	}
+}
+
+func (rcv *ExecBatchResultRaw) UnPack() *ExecBatchResultRawT {
+	if rcv == nil {
+		return nil
+	}
+	t := &ExecBatchResultRawT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
+type ExecBatchResultRaw struct {
+	_tab flatbuffers.Table
+}
+
+func GetRootAsExecBatchResultRaw(buf []byte, offset flatbuffers.UOffsetT) *ExecBatchResultRaw {
+	n := flatbuffers.GetUOffsetT(buf[offset:])
+	x := &ExecBatchResultRaw{}
+	x.Init(buf, n+offset)
+	return x
+}
+
+func GetSizePrefixedRootAsExecBatchResultRaw(buf []byte, offset flatbuffers.UOffsetT) *ExecBatchResultRaw {
+	n := flatbuffers.GetUOffsetT(buf[offset+flatbuffers.SizeUint32:])
+	x := &ExecBatchResultRaw{}
+	x.Init(buf, n+offset+flatbuffers.SizeUint32)
+	return x
+}
+
+func (rcv *ExecBatchResultRaw) Init(buf []byte, i flatbuffers.UOffsetT) {
+	rcv._tab.Bytes = buf
+	rcv._tab.Pos = i
+}
+
+func (rcv *ExecBatchResultRaw) Table() flatbuffers.Table {
+	return rcv._tab
+}
+
+func (rcv *ExecBatchResultRaw) Results(obj *ExecResultRaw, j int) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		x := rcv._tab.Vector(o)
+		x += flatbuffers.UOffsetT(j) * 4
+		x = rcv._tab.Indirect(x)
+		obj.Init(rcv._tab.Bytes, x)
+		return true
+	}
+	return false
+}
+
+func (rcv *ExecBatchResultRaw) ResultsLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func ExecBatchResultRawStart(builder *flatbuffers.Builder) {
+	builder.StartObject(1)
+}
+func ExecBatchResultRawAddResults(builder *flatbuffers.Builder, results flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(results), 0)
+}
+func ExecBatchResultRawStartResultsVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(4, numElems, 4)
+}
+func ExecBatchResultRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	return builder.EndObject()
+}
+
 type StateResultRawT struct {
 	Data []byte `json:"data"`
 }
@@ -61,6 +61,10 @@ struct ExecRequestRaw;
 struct ExecRequestRawBuilder;
 struct ExecRequestRawT;
 
+struct ExecBatchRaw;
+struct ExecBatchRawBuilder;
+struct ExecBatchRawT;
+
 struct SignalUpdateRaw;
 struct SignalUpdateRawBuilder;
 struct SignalUpdateRawT;
@@ -91,6 +95,10 @@ struct ExecResultRaw;
 struct ExecResultRawBuilder;
 struct ExecResultRawT;
 
+struct ExecBatchResultRaw;
+struct ExecBatchResultRawBuilder;
+struct ExecBatchResultRawT;
+
 struct StateResultRaw;
 struct StateResultRawBuilder;
 struct StateResultRawT;
@@ -223,35 +231,38 @@ enum class HostMessagesRaw : uint8_t {
   SignalUpdate = 2,
   CorpusTriaged = 3,
   StateRequest = 4,
+  ExecBatch = 5,
   MIN = NONE,
-  MAX = StateRequest
+  MAX = ExecBatch
 };
 
-inline const HostMessagesRaw (&EnumValuesHostMessagesRaw())[5] {
+inline const HostMessagesRaw (&EnumValuesHostMessagesRaw())[6] {
   static const HostMessagesRaw values[] = {
     HostMessagesRaw::NONE,
     HostMessagesRaw::ExecRequest,
     HostMessagesRaw::SignalUpdate,
     HostMessagesRaw::CorpusTriaged,
-    HostMessagesRaw::StateRequest
+    HostMessagesRaw::StateRequest,
+    HostMessagesRaw::ExecBatch
   };
   return values;
 }
 
 inline const char * const *EnumNamesHostMessagesRaw() {
-  static const char * const names[6] = {
+  static const char * const names[7] = {
     "NONE",
     "ExecRequest",
     "SignalUpdate",
     "CorpusTriaged",
     "StateRequest",
+    "ExecBatch",
     nullptr
   };
   return names;
 }
 
 inline const char *EnumNameHostMessagesRaw(HostMessagesRaw e) {
-  if (flatbuffers::IsOutRange(e, HostMessagesRaw::NONE, HostMessagesRaw::StateRequest)) return "";
+  if (flatbuffers::IsOutRange(e, HostMessagesRaw::NONE, HostMessagesRaw::ExecBatch)) return "";
   const size_t index = static_cast<size_t>(e);
   return EnumNamesHostMessagesRaw()[index];
 }
@@ -276,6 +287,10 @@ template<> struct HostMessagesRawTraits<rpc::StateRequestRaw> {
   static const HostMessagesRaw enum_value = HostMessagesRaw::StateRequest;
 };
 
+template<> struct HostMessagesRawTraits<rpc::ExecBatchRaw> {
+  static const HostMessagesRaw enum_value = HostMessagesRaw::ExecBatch;
+};
+
 template<typename T> struct HostMessagesRawUnionTraits {
   static const HostMessagesRaw enum_value = HostMessagesRaw::NONE;
 };
@@ -296,6 +311,10 @@ template<> struct HostMessagesRawUnionTraits<rpc::StateRequestRawT> {
   static const HostMessagesRaw enum_value = HostMessagesRaw::StateRequest;
 };
 
+template<> struct HostMessagesRawUnionTraits<rpc::ExecBatchRawT> {
+  static const HostMessagesRaw enum_value = HostMessagesRaw::ExecBatch;
+};
+
 struct HostMessagesRawUnion {
   HostMessagesRaw type;
   void *value;
@@ -358,6 +377,14 @@ struct HostMessagesRawUnion {
     return type == HostMessagesRaw::StateRequest ?
       reinterpret_cast<const rpc::StateRequestRawT *>(value) : nullptr;
   }
+  rpc::ExecBatchRawT *AsExecBatch() {
+    return type == HostMessagesRaw::ExecBatch ?
+      reinterpret_cast<rpc::ExecBatchRawT *>(value) : nullptr;
+  }
+  const rpc::ExecBatchRawT *AsExecBatch() const {
+    return type == HostMessagesRaw::ExecBatch ?
+      reinterpret_cast<const rpc::ExecBatchRawT *>(value) : nullptr;
+  }
 };
 
 bool VerifyHostMessagesRaw(flatbuffers::Verifier &verifier, const void *obj, HostMessagesRaw type);
@@ -368,33 +395,36 @@ enum class ExecutorMessagesRaw : uint8_t {
   ExecResult = 1,
   Executing = 2,
   State = 3,
+  ExecBatchResult = 4,
   MIN = NONE,
-  MAX = State
+  MAX = ExecBatchResult
 };
 
-inline const ExecutorMessagesRaw (&EnumValuesExecutorMessagesRaw())[4] {
+inline const ExecutorMessagesRaw (&EnumValuesExecutorMessagesRaw())[5] {
   static const ExecutorMessagesRaw values[] = {
     ExecutorMessagesRaw::NONE,
     ExecutorMessagesRaw::ExecResult,
     ExecutorMessagesRaw::Executing,
-    ExecutorMessagesRaw::State
+    ExecutorMessagesRaw::State,
+    ExecutorMessagesRaw::ExecBatchResult
   };
   return values;
 }
 
 inline const char * const *EnumNamesExecutorMessagesRaw() {
-  static const char * const names[5] = {
+  static const char * const names[6] = {
     "NONE",
     "ExecResult",
     "Executing",
     "State",
+    "ExecBatchResult",
     nullptr
   };
   return names;
 }
 
 inline const char *EnumNameExecutorMessagesRaw(ExecutorMessagesRaw e) {
-  if (flatbuffers::IsOutRange(e, ExecutorMessagesRaw::NONE, ExecutorMessagesRaw::State)) return "";
+  if (flatbuffers::IsOutRange(e, ExecutorMessagesRaw::NONE, ExecutorMessagesRaw::ExecBatchResult)) return "";
   const size_t index = static_cast<size_t>(e);
   return EnumNamesExecutorMessagesRaw()[index];
 }
@@ -415,6 +445,10 @@ template<> struct ExecutorMessagesRawTraits<rpc::StateResultRaw> {
   static const ExecutorMessagesRaw enum_value = ExecutorMessagesRaw::State;
 };
 
+template<> struct ExecutorMessagesRawTraits<rpc::ExecBatchResultRaw> {
+  static const ExecutorMessagesRaw enum_value = ExecutorMessagesRaw::ExecBatchResult;
+};
+
 template<typename T> struct ExecutorMessagesRawUnionTraits {
   static const ExecutorMessagesRaw enum_value = ExecutorMessagesRaw::NONE;
 };
@@ -431,6 +465,10 @@ template<> struct ExecutorMessagesRawUnionTraits<rpc::StateResultRawT> {
   static const ExecutorMessagesRaw enum_value = ExecutorMessagesRaw::State;
 };
 
+template<> struct ExecutorMessagesRawUnionTraits<rpc::ExecBatchResultRawT> {
+  static const ExecutorMessagesRaw enum_value = ExecutorMessagesRaw::ExecBatchResult;
+};
+
 struct ExecutorMessagesRawUnion {
   ExecutorMessagesRaw type;
   void *value;
@@ -485,6 +523,14 @@ struct ExecutorMessagesRawUnion {
     return type == ExecutorMessagesRaw::State ?
       reinterpret_cast<const rpc::StateResultRawT *>(value) : nullptr;
   }
+  rpc::ExecBatchResultRawT *AsExecBatchResult() {
+    return type == ExecutorMessagesRaw::ExecBatchResult ?
+      reinterpret_cast<rpc::ExecBatchResultRawT *>(value) : nullptr;
+  }
+  const rpc::ExecBatchResultRawT *AsExecBatchResult() const {
+    return type == ExecutorMessagesRaw::ExecBatchResult ?
+      reinterpret_cast<const rpc::ExecBatchResultRawT *>(value) : nullptr;
+  }
 };
 
 bool VerifyExecutorMessagesRaw(flatbuffers::Verifier &verifier, const void *obj, ExecutorMessagesRaw type);
@@ -494,31 +540,34 @@ enum class RequestType : uint64_t {
   Program = 0,
   Binary = 1ULL,
   Glob = 2ULL,
+  ReadFile = 3ULL,
   MIN = Program,
-  MAX = Glob
+  MAX = ReadFile
 };
 
-inline const RequestType (&EnumValuesRequestType())[3] {
+inline const RequestType (&EnumValuesRequestType())[4] {
   static const RequestType values[] = {
     RequestType::Program,
     RequestType::Binary,
-    RequestType::Glob
+    RequestType::Glob,
+    RequestType::ReadFile
   };
   return values;
 }
 
 inline const char * const *EnumNamesRequestType() {
-  static const char * const names[4] = {
+  static const char * const names[5] = {
     "Program",
     "Binary",
     "Glob",
+    "ReadFile",
     nullptr
   };
   return names;
 }
 
 inline const char *EnumNameRequestType(RequestType e) {
-  if (flatbuffers::IsOutRange(e, RequestType::Program, RequestType::Glob)) return "";
+  if (flatbuffers::IsOutRange(e, RequestType::Program, RequestType::ReadFile)) return "";
   const size_t index = static_cast<size_t>(e);
   return EnumNamesRequestType()[index];
 }
@@ -632,49 +681,43 @@ enum class ExecFlag : uint64_t {
   DedupCover = 4ULL,
   CollectComps = 8ULL,
   Threaded = 16ULL,
+  SchedYield = 32ULL,
+  SchedPin = 64ULL,
+  SchedSpread = 128ULL,
+  SchedDelay = 256ULL,
   NONE = 0,
-  ANY = 31ULL
+  ANY = 511ULL
 };
 FLATBUFFERS_DEFINE_BITMASK_OPERATORS(ExecFlag, uint64_t)
 
-inline const ExecFlag (&EnumValuesExecFlag())[5] {
+inline const ExecFlag (&EnumValuesExecFlag())[9] {
   static const ExecFlag values[] = {
     ExecFlag::CollectSignal,
     ExecFlag::CollectCover,
     ExecFlag::DedupCover,
     ExecFlag::CollectComps,
-    ExecFlag::Threaded
+    ExecFlag::Threaded,
+    ExecFlag::SchedYield,
+    ExecFlag::SchedPin,
+    ExecFlag::SchedSpread,
+    ExecFlag::SchedDelay
   };
   return values;
 }
 
-inline const char * const *EnumNamesExecFlag() {
-  static const char * const names[17] = {
-    "CollectSignal",
-    "CollectCover",
-    "",
-    "DedupCover",
-    "",
-    "",
-    "",
-    "CollectComps",
-    "",
-    "",
-    "",
-    "",
-    "",
-    "",
-    "",
-    "Threaded",
-    nullptr
-  };
-  return names;
-}
-
 inline const char *EnumNameExecFlag(ExecFlag e) {
-  if (flatbuffers::IsOutRange(e, ExecFlag::CollectSignal, ExecFlag::Threaded)) return "";
-  const size_t index = static_cast<size_t>(e) - static_cast<size_t>(ExecFlag::CollectSignal);
-  return EnumNamesExecFlag()[index];
+  switch (e) {
+    case ExecFlag::CollectSignal: return "CollectSignal";
+    case ExecFlag::CollectCover: return "CollectCover";
+    case ExecFlag::DedupCover: return "DedupCover";
+    case ExecFlag::CollectComps: return "CollectComps";
+    case ExecFlag::Threaded: return "Threaded";
+    case ExecFlag::SchedYield: return "SchedYield";
+    case ExecFlag::SchedPin: return "SchedPin";
+    case ExecFlag::SchedSpread: return "SchedSpread";
+    case ExecFlag::SchedDelay: return "SchedDelay";
+    default: return "";
+  }
 }
 
 enum class CallFlag : uint8_t {
@@ -909,6 +952,8 @@ struct ConnectRequestRawT : public flatbuffers::NativeTable {
   std::string arch{};
   std::string git_revision{};
   std::string syz_revision{};
+  int32_t max_batch = 0;
+  bool compact_cover = false;
 };
 
 struct ConnectRequestRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
@@ -919,7 +964,9 @@ struct ConnectRequestRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
     VT_ID = 6,
     VT_ARCH = 8,
     VT_GIT_REVISION = 10,
-    VT_SYZ_REVISION = 12
+    VT_SYZ_REVISION = 12,
+    VT_MAX_BATCH = 14,
+    VT_COMPACT_COVER = 16
   };
   uint64_t cookie() const {
     return GetField<uint64_t>(VT_COOKIE, 0);
@@ -936,6 +983,12 @@ struct ConnectRequestRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
   const flatbuffers::String *syz_revision() const {
     return GetPointer<const flatbuffers::String *>(VT_SYZ_REVISION);
   }
+  int32_t max_batch() const {
+    return GetField<int32_t>(VT_MAX_BATCH, 0);
+  }
+  bool compact_cover() const {
+    return GetField<uint8_t>(VT_COMPACT_COVER, 0) != 0;
+  }
   bool Verify(flatbuffers::Verifier &verifier) const {
     return VerifyTableStart(verifier) &&
            VerifyField<uint64_t>(verifier, VT_COOKIE, 8) &&
@@ -946,6 +999,8 @@ struct ConnectRequestRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
            verifier.VerifyString(git_revision()) &&
            VerifyOffset(verifier, VT_SYZ_REVISION) &&
            verifier.VerifyString(syz_revision()) &&
+           VerifyField<int32_t>(verifier, VT_MAX_BATCH, 4) &&
+           VerifyField<uint8_t>(verifier, VT_COMPACT_COVER, 1) &&
            verifier.EndTable();
   }
   ConnectRequestRawT *UnPack(const flatbuffers::resolver_function_t *_resolver = nullptr) const;
@@ -972,6 +1027,12 @@ struct ConnectRequestRawBuilder {
   void add_syz_revision(flatbuffers::Offset<flatbuffers::String> syz_revision) {
     fbb_.AddOffset(ConnectRequestRaw::VT_SYZ_REVISION, syz_revision);
   }
+  void add_max_batch(int32_t max_batch) {
+    fbb_.AddElement<int32_t>(ConnectRequestRaw::VT_MAX_BATCH, max_batch, 0);
+  }
+  void add_compact_cover(bool compact_cover) {
+    fbb_.AddElement<uint8_t>(ConnectRequestRaw::VT_COMPACT_COVER, static_cast<uint8_t>(compact_cover), 0);
+  }
   explicit ConnectRequestRawBuilder(flatbuffers::FlatBufferBuilder &_fbb)
         : fbb_(_fbb) {
     start_ = fbb_.StartTable();
@@ -989,13 +1050,17 @@ inline flatbuffers::Offset<ConnectRequestRaw> CreateConnectRequestRaw(
     int64_t id = 0,
     flatbuffers::Offset<flatbuffers::String> arch = 0,
     flatbuffers::Offset<flatbuffers::String> git_revision = 0,
-    flatbuffers::Offset<flatbuffers::String> syz_revision = 0) {
+    flatbuffers::Offset<flatbuffers::String> syz_revision = 0,
+    int32_t max_batch = 0,
+    bool compact_cover = false) {
   ConnectRequestRawBuilder builder_(_fbb);
   builder_.add_id(id);
   builder_.add_cookie(cookie);
+  builder_.add_max_batch(max_batch);
   builder_.add_syz_revision(syz_revision);
   builder_.add_git_revision(git_revision);
   builder_.add_arch(arch);
+  builder_.add_compact_cover(compact_cover);
   return builder_.Finish();
 }
 
@@ -1005,7 +1070,9 @@ inline flatbuffers::Offset<ConnectRequestRaw> CreateConnectRequestRawDirect(
     int64_t id = 0,
     const char *arch = nullptr,
     const char *git_revision = nullptr,
-    const char *syz_revision = nullptr) {
+    const char *syz_revision = nullptr,
+    int32_t max_batch = 0,
+    bool compact_cover = false) {
   auto arch__ = arch ? _fbb.CreateString(arch) : 0;
   auto git_revision__ = git_revision ? _fbb.CreateString(git_revision) : 0;
   auto syz_revision__ = syz_revision ? _fbb.CreateString(syz_revision) : 0;
@@ -1015,7 +1082,9 @@ inline flatbuffers::Offset<ConnectRequestRaw> CreateConnectRequestRawDirect(
       id,
       arch__,
       git_revision__,
-      syz_revision__);
+      syz_revision__,
+      max_batch,
+      compact_cover);
 }
 
 flatbuffers::Offset<ConnectRequestRaw> CreateConnectRequestRaw(flatbuffers::FlatBufferBuilder &_fbb, const ConnectRequestRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
@@ -1034,6 +1103,8 @@ struct ConnectReplyRawT : public flatbuffers::NativeTable {
   std::vector<std::string> race_frames{};
   rpc::Feature features = static_cast<rpc::Feature>(0);
   std::vector<std::string> files{};
+  int32_t batch_size = 0;
+  bool compact_cover = false;
 };
 
 struct ConnectReplyRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
@@ -1051,7 +1122,9 @@ struct ConnectReplyRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
     VT_LEAK_FRAMES = 20,
     VT_RACE_FRAMES = 22,
     VT_FEATURES = 24,
-    VT_FILES = 26
+    VT_FILES = 26,
+    VT_BATCH_SIZE = 28,
+    VT_COMPACT_COVER = 30
   };
   bool debug() const {
     return GetField<uint8_t>(VT_DEBUG, 0) != 0;
@@ -1089,6 +1162,12 @@ struct ConnectReplyRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
   const flatbuffers::Vector<flatbuffers::Offset<flatbuffers::String>> *files() const {
     return GetPointer<const flatbuffers::Vector<flatbuffers::Offset<flatbuffers::String>> *>(VT_FILES);
   }
+  int32_t batch_size() const {
+    return GetField<int32_t>(VT_BATCH_SIZE, 0);
+  }
+  bool compact_cover() const {
+    return GetField<uint8_t>(VT_COMPACT_COVER, 0) != 0;
+  }
   bool Verify(flatbuffers::Verifier &verifier) const {
     return VerifyTableStart(verifier) &&
            VerifyField<uint8_t>(verifier, VT_DEBUG, 1) &&
@@ -1109,6 +1188,8 @@ struct ConnectReplyRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
            VerifyOffset(verifier, VT_FILES) &&
            verifier.VerifyVector(files()) &&
            verifier.VerifyVectorOfStrings(files()) &&
+           VerifyField<int32_t>(verifier, VT_BATCH_SIZE, 4) &&
+           VerifyField<uint8_t>(verifier, VT_COMPACT_COVER, 1) &&
            verifier.EndTable();
   }
   ConnectReplyRawT *UnPack(const flatbuffers::resolver_function_t *_resolver = nullptr) const;
@@ -1156,6 +1237,12 @@ struct ConnectReplyRawBuilder {
   void add_files(flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<flatbuffers::String>>> files) {
     fbb_.AddOffset(ConnectReplyRaw::VT_FILES, files);
   }
+  void add_batch_size(int32_t batch_size) {
+    fbb_.AddElement<int32_t>(ConnectReplyRaw::VT_BATCH_SIZE, batch_size, 0);
+  }
+  void add_compact_cover(bool compact_cover) {
+    fbb_.AddElement<uint8_t>(ConnectReplyRaw::VT_COMPACT_COVER, static_cast<uint8_t>(compact_cover), 0);
+  }
   explicit ConnectReplyRawBuilder(flatbuffers::FlatBufferBuilder &_fbb)
         : fbb_(_fbb) {
     start_ = fbb_.StartTable();
@@ -1180,9 +1267,12 @@ inline flatbuffers::Offset<ConnectReplyRaw> CreateConnectReplyRaw(
     flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<flatbuffers::String>>> leak_frames = 0,
     flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<flatbuffers::String>>> race_frames = 0,
     rpc::Feature features = static_cast<rpc::Feature>(0),
-    flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<flatbuffers::String>>> files = 0) {
+    flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<flatbuffers::String>>> files = 0,
+    int32_t batch_size = 0,
+    bool compact_cover = false) {
   ConnectReplyRawBuilder builder_(_fbb);
   builder_.add_features(features);
+  builder_.add_batch_size(batch_size);
   builder_.add_files(files);
   builder_.add_race_frames(race_frames);
   builder_.add_leak_frames(leak_frames);
@@ -1190,6 +1280,7 @@ inline flatbuffers::Offset<ConnectReplyRaw> CreateConnectReplyRaw(
   builder_.add_syscall_timeout_ms(syscall_timeout_ms);
   builder_.add_slowdown(slowdown);
   builder_.add_procs(procs);
+  builder_.add_compact_cover(compact_cover);
   builder_.add_kernel_64_bit(kernel_64_bit);
   builder_.add_cover_edges(cover_edges);
   builder_.add_cover(cover);
@@ -1210,7 +1301,9 @@ inline flatbuffers::Offset<ConnectReplyRaw> CreateConnectReplyRawDirect(
     const std::vector<flatbuffers::Offset<flatbuffers::String>> *leak_frames = nullptr,
     const std::vector<flatbuffers::Offset<flatbuffers::String>> *race_frames = nullptr,
     rpc::Feature features = static_cast<rpc::Feature>(0),
-    const std::vector<flatbuffers::Offset<flatbuffers::String>> *files = nullptr) {
+    const std::vector<flatbuffers::Offset<flatbuffers::String>> *files = nullptr,
+    int32_t batch_size = 0,
+    bool compact_cover = false) {
   auto leak_frames__ = leak_frames ? _fbb.CreateVector<flatbuffers::Offset<flatbuffers::String>>(*leak_frames) : 0;
   auto race_frames__ = race_frames ? _fbb.CreateVector<flatbuffers::Offset<flatbuffers::String>>(*race_frames) : 0;
   auto files__ = files ? _fbb.CreateVector<flatbuffers::Offset<flatbuffers::String>>(*files) : 0;
@@ -1227,7 +1320,9 @@ inline flatbuffers::Offset<ConnectReplyRaw> CreateConnectReplyRawDirect(
       leak_frames__,
       race_frames__,
       features,
-      files__);
+      files__,
+      batch_size,
+      compact_cover);
 }
 
 flatbuffers::Offset<ConnectReplyRaw> CreateConnectReplyRaw(flatbuffers::FlatBufferBuilder &_fbb, const ConnectReplyRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
@@ -1694,6 +1789,9 @@ struct HostMessageRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
   const rpc::StateRequestRaw *msg_as_StateRequest() const {
     return msg_type() == rpc::HostMessagesRaw::StateRequest ? static_cast<const rpc::StateRequestRaw *>(msg()) : nullptr;
   }
+  const rpc::ExecBatchRaw *msg_as_ExecBatch() const {
+    return msg_type() == rpc::HostMessagesRaw::ExecBatch ? static_cast<const rpc::ExecBatchRaw *>(msg()) : nullptr;
+  }
   bool Verify(flatbuffers::Verifier &verifier) const {
     return VerifyTableStart(verifier) &&
            VerifyField<uint8_t>(verifier, VT_MSG_TYPE, 1) &&
@@ -1722,6 +1820,10 @@ template<> inline const rpc::StateRequestRaw *HostMessageRaw::msg_as<rpc::StateR
   return msg_as_StateRequest();
 }
 
+template<> inline const rpc::ExecBatchRaw *HostMessageRaw::msg_as<rpc::ExecBatchRaw>() const {
+  return msg_as_ExecBatch();
+}
+
 struct HostMessageRawBuilder {
   typedef HostMessageRaw Table;
   flatbuffers::FlatBufferBuilder &fbb_;
@@ -1783,6 +1885,9 @@ struct ExecutorMessageRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
   const rpc::StateResultRaw *msg_as_State() const {
     return msg_type() == rpc::ExecutorMessagesRaw::State ? static_cast<const rpc::StateResultRaw *>(msg()) : nullptr;
   }
+  const rpc::ExecBatchResultRaw *msg_as_ExecBatchResult() const {
+    return msg_type() == rpc::ExecutorMessagesRaw::ExecBatchResult ? static_cast<const rpc::ExecBatchResultRaw *>(msg()) : nullptr;
+  }
   bool Verify(flatbuffers::Verifier &verifier) const {
     return VerifyTableStart(verifier) &&
            VerifyField<uint8_t>(verifier, VT_MSG_TYPE, 1) &&
@@ -1807,6 +1912,10 @@ template<> inline const rpc::StateResultRaw *ExecutorMessageRaw::msg_as<rpc::Sta
   return msg_as_State();
 }
 
+template<> inline const rpc::ExecBatchResultRaw *ExecutorMessageRaw::msg_as<rpc::ExecBatchResultRaw>() const {
+  return msg_as_ExecBatchResult();
+}
+
 struct ExecutorMessageRawBuilder {
   typedef ExecutorMessageRaw Table;
   flatbuffers::FlatBufferBuilder &fbb_;
@@ -1986,6 +2095,73 @@ inline flatbuffers::Offset<ExecRequestRaw> CreateExecRequestRawDirect(
 
 flatbuffers::Offset<ExecRequestRaw> CreateExecRequestRaw(flatbuffers::FlatBufferBuilder &_fbb, const ExecRequestRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
 
+struct ExecBatchRawT : public flatbuffers::NativeTable {
+  typedef ExecBatchRaw TableType;
+  std::vector<std::unique_ptr<rpc::ExecRequestRawT>> requests{};
+  ExecBatchRawT() = default;
+  ExecBatchRawT(const ExecBatchRawT &o);
+  ExecBatchRawT(ExecBatchRawT&&) FLATBUFFERS_NOEXCEPT = default;
+  ExecBatchRawT &operator=(ExecBatchRawT o) FLATBUFFERS_NOEXCEPT;
+};
+
+struct ExecBatchRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
+  typedef ExecBatchRawT NativeTableType;
+  typedef ExecBatchRawBuilder Builder;
+  enum FlatBuffersVTableOffset FLATBUFFERS_VTABLE_UNDERLYING_TYPE {
+    VT_REQUESTS = 4
+  };
+  const flatbuffers::Vector<flatbuffers::Offset<rpc::ExecRequestRaw>> *requests() const {
+    return GetPointer<const flatbuffers::Vector<flatbuffers::Offset<rpc::ExecRequestRaw>> *>(VT_REQUESTS);
+  }
+  bool Verify(flatbuffers::Verifier &verifier) const {
+    return VerifyTableStart(verifier) &&
+           VerifyOffset(verifier, VT_REQUESTS) &&
+           verifier.VerifyVector(requests()) &&
+           verifier.VerifyVectorOfTables(requests()) &&
+           verifier.EndTable();
+  }
+  ExecBatchRawT *UnPack(const flatbuffers::resolver_function_t *_resolver = nullptr) const;
+  void UnPackTo(ExecBatchRawT *_o, const flatbuffers::resolver_function_t *_resolver = nullptr) const;
+  static flatbuffers::Offset<ExecBatchRaw> Pack(flatbuffers::FlatBufferBuilder &_fbb, const ExecBatchRawT* _o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
+};
+
+struct ExecBatchRawBuilder {
+  typedef ExecBatchRaw Table;
+  flatbuffers::FlatBufferBuilder &fbb_;
+  flatbuffers::uoffset_t start_;
+  void add_requests(flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<rpc::ExecRequestRaw>>> requests) {
+    fbb_.AddOffset(ExecBatchRaw::VT_REQUESTS, requests);
+  }
+  explicit ExecBatchRawBuilder(flatbuffers::FlatBufferBuilder &_fbb)
+        : fbb_(_fbb) {
+    start_ = fbb_.StartTable();
+  }
+  flatbuffers::Offset<ExecBatchRaw> Finish() {
+    const auto end = fbb_.EndTable(start_);
+    auto o = flatbuffers::Offset<ExecBatchRaw>(end);
+    return o;
+  }
+};
+
+inline flatbuffers::Offset<ExecBatchRaw> CreateExecBatchRaw(
+    flatbuffers::FlatBufferBuilder &_fbb,
+    flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<rpc::ExecRequestRaw>>> requests = 0) {
+  ExecBatchRawBuilder builder_(_fbb);
+  builder_.add_requests(requests);
+  return builder_.Finish();
+}
+
+inline flatbuffers::Offset<ExecBatchRaw> CreateExecBatchRawDirect(
+    flatbuffers::FlatBufferBuilder &_fbb,
+    const std::vector<flatbuffers::Offset<rpc::ExecRequestRaw>> *requests = nullptr) {
+  auto requests__ = requests ? _fbb.CreateVector<flatbuffers::Offset<rpc::ExecRequestRaw>>(*requests) : 0;
+  return rpc::CreateExecBatchRaw(
+      _fbb,
+      requests__);
+}
+
+flatbuffers::Offset<ExecBatchRaw> CreateExecBatchRaw(flatbuffers::FlatBufferBuilder &_fbb, const ExecBatchRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
+
 struct SignalUpdateRawT : public flatbuffers::NativeTable {
   typedef SignalUpdateRaw TableType;
   std::vector<uint64_t> new_max{};
@@ -2218,6 +2394,8 @@ struct CallInfoRawT : public flatbuffers::NativeTable {
   std::vector<uint64_t> signal{};
   std::vector<uint64_t> cover{};
   std::vector<rpc::ComparisonRaw> comps{};
+  std::vector<uint8_t> signal_packed{};
+  std::vector<uint8_t> cover_packed{};
 };
 
 struct CallInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
@@ -2228,7 +2406,9 @@ struct CallInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
     VT_ERROR = 6,
     VT_SIGNAL = 8,
     VT_COVER = 10,
-    VT_COMPS = 12
+    VT_COMPS = 12,
+    VT_SIGNAL_PACKED = 14,
+    VT_COVER_PACKED = 16
   };
   rpc::CallFlag flags() const {
     return static_cast<rpc::CallFlag>(GetField<uint8_t>(VT_FLAGS, 0));
@@ -2245,6 +2425,12 @@ struct CallInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
   const flatbuffers::Vector<const rpc::ComparisonRaw *> *comps() const {
     return GetPointer<const flatbuffers::Vector<const rpc::ComparisonRaw *> *>(VT_COMPS);
   }
+  const flatbuffers::Vector<uint8_t> *signal_packed() const {
+    return GetPointer<const flatbuffers::Vector<uint8_t> *>(VT_SIGNAL_PACKED);
+  }
+  const flatbuffers::Vector<uint8_t> *cover_packed() const {
+    return GetPointer<const flatbuffers::Vector<uint8_t> *>(VT_COVER_PACKED);
+  }
   bool Verify(flatbuffers::Verifier &verifier) const {
     return VerifyTableStart(verifier) &&
            VerifyField<uint8_t>(verifier, VT_FLAGS, 1) &&
@@ -2255,6 +2441,10 @@ struct CallInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
            verifier.VerifyVector(cover()) &&
            VerifyOffset(verifier, VT_COMPS) &&
            verifier.VerifyVector(comps()) &&
+           VerifyOffset(verifier, VT_SIGNAL_PACKED) &&
+           verifier.VerifyVector(signal_packed()) &&
+           VerifyOffset(verifier, VT_COVER_PACKED) &&
+           verifier.VerifyVector(cover_packed()) &&
            verifier.EndTable();
   }
   CallInfoRawT *UnPack(const flatbuffers::resolver_function_t *_resolver = nullptr) const;
@@ -2281,6 +2471,12 @@ struct CallInfoRawBuilder {
   void add_comps(flatbuffers::Offset<flatbuffers::Vector<const rpc::ComparisonRaw *>> comps) {
     fbb_.AddOffset(CallInfoRaw::VT_COMPS, comps);
   }
+  void add_signal_packed(flatbuffers::Offset<flatbuffers::Vector<uint8_t>> signal_packed) {
+    fbb_.AddOffset(CallInfoRaw::VT_SIGNAL_PACKED, signal_packed);
+  }
+  void add_cover_packed(flatbuffers::Offset<flatbuffers::Vector<uint8_t>> cover_packed) {
+    fbb_.AddOffset(CallInfoRaw::VT_COVER_PACKED, cover_packed);
+  }
   explicit CallInfoRawBuilder(flatbuffers::FlatBufferBuilder &_fbb)
         : fbb_(_fbb) {
     start_ = fbb_.StartTable();
@@ -2298,8 +2494,12 @@ inline flatbuffers::Offset<CallInfoRaw> CreateCallInfoRaw(
     int32_t error = 0,
     flatbuffers::Offset<flatbuffers::Vector<uint64_t>> signal = 0,
     flatbuffers::Offset<flatbuffers::Vector<uint64_t>> cover = 0,
-    flatbuffers::Offset<flatbuffers::Vector<const rpc::ComparisonRaw *>> comps = 0) {
+    flatbuffers::Offset<flatbuffers::Vector<const rpc::ComparisonRaw *>> comps = 0,
+    flatbuffers::Offset<flatbuffers::Vector<uint8_t>> signal_packed = 0,
+    flatbuffers::Offset<flatbuffers::Vector<uint8_t>> cover_packed = 0) {
   CallInfoRawBuilder builder_(_fbb);
+  builder_.add_cover_packed(cover_packed);
+  builder_.add_signal_packed(signal_packed);
   builder_.add_comps(comps);
   builder_.add_cover(cover);
   builder_.add_signal(signal);
@@ -2314,17 +2514,23 @@ inline flatbuffers::Offset<CallInfoRaw> CreateCallInfoRawDirect(
     int32_t error = 0,
     const std::vector<uint64_t> *signal = nullptr,
     const std::vector<uint64_t> *cover = nullptr,
-    const std::vector<rpc::ComparisonRaw> *comps = nullptr) {
+    const std::vector<rpc::ComparisonRaw> *comps = nullptr,
+    const std::vector<uint8_t> *signal_packed = nullptr,
+    const std::vector<uint8_t> *cover_packed = nullptr) {
   auto signal__ = signal ? _fbb.CreateVector<uint64_t>(*signal) : 0;
   auto cover__ = cover ? _fbb.CreateVector<uint64_t>(*cover) : 0;
   auto comps__ = comps ? _fbb.CreateVectorOfStructs<rpc::ComparisonRaw>(*comps) : 0;
+  auto signal_packed__ = signal_packed ? _fbb.CreateVector<uint8_t>(*signal_packed) : 0;
+  auto cover_packed__ = cover_packed ? _fbb.CreateVector<uint8_t>(*cover_packed) : 0;
   return rpc::CreateCallInfoRaw(
       _fbb,
       flags,
       error,
       signal__,
       cover__,
-      comps__);
+      comps__,
+      signal_packed__,
+      cover_packed__);
 }
 
 flatbuffers::Offset<CallInfoRaw> CreateCallInfoRaw(flatbuffers::FlatBufferBuilder &_fbb, const CallInfoRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
@@ -2460,6 +2666,7 @@ struct ExecResultRawT : public flatbuffers::NativeTable {
   bool hanged = false;
   std::string error{};
   std::unique_ptr<rpc::ProgInfoRawT> info{};
+  std::vector<std::unique_ptr<rpc::FileInfoRawT>> files{};
   ExecResultRawT() = default;
   ExecResultRawT(const ExecResultRawT &o);
   ExecResultRawT(ExecResultRawT&&) FLATBUFFERS_NOEXCEPT = default;
@@ -2475,7 +2682,8 @@ struct ExecResultRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
     VT_OUTPUT = 8,
     VT_HANGED = 10,
     VT_ERROR = 12,
-    VT_INFO = 14
+    VT_INFO = 14,
+    VT_FILES = 16
   };
   int64_t id() const {
     return GetField<int64_t>(VT_ID, 0);
@@ -2495,6 +2703,9 @@ struct ExecResultRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
   const rpc::ProgInfoRaw *info() const {
     return GetPointer<const rpc::ProgInfoRaw *>(VT_INFO);
   }
+  const flatbuffers::Vector<flatbuffers::Offset<rpc::FileInfoRaw>> *files() const {
+    return GetPointer<const flatbuffers::Vector<flatbuffers::Offset<rpc::FileInfoRaw>> *>(VT_FILES);
+  }
   bool Verify(flatbuffers::Verifier &verifier) const {
     return VerifyTableStart(verifier) &&
            VerifyField<int64_t>(verifier, VT_ID, 8) &&
@@ -2506,6 +2717,9 @@ struct ExecResultRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
            verifier.VerifyString(error()) &&
            VerifyOffset(verifier, VT_INFO) &&
            verifier.VerifyTable(info()) &&
+           VerifyOffset(verifier, VT_FILES) &&
+           verifier.VerifyVector(files()) &&
+           verifier.VerifyVectorOfTables(files()) &&
            verifier.EndTable();
   }
   ExecResultRawT *UnPack(const flatbuffers::resolver_function_t *_resolver = nullptr) const;
@@ -2535,6 +2749,9 @@ struct ExecResultRawBuilder {
   void add_info(flatbuffers::Offset<rpc::ProgInfoRaw> info) {
     fbb_.AddOffset(ExecResultRaw::VT_INFO, info);
   }
+  void add_files(flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<rpc::FileInfoRaw>>> files) {
+    fbb_.AddOffset(ExecResultRaw::VT_FILES, files);
+  }
   explicit ExecResultRawBuilder(flatbuffers::FlatBufferBuilder &_fbb)
         : fbb_(_fbb) {
     start_ = fbb_.StartTable();
@@ -2553,9 +2770,11 @@ inline flatbuffers::Offset<ExecResultRaw> CreateExecResultRaw(
     flatbuffers::Offset<flatbuffers::Vector<uint8_t>> output = 0,
     bool hanged = false,
     flatbuffers::Offset<flatbuffers::String> error = 0,
-    flatbuffers::Offset<rpc::ProgInfoRaw> info = 0) {
+    flatbuffers::Offset<rpc::ProgInfoRaw> info = 0,
+    flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<rpc::FileInfoRaw>>> files = 0) {
   ExecResultRawBuilder builder_(_fbb);
   builder_.add_id(id);
+  builder_.add_files(files);
   builder_.add_info(info);
   builder_.add_error(error);
   builder_.add_output(output);
@@ -2571,9 +2790,11 @@ inline flatbuffers::Offset<ExecResultRaw> CreateExecResultRawDirect(
     const std::vector<uint8_t> *output = nullptr,
     bool hanged = false,
     const char *error = nullptr,
-    flatbuffers::Offset<rpc::ProgInfoRaw> info = 0) {
+    flatbuffers::Offset<rpc::ProgInfoRaw> info = 0,
+    const std::vector<flatbuffers::Offset<rpc::FileInfoRaw>> *files = nullptr) {
   auto output__ = output ? _fbb.CreateVector<uint8_t>(*output) : 0;
   auto error__ = error ? _fbb.CreateString(error) : 0;
+  auto files__ = files ? _fbb.CreateVector<flatbuffers::Offset<rpc::FileInfoRaw>>(*files) : 0;
   return rpc::CreateExecResultRaw(
       _fbb,
       id,
@@ -2581,11 +2802,79 @@ inline flatbuffers::Offset<ExecResultRaw> CreateExecResultRawDirect(
       output__,
       hanged,
       error__,
-      info);
+      info,
+      files__);
 }
 
 flatbuffers::Offset<ExecResultRaw> CreateExecResultRaw(flatbuffers::FlatBufferBuilder &_fbb, const ExecResultRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
 
+struct ExecBatchResultRawT : public flatbuffers::NativeTable {
+  typedef ExecBatchResultRaw TableType;
+  std::vector<std::unique_ptr<rpc::ExecResultRawT>> results{};
+  ExecBatchResultRawT() = default;
+  ExecBatchResultRawT(const ExecBatchResultRawT &o);
+  ExecBatchResultRawT(ExecBatchResultRawT&&) FLATBUFFERS_NOEXCEPT = default;
+  ExecBatchResultRawT &operator=(ExecBatchResultRawT o) FLATBUFFERS_NOEXCEPT;
+};
+
+struct ExecBatchResultRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
+  typedef ExecBatchResultRawT NativeTableType;
+  typedef ExecBatchResultRawBuilder Builder;
+  enum FlatBuffersVTableOffset FLATBUFFERS_VTABLE_UNDERLYING_TYPE {
+    VT_RESULTS = 4
+  };
+  const flatbuffers::Vector<flatbuffers::Offset<rpc::ExecResultRaw>> *results() const {
+    return GetPointer<const flatbuffers::Vector<flatbuffers::Offset<rpc::ExecResultRaw>> *>(VT_RESULTS);
+  }
+  bool Verify(flatbuffers::Verifier &verifier) const {
+    return VerifyTableStart(verifier) &&
+           VerifyOffset(verifier, VT_RESULTS) &&
+           verifier.VerifyVector(results()) &&
+           verifier.VerifyVectorOfTables(results()) &&
+           verifier.EndTable();
+  }
+  ExecBatchResultRawT *UnPack(const flatbuffers::resolver_function_t *_resolver = nullptr) const;
+  void UnPackTo(ExecBatchResultRawT *_o, const flatbuffers::resolver_function_t *_resolver = nullptr) const;
+  static flatbuffers::Offset<ExecBatchResultRaw> Pack(flatbuffers::FlatBufferBuilder &_fbb, const ExecBatchResultRawT* _o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
+};
+
+struct ExecBatchResultRawBuilder {
+  typedef ExecBatchResultRaw Table;
+  flatbuffers::FlatBufferBuilder &fbb_;
+  flatbuffers::uoffset_t start_;
+  void add_results(flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<rpc::ExecResultRaw>>> results) {
+    fbb_.AddOffset(ExecBatchResultRaw::VT_RESULTS, results);
+  }
+  explicit ExecBatchResultRawBuilder(flatbuffers::FlatBufferBuilder &_fbb)
+        : fbb_(_fbb) {
+    start_ = fbb_.StartTable();
+  }
+  flatbuffers::Offset<ExecBatchResultRaw> Finish() {
+    const auto end = fbb_.EndTable(start_);
+    auto o = flatbuffers::Offset<ExecBatchResultRaw>(end);
+    return o;
+  }
+};
+
+inline flatbuffers::Offset<ExecBatchResultRaw> CreateExecBatchResultRaw(
+    flatbuffers::FlatBufferBuilder &_fbb,
+    flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<rpc::ExecResultRaw>>> results = 0) {
+  ExecBatchResultRawBuilder builder_(_fbb);
+  builder_.add_results(results);
+  return builder_.Finish();
+}
+
+inline flatbuffers::Offset<ExecBatchResultRaw> CreateExecBatchResultRawDirect(
+    flatbuffers::FlatBufferBuilder &_fbb,
+    const std::vector<flatbuffers::Offset<rpc::ExecResultRaw>> *results = nullptr) {
+  auto results__ = results ? _fbb.CreateVector<flatbuffers::Offset<rpc::ExecResultRaw>>(*results) : 0;
+  return rpc::CreateExecBatchResultRaw(
+      _fbb,
+      results__);
+}
+
+flatbuffers::Offset<ExecBatchResultRaw> CreateExecBatchResultRaw(flatbuffers::FlatBufferBuilder &_fbb, const ExecBatchResultRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
+
 struct StateResultRawT : public flatbuffers::NativeTable {
   typedef StateResultRaw TableType;
   std::vector<uint8_t> data{};
@@ -3005,6 +3294,8 @@ inline void ConnectRequestRaw::UnPackTo(ConnectRequestRawT *_o, const flatbuffer
   { auto _e = arch(); if (_e) _o->arch = _e->str(); }
   { auto _e = git_revision(); if (_e) _o->git_revision = _e->str(); }
   { auto _e = syz_revision(); if (_e) _o->syz_revision = _e->str(); }
+  { auto _e = max_batch(); _o->max_batch = _e; }
+  { auto _e = compact_cover(); _o->compact_cover = _e; }
 }
 
 inline flatbuffers::Offset<ConnectRequestRaw> ConnectRequestRaw::Pack(flatbuffers::FlatBufferBuilder &_fbb, const ConnectRequestRawT* _o, const flatbuffers::rehasher_function_t *_rehasher) {
@@ -3020,13 +3311,17 @@ inline flatbuffers::Offset<ConnectRequestRaw> CreateConnectRequestRaw(flatbuffer
   auto _arch = _o->arch.empty() ? 0 : _fbb.CreateString(_o->arch);
   auto _git_revision = _o->git_revision.empty() ? 0 : _fbb.CreateString(_o->git_revision);
   auto _syz_revision = _o->syz_revision.empty() ? 0 : _fbb.CreateString(_o->syz_revision);
+  auto _max_batch = _o->max_batch;
+  auto _compact_cover = _o->compact_cover;
   return rpc::CreateConnectRequestRaw(
       _fbb,
       _cookie,
       _id,
       _arch,
       _git_revision,
-      _syz_revision);
+      _syz_revision,
+      _max_batch,
+      _compact_cover);
 }
 
 inline ConnectReplyRawT *ConnectReplyRaw::UnPack(const flatbuffers::resolver_function_t *_resolver) const {
@@ -3050,6 +3345,8 @@ inline void ConnectReplyRaw::UnPackTo(ConnectReplyRawT *_o, const flatbuffers::r
   { auto _e = race_frames(); if (_e) { _o->race_frames.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->race_frames[_i] = _e->Get(_i)->str(); } } }
   { auto _e = features(); _o->features = _e; }
   { auto _e = files(); if (_e) { _o->files.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->files[_i] = _e->Get(_i)->str(); } } }
+  { auto _e = batch_size(); _o->batch_size = _e; }
+  { auto _e = compact_cover(); _o->compact_cover = _e; }
 }
 
 inline flatbuffers::Offset<ConnectReplyRaw> ConnectReplyRaw::Pack(flatbuffers::FlatBufferBuilder &_fbb, const ConnectReplyRawT* _o, const flatbuffers::rehasher_function_t *_rehasher) {
@@ -3072,6 +3369,8 @@ inline flatbuffers::Offset<ConnectReplyRaw> CreateConnectReplyRaw(flatbuffers::F
   auto _race_frames = _o->race_frames.size() ? _fbb.CreateVectorOfStrings(_o->race_frames) : 0;
   auto _features = _o->features;
   auto _files = _o->files.size() ? _fbb.CreateVectorOfStrings(_o->files) : 0;
+  auto _batch_size = _o->batch_size;
+  auto _compact_cover = _o->compact_cover;
   return rpc::CreateConnectReplyRaw(
       _fbb,
       _debug,
@@ -3085,7 +3384,9 @@ inline flatbuffers::Offset<ConnectReplyRaw> CreateConnectReplyRaw(flatbuffers::F
       _leak_frames,
       _race_frames,
       _features,
-      _files);
+      _files,
+      _batch_size,
+      _compact_cover);
 }
 
 inline InfoRequestRawT::InfoRequestRawT(const InfoRequestRawT &o)
@@ -3380,6 +3681,42 @@ inline flatbuffers::Offset<ExecRequestRaw> CreateExecRequestRaw(flatbuffers::Fla
       _all_signal);
 }
 
+inline ExecBatchRawT::ExecBatchRawT(const ExecBatchRawT &o) {
+  requests.reserve(o.requests.size());
+  for (const auto &requests_ : o.requests) { requests.emplace_back((requests_) ? new rpc::ExecRequestRawT(*requests_) : nullptr); }
+}
+
+inline ExecBatchRawT &ExecBatchRawT::operator=(ExecBatchRawT o) FLATBUFFERS_NOEXCEPT {
+  std::swap(requests, o.requests);
+  return *this;
+}
+
+inline ExecBatchRawT *ExecBatchRaw::UnPack(const flatbuffers::resolver_function_t *_resolver) const {
+  auto _o = std::unique_ptr<ExecBatchRawT>(new ExecBatchRawT());
+  UnPackTo(_o.get(), _resolver);
+  return _o.release();
+}
+
+inline void ExecBatchRaw::UnPackTo(ExecBatchRawT *_o, const flatbuffers::resolver_function_t *_resolver) const {
+  (void)_o;
+  (void)_resolver;
+  { auto _e = requests(); if (_e) { _o->requests.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->requests[_i] = std::unique_ptr<rpc::ExecRequestRawT>(_e->Get(_i)->UnPack(_resolver)); } } }
+}
+
+inline flatbuffers::Offset<ExecBatchRaw> ExecBatchRaw::Pack(flatbuffers::FlatBufferBuilder &_fbb, const ExecBatchRawT* _o, const flatbuffers::rehasher_function_t *_rehasher) {
+  return CreateExecBatchRaw(_fbb, _o, _rehasher);
+}
+
+inline flatbuffers::Offset<ExecBatchRaw> CreateExecBatchRaw(flatbuffers::FlatBufferBuilder &_fbb, const ExecBatchRawT *_o, const flatbuffers::rehasher_function_t *_rehasher) {
+  (void)_rehasher;
+  (void)_o;
+  struct _VectorArgs { flatbuffers::FlatBufferBuilder *__fbb; const ExecBatchRawT* __o; const flatbuffers::rehasher_function_t *__rehasher; } _va = { &_fbb, _o, _rehasher}; (void)_va;
+  auto _requests = _o->requests.size() ? _fbb.CreateVector<flatbuffers::Offset<rpc::ExecRequestRaw>> (_o->requests.size(), [](size_t i, _VectorArgs *__va) { return CreateExecRequestRaw(*__va->__fbb, __va->__o->requests[i].get(), __va->__rehasher); }, &_va ) : 0;
+  return rpc::CreateExecBatchRaw(
+      _fbb,
+      _requests);
+}
+
 inline SignalUpdateRawT *SignalUpdateRaw::UnPack(const flatbuffers::resolver_function_t *_resolver) const {
   auto _o = std::unique_ptr<SignalUpdateRawT>(new SignalUpdateRawT());
   UnPackTo(_o.get(), _resolver);
@@ -3501,6 +3838,8 @@ inline void CallInfoRaw::UnPackTo(CallInfoRawT *_o, const flatbuffers::resolver_
   { auto _e = signal(); if (_e) { _o->signal.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->signal[_i] = _e->Get(_i); } } }
   { auto _e = cover(); if (_e) { _o->cover.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->cover[_i] = _e->Get(_i); } } }
   { auto _e = comps(); if (_e) { _o->comps.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->comps[_i] = *_e->Get(_i); } } }
+  { auto _e = signal_packed(); if (_e) { _o->signal_packed.resize(_e->size()); std::copy(_e->begin(), _e->end(), _o->signal_packed.begin()); } }
+  { auto _e = cover_packed(); if (_e) { _o->cover_packed.resize(_e->size()); std::copy(_e->begin(), _e->end(), _o->cover_packed.begin()); } }
 }
 
 inline flatbuffers::Offset<CallInfoRaw> CallInfoRaw::Pack(flatbuffers::FlatBufferBuilder &_fbb, const CallInfoRawT* _o, const flatbuffers::rehasher_function_t *_rehasher) {
@@ -3516,13 +3855,17 @@ inline flatbuffers::Offset<CallInfoRaw> CreateCallInfoRaw(flatbuffers::FlatBuffe
   auto _signal = _o->signal.size() ? _fbb.CreateVector(_o->signal) : 0;
   auto _cover = _o->cover.size() ? _fbb.CreateVector(_o->cover) : 0;
   auto _comps = _o->comps.size() ? _fbb.CreateVectorOfStructs(_o->comps) : 0;
+  auto _signal_packed = _o->signal_packed.size() ? _fbb.CreateVector(_o->signal_packed) : 0;
+  auto _cover_packed = _o->cover_packed.size() ? _fbb.CreateVector(_o->cover_packed) : 0;
   return rpc::CreateCallInfoRaw(
       _fbb,
       _flags,
       _error,
       _signal,
       _cover,
-      _comps);
+      _comps,
+      _signal_packed,
+      _cover_packed);
 }
 
 inline ProgInfoRawT::ProgInfoRawT(const ProgInfoRawT &o)
@@ -3589,6 +3932,8 @@ inline ExecResultRawT::ExecResultRawT(const ExecResultRawT &o)
         hanged(o.hanged),
         error(o.error),
         info((o.info) ? new rpc::ProgInfoRawT(*o.info) : nullptr) {
+  files.reserve(o.files.size());
+  for (const auto &files_ : o.files) { files.emplace_back((files_) ? new rpc::FileInfoRawT(*files_) : nullptr); }
 }
 
 inline ExecResultRawT &ExecResultRawT::operator=(ExecResultRawT o) FLATBUFFERS_NOEXCEPT {
@@ -3598,6 +3943,7 @@ inline ExecResultRawT &ExecResultRawT::operator=(ExecResultRawT o) FLATBUFFERS_N
   std::swap(hanged, o.hanged);
   std::swap(error, o.error);
   std::swap(info, o.info);
+  std::swap(files, o.files);
   return *this;
 }
 
@@ -3616,6 +3962,7 @@ inline void ExecResultRaw::UnPackTo(ExecResultRawT *_o, const flatbuffers::resol
   { auto _e = hanged(); _o->hanged = _e; }
   { auto _e = error(); if (_e) _o->error = _e->str(); }
   { auto _e = info(); if (_e) _o->info = std::unique_ptr<rpc::ProgInfoRawT>(_e->UnPack(_resolver)); }
+  { auto _e = files(); if (_e) { _o->files.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->files[_i] = std::unique_ptr<rpc::FileInfoRawT>(_e->Get(_i)->UnPack(_resolver)); } } }
 }
 
 inline flatbuffers::Offset<ExecResultRaw> ExecResultRaw::Pack(flatbuffers::FlatBufferBuilder &_fbb, const ExecResultRawT* _o, const flatbuffers::rehasher_function_t *_rehasher) {
@@ -3632,6 +3979,7 @@ inline flatbuffers::Offset<ExecResultRaw> CreateExecResultRaw(flatbuffers::FlatB
   auto _hanged = _o->hanged;
   auto _error = _o->error.empty() ? 0 : _fbb.CreateString(_o->error);
   auto _info = _o->info ? CreateProgInfoRaw(_fbb, _o->info.get(), _rehasher) : 0;
+  auto _files = _o->files.size() ? _fbb.CreateVector<flatbuffers::Offset<rpc::FileInfoRaw>> (_o->files.size(), [](size_t i, _VectorArgs *__va) { return CreateFileInfoRaw(*__va->__fbb, __va->__o->files[i].get(), __va->__rehasher); }, &_va ) : 0;
   return rpc::CreateExecResultRaw(
       _fbb,
       _id,
@@ -3639,7 +3987,44 @@ inline flatbuffers::Offset<ExecResultRaw> CreateExecResultRaw(flatbuffers::FlatB
       _output,
       _hanged,
       _error,
-      _info);
+      _info,
+      _files);
+}
+
+inline ExecBatchResultRawT::ExecBatchResultRawT(const ExecBatchResultRawT &o) {
+  results.reserve(o.results.size());
+  for (const auto &results_ : o.results) { results.emplace_back((results_) ? new rpc::ExecResultRawT(*results_) : nullptr); }
+}
+
+inline ExecBatchResultRawT &ExecBatchResultRawT::operator=(ExecBatchResultRawT o) FLATBUFFERS_NOEXCEPT {
+  std::swap(results, o.results);
+  return *this;
+}
+
+inline ExecBatchResultRawT *ExecBatchResultRaw::UnPack(const flatbuffers::resolver_function_t *_resolver) const {
+  auto _o = std::unique_ptr<ExecBatchResultRawT>(new ExecBatchResultRawT());
+  UnPackTo(_o.get(), _resolver);
+  return _o.release();
+}
+
+inline void ExecBatchResultRaw::UnPackTo(ExecBatchResultRawT *_o, const flatbuffers::resolver_function_t *_resolver) const {
+  (void)_o;
+  (void)_resolver;
+  { auto _e = results(); if (_e) { _o->results.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->results[_i] = std::unique_ptr<rpc::ExecResultRawT>(_e->Get(_i)->UnPack(_resolver)); } } }
+}
+
+inline flatbuffers::Offset<ExecBatchResultRaw> ExecBatchResultRaw::Pack(flatbuffers::FlatBufferBuilder &_fbb, const ExecBatchResultRawT* _o, const flatbuffers::rehasher_function_t *_rehasher) {
+  return CreateExecBatchResultRaw(_fbb, _o, _rehasher);
+}
+
+inline flatbuffers::Offset<ExecBatchResultRaw> CreateExecBatchResultRaw(flatbuffers::FlatBufferBuilder &_fbb, const ExecBatchResultRawT *_o, const flatbuffers::rehasher_function_t *_rehasher) {
+  (void)_rehasher;
+  (void)_o;
+  struct _VectorArgs { flatbuffers::FlatBufferBuilder *__fbb; const ExecBatchResultRawT* __o; const flatbuffers::rehasher_function_t *__rehasher; } _va = { &_fbb, _o, _rehasher}; (void)_va;
+  auto _results = _o->results.size() ? _fbb.CreateVector<flatbuffers::Offset<rpc::ExecResultRaw>> (_o->results.size(), [](size_t i, _VectorArgs *__va) { return CreateExecResultRaw(*__va->__fbb, __va->__o->results[i].get(), __va->__rehasher); }, &_va ) : 0;
+  return rpc::CreateExecBatchResultRaw(
+      _fbb,
+      _results);
 }
 
 inline StateResultRawT *StateResultRaw::UnPack(const flatbuffers::resolver_function_t *_resolver) const {
@@ -3806,6 +4191,10 @@ inline bool VerifyHostMessagesRaw(flatbuffers::Verifier &verifier, const void *o
       auto ptr = reinterpret_cast<const rpc::StateRequestRaw *>(obj);
       return verifier.VerifyTable(ptr);
     }
+    case HostMessagesRaw::ExecBatch: {
+      auto ptr = reinterpret_cast<const rpc::ExecBatchRaw *>(obj);
+      return verifier.VerifyTable(ptr);
+    }
     default: return true;
   }
 }
@@ -3841,6 +4230,10 @@ inline void *HostMessagesRawUnion::UnPack(const void *obj, HostMessagesRaw type,
       auto ptr = reinterpret_cast<const rpc::StateRequestRaw *>(obj);
       return ptr->UnPack(resolver);
     }
+    case HostMessagesRaw::ExecBatch: {
+      auto ptr = reinterpret_cast<const rpc::ExecBatchRaw *>(obj);
+      return ptr->UnPack(resolver);
+    }
     default: return nullptr;
   }
 }
@@ -3864,6 +4257,10 @@ inline flatbuffers::Offset<void> HostMessagesRawUnion::Pack(flatbuffers::FlatBuf
       auto ptr = reinterpret_cast<const rpc::StateRequestRawT *>(value);
       return CreateStateRequestRaw(_fbb, ptr, _rehasher).Union();
     }
+    case HostMessagesRaw::ExecBatch: {
+      auto ptr = reinterpret_cast<const rpc::ExecBatchRawT *>(value);
+      return CreateExecBatchRaw(_fbb, ptr, _rehasher).Union();
+    }
     default: return 0;
   }
 }
@@ -3886,6 +4283,10 @@ inline HostMessagesRawUnion::HostMessagesRawUnion(const HostMessagesRawUnion &u)
       value = new rpc::StateRequestRawT(*reinterpret_cast<rpc::StateRequestRawT *>(u.value));
       break;
     }
+    case HostMessagesRaw::ExecBatch: {
+      value = new rpc::ExecBatchRawT(*reinterpret_cast<rpc::ExecBatchRawT *>(u.value));
+      break;
+    }
     default:
       break;
   }
@@ -3913,6 +4314,11 @@ inline void HostMessagesRawUnion::Reset() {
       delete ptr;
       break;
     }
+    case HostMessagesRaw::ExecBatch: {
+      auto ptr = reinterpret_cast<rpc::ExecBatchRawT *>(value);
+      delete ptr;
+      break;
+    }
     default: break;
   }
   value = nullptr;
@@ -3936,6 +4342,10 @@ inline bool VerifyExecutorMessagesRaw(flatbuffers::Verifier &verifier, const voi
       auto ptr = reinterpret_cast<const rpc::StateResultRaw *>(obj);
       return verifier.VerifyTable(ptr);
     }
+    case ExecutorMessagesRaw::ExecBatchResult: {
+      auto ptr = reinterpret_cast<const rpc::ExecBatchResultRaw *>(obj);
+      return verifier.VerifyTable(ptr);
+    }
     default: return true;
   }
 }
@@ -3967,6 +4377,10 @@ inline void *ExecutorMessagesRawUnion::UnPack(const void *obj, ExecutorMessagesR
       auto ptr = reinterpret_cast<const rpc::StateResultRaw *>(obj);
       return ptr->UnPack(resolver);
     }
+    case ExecutorMessagesRaw::ExecBatchResult: {
+      auto ptr = reinterpret_cast<const rpc::ExecBatchResultRaw *>(obj);
+      return ptr->UnPack(resolver);
+    }
     default: return nullptr;
   }
 }
@@ -3986,6 +4400,10 @@ inline flatbuffers::Offset<void> ExecutorMessagesRawUnion::Pack(flatbuffers::Fla
       auto ptr = reinterpret_cast<const rpc::StateResultRawT *>(value);
       return CreateStateResultRaw(_fbb, ptr, _rehasher).Union();
     }
+    case ExecutorMessagesRaw::ExecBatchResult: {
+      auto ptr = reinterpret_cast<const rpc::ExecBatchResultRawT *>(value);
+      return CreateExecBatchResultRaw(_fbb, ptr, _rehasher).Union();
+    }
     default: return 0;
   }
 }
@@ -4004,6 +4422,10 @@ inline ExecutorMessagesRawUnion::ExecutorMessagesRawUnion(const ExecutorMessages
       value = new rpc::StateResultRawT(*reinterpret_cast<rpc::StateResultRawT *>(u.value));
       break;
     }
+    case ExecutorMessagesRaw::ExecBatchResult: {
+      value = new rpc::ExecBatchResultRawT(*reinterpret_cast<rpc::ExecBatchResultRawT *>(u.value));
+      break;
+    }
     default:
       break;
   }
@@ -4026,6 +4448,11 @@ inline void ExecutorMessagesRawUnion::Reset() {
       delete ptr;
       break;
     }
+    case ExecutorMessagesRaw::ExecBatchResult: {
+      auto ptr = reinterpret_cast<rpc::ExecBatchResultRawT *>(value);
+      delete ptr;
+      break;
+    }
     default: break;
   }
   value = nullptr;
@@ -31,6 +31,7 @@ type HostMessage = HostMessageRawT
 type ExecutorMessages = ExecutorMessagesRawT
 type ExecutorMessage = ExecutorMessageRawT
 type ExecRequest = ExecRequestRawT
+type ExecBatch = ExecBatchRawT
 type StateRequest = StateRequestRawT
 type SignalUpdate = SignalUpdateRawT
 type CorpusTriaged = CorpusTriagedRawT
@@ -40,6 +41,7 @@ type Comparison = ComparisonRawT
 type ExecOpts = ExecOptsRawT
 type ProgInfo = ProgInfoRawT
 type ExecResult = ExecResultRawT
+type ExecBatchResult = ExecBatchResultRawT
 type StateResult = StateResultRawT
 
 func init() {
@@ -101,6 +103,24 @@ func SandboxToFlags(sandbox string) (ExecEnv, error) {
 	}
 }
 
+// SchedToFlags converts a scheduling perturbation name (see csource.Options.Sched) to exec flags.
+func SchedToFlags(sched string) (ExecFlag, error) {
+	switch sched {
+	case "":
+		return 0, nil
+	case "yield":
+		return ExecFlagSchedYield, nil
+	case "pin":
+		return ExecFlagSchedPin, nil
+	case "spread":
+		return ExecFlagSchedSpread, nil
+	case "delay":
+		return ExecFlagSchedDelay, nil
+	default:
+		return 0, fmt.Errorf("sched must contain one of yield/pin/spread/delay")
+	}
+}
+
 func (hdr *SnapshotHeaderT) UpdateState(state SnapshotState) {
 	atomic.StoreUint64((*uint64)(unsafe.Pointer(&hdr.State)), uint64(state))
 }
@@ -0,0 +1,206 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package flatrpc
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Shared memory transport for executors running on the same host (see LocalConfig.SharedMemory).
+// The shared memory region contains 2 ring buffers: host->executor and executor->host.
+// Each ring has a header with the write position (head, at offset 0) and the read position
+// (tail, at offset 64, on a separate cache line), followed by shmemRingSize bytes of data.
+// Positions grow monotonically, data for position pos is at offset pos % shmemRingSize.
+// Each ring has a doorbell pipe: the writer writes a byte into the pipe if the ring was empty
+// before the write, so that the reader can block on the pipe when the ring is empty.
+// Similarly, each ring has a space doorbell pipe: the reader writes a byte into the pipe
+// if the ring was full before the read, so that the writer can block on the pipe when the ring is full.
+// The layout must match ShmemRing in executor/conn.h.
+const (
+	shmemRingSize   = 8 << 20
+	shmemRingHeader = 128
+	shmemRingTail   = 64
+	shmemSize       = 2 * (shmemRingHeader + shmemRingSize)
+)
+
+type shmemTransport struct {
+	mu        sync.RWMutex
+	mem       []byte
+	send      shmemRing
+	recv      shmemRing
+	sendBell  *os.File
+	recvBell  *os.File
+	sendSpace *os.File // the executor has consumed data from the full send ring
+	recvSpace *os.File // we have consumed data from the full recv ring
+}
+
+type shmemRing struct {
+	head *uint64
+	tail *uint64
+	data []byte
+}
+
+// NewShmemConn creates a connection to an executor process running on the same host.
+// The returned files must be passed to the executor process as 5 consecutive file descriptors
+// (shared memory, host->executor doorbell, executor->host doorbell, host->executor space doorbell,
+// executor->host space doorbell), and closed in the host process after the executor process is started.
+func NewShmemConn() (*Conn, []*os.File, error) {
+	fd, err := unix.MemfdCreate("syz-executor-shmem", unix.MFD_CLOEXEC)
+	if err != nil {
+		return nil, nil, fmt.Errorf("memfd_create failed: %w", err)
+	}
+	shmemFile := os.NewFile(uintptr(fd), "syz-executor-shmem")
+	if err := shmemFile.Truncate(shmemSize); err != nil {
+		shmemFile.Close()
+		return nil, nil, fmt.Errorf("shmem truncate failed: %w", err)
+	}
+	mem, err := syscall.Mmap(fd, 0, shmemSize, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		shmemFile.Close()
+		return nil, nil, fmt.Errorf("shmem mmap failed: %w", err)
+	}
+	// Doorbells: host->executor, executor->host, host->executor space, executor->host space.
+	var pipes [4][2]*os.File
+	for i := range pipes {
+		pipes[i][0], pipes[i][1], err = os.Pipe()
+		if err != nil {
+			shmemFile.Close()
+			syscall.Munmap(mem)
+			for _, pipe := range pipes[:i] {
+				pipe[0].Close()
+				pipe[1].Close()
+			}
+			return nil, nil, err
+		}
+	}
+	sendBellR, sendBellW := pipes[0][0], pipes[0][1]
+	recvBellR, recvBellW := pipes[1][0], pipes[1][1]
+	sendSpaceR, sendSpaceW := pipes[2][0], pipes[2][1]
+	recvSpaceR, recvSpaceW := pipes[3][0], pipes[3][1]
+	t := &shmemTransport{
+		mem:       mem,
+		send:      newShmemRing(mem[:shmemRingHeader+shmemRingSize]),
+		recv:      newShmemRing(mem[shmemRingHeader+shmemRingSize:]),
+		sendBell:  sendBellW,
+		recvBell:  recvBellR,
+		sendSpace: sendSpaceR,
+		recvSpace: recvSpaceW,
+	}
+	conn := newConn(t)
+	return conn, []*os.File{shmemFile, sendBellR, recvBellW, sendSpaceW, recvSpaceR}, nil
+}
+
+func newShmemRing(mem []byte) shmemRing {
+	return shmemRing{
+		head: (*uint64)(unsafe.Pointer(&mem[0])),
+		tail: (*uint64)(unsafe.Pointer(&mem[shmemRingTail])),
+		data: mem[shmemRingHeader:],
+	}
+}
+
+func (t *shmemTransport) Write(p []byte) (int, error) {
+	var bell [64]byte
+	written := 0
+	for written < len(p) {
+		n, wasEmpty, err := t.write(p[written:])
+		if err != nil {
+			return written, err
+		}
+		written += n
+		if n == 0 {
+			// The ring is full, wait for the executor to consume some data.
+			if _, err := t.sendSpace.Read(bell[:]); err != nil {
+				return written, err
+			}
+			continue
+		}
+		if wasEmpty {
+			if _, err := t.sendBell.Write([]byte{0}); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (t *shmemTransport) write(p []byte) (int, bool, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.mem == nil {
+		return 0, false, os.ErrClosed
+	}
+	head := atomic.LoadUint64(t.send.head)
+	n := min(len(p), shmemRingSize-int(head-atomic.LoadUint64(t.send.tail)))
+	if n == 0 {
+		return 0, false, nil
+	}
+	pos := int(head % shmemRingSize)
+	copied := copy(t.send.data[pos:], p[:n])
+	copy(t.send.data, p[copied:n])
+	atomic.StoreUint64(t.send.head, head+uint64(n))
+	// The tail must be re-read after publishing the new head: either the executor sees the new data,
+	// or we see that it has consumed all previous data (and may be blocked on the doorbell).
+	return n, atomic.LoadUint64(t.send.tail) == head, nil
+}
+
+func (t *shmemTransport) Read(p []byte) (int, error) {
+	var bell [64]byte
+	for {
+		n, wasFull, err := t.read(p)
+		if wasFull {
+			if _, err := t.recvSpace.Write([]byte{0}); err != nil {
+				return n, err
+			}
+		}
+		if n != 0 || err != nil {
+			return n, err
+		}
+		if _, err := t.recvBell.Read(bell[:]); err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (t *shmemTransport) read(p []byte) (int, bool, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.mem == nil {
+		return 0, false, os.ErrClosed
+	}
+	tail := atomic.LoadUint64(t.recv.tail)
+	n := min(len(p), int(atomic.LoadUint64(t.recv.head)-tail))
+	if n == 0 {
+		return 0, false, nil
+	}
+	pos := int(tail % shmemRingSize)
+	copied := copy(p[:n], t.recv.data[pos:])
+	copy(p[copied:n], t.recv.data)
+	atomic.StoreUint64(t.recv.tail, tail+uint64(n))
+	// The head must be re-read after publishing the new tail: either the executor sees the free space,
+	// or we see that the ring was full (and the executor may be blocked on the space doorbell).
+	return n, atomic.LoadUint64(t.recv.head)-tail == shmemRingSize, nil
+}
+
+func (t *shmemTransport) Close() error {
+	// Closing the doorbells unblocks concurrent Read and Write calls.
+	t.sendBell.Close()
+	t.recvBell.Close()
+	t.sendSpace.Close()
+	t.recvSpace.Close()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.mem == nil {
+		return nil
+	}
+	err := syscall.Munmap(t.mem)
+	t.mem = nil
+	return err
+}
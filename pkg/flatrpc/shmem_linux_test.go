@@ -0,0 +1,92 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package flatrpc
+
+import (
+	"bytes"
+	"math/rand"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShmemConn(t *testing.T) {
+	host, files, err := NewShmemConn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer host.Close()
+	// Mimic the executor side of the transport: rings and doorbells are swapped.
+	mem, err := syscall.Mmap(int(files[0].Fd()), 0, shmemSize, syscall.PROT_READ|syscall.PROT_WRITE,
+		syscall.MAP_SHARED)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files[0].Close()
+	executor := newConn(&shmemTransport{
+		mem:       mem,
+		send:      newShmemRing(mem[shmemRingHeader+shmemRingSize:]),
+		recv:      newShmemRing(mem[:shmemRingHeader+shmemRingSize]),
+		sendBell:  files[2],
+		recvBell:  files[1],
+		sendSpace: files[4],
+		recvSpace: files[3],
+	})
+	defer executor.Close()
+
+	// Messages larger than the ring must be streamed through it.
+	outputs := [][]byte{[]byte("small"), make([]byte, shmemRingSize+12345), make([]byte, 3<<20)}
+	for _, output := range outputs[1:] {
+		rand.New(rand.NewSource(0)).Read(output)
+	}
+	done := make(chan error)
+	go func() {
+		for i, output := range outputs {
+			msg := &ExecutorMessage{
+				Msg: &ExecutorMessages{
+					Type: ExecutorMessagesRawExecResult,
+					Value: &ExecResult{
+						Id:     int64(i),
+						Output: output,
+					},
+				},
+			}
+			if err := Send(executor, msg); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	for i, output := range outputs {
+		msg, err := Recv[*ExecutorMessageRaw](host)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := msg.Msg.Value.(*ExecResult)
+		assert.Equal(t, int64(i), res.Id)
+		assert.True(t, bytes.Equal(output, res.Output), "output %v mismatch", i)
+	}
+	assert.NoError(t, <-done)
+
+	// The other direction.
+	req := &HostMessage{
+		Msg: &HostMessages{
+			Type:  HostMessagesRawStateRequest,
+			Value: &StateRequest{},
+		},
+	}
+	assert.NoError(t, Send(host, req))
+	msg, err := Recv[*HostMessageRaw](executor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, HostMessagesRawStateRequest, msg.Msg.Type)
+
+	// Closing the peer's doorbell must unblock the reader.
+	executor.Close()
+	_, err = Recv[*ExecutorMessageRaw](host)
+	assert.Error(t, err)
+}
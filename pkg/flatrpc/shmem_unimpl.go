@@ -0,0 +1,15 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+//go:build !linux
+
+package flatrpc
+
+import (
+	"fmt"
+	"os"
+)
+
+func NewShmemConn() (*Conn, []*os.File, error) {
+	return nil, nil, fmt.Errorf("shared memory transport is not supported on this OS")
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package fuzzer
+
+import (
+	"fmt"
+	"time"
+)
+
+// Classes of executions, with ExecBudget each class gets its own share of executions (see queue.Budget).
+const (
+	ExecCandidate = "candidate" // candidate programs and their triage
+	ExecTriage    = "triage"
+	ExecSmash     = "smash"
+	ExecHints     = "hints"
+	ExecFault     = "fault"
+	ExecGenerate  = "generate" // generation and mutation of programs
+)
+
+var ExecClasses = []string{ExecCandidate, ExecTriage, ExecSmash, ExecHints, ExecFault, ExecGenerate}
+
+// ExecBudget configures distribution of executions between the execution classes.
+// Without a budget the classes are served in strict priority order (see newExecQueues).
+type ExecBudget struct {
+	// Shares of executions the classes get when all of them have pending requests.
+	Shares map[string]float64
+	// Requests that wait for longer than the deadline are executed before all others.
+	Deadlines map[string]time.Duration
+}
+
+// DefaultExecBudget returns the shares and deadlines used for the classes that are not configured.
+// Triage and candidates have the largest shares, so they are not starved by smash/hints jobs
+// (e.g. after import of a large corpus), but they still don't block the jobs completely.
+// When only the jobs and generation have requests, the shares are close to the strict priority
+// order, where smash jobs are given 2 out of every 3 requests.
+func DefaultExecBudget(patchTest bool) *ExecBudget {
+	budget := &ExecBudget{
+		Shares: map[string]float64{
+			ExecCandidate: 50,
+			ExecTriage:    30,
+			ExecSmash:     6,
+			ExecHints:     6,
+			ExecFault:     3,
+			ExecGenerate:  5,
+		},
+		Deadlines: map[string]time.Duration{
+			ExecTriage: 10 * time.Minute,
+			ExecSmash:  time.Hour,
+			ExecHints:  time.Hour,
+			ExecFault:  time.Hour,
+		},
+	}
+	if patchTest {
+		// When we do patch fuzzing, we do not focus on finding and persisting
+		// new coverage that much, so it's reasonable to spend more time just
+		// mutating various corpus programs.
+		budget.Shares[ExecGenerate] = 15
+	}
+	return budget
+}
+
+// ParseExecBudget overrides the default budget with the given shares and deadlines (in seconds).
+func ParseExecBudget(patchTest bool, shares map[string]float64, deadlines map[string]int) (*ExecBudget, error) {
+	budget := DefaultExecBudget(patchTest)
+	for class, share := range shares {
+		if _, ok := budget.Shares[class]; !ok {
+			return nil, fmt.Errorf("unknown execution class %q, known classes: %v", class, ExecClasses)
+		}
+		if share <= 0 {
+			return nil, fmt.Errorf("share of %v must be positive", class)
+		}
+		budget.Shares[class] = share
+	}
+	for class, deadline := range deadlines {
+		if _, ok := budget.Shares[class]; !ok {
+			return nil, fmt.Errorf("unknown execution class %q, known classes: %v", class, ExecClasses)
+		}
+		if deadline < 0 {
+			return nil, fmt.Errorf("deadline of %v can't be negative", class)
+		}
+		budget.Deadlines[class] = time.Duration(deadline) * time.Second
+	}
+	return budget, nil
+}
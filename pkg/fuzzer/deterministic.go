@@ -0,0 +1,75 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package fuzzer
+
+import (
+	"context"
+	"sync"
+)
+
+// jobScheduler is used in the deterministic mode to serialize execution of jobs.
+// At most one job runs at a time, and only from within Fuzzer.Next. Jobs are resumed
+// in the order in which they were started, or in which their requests were completed.
+// As the result, the sequence of requests produced by the fuzzer depends only
+// on the sequence of results it receives (and on the initial random seed).
+type jobScheduler struct {
+	ctx   context.Context
+	mu    sync.Mutex
+	runq  []chan struct{}
+	yield chan struct{}
+}
+
+func newJobScheduler(ctx context.Context) *jobScheduler {
+	return &jobScheduler{
+		ctx:   ctx,
+		yield: make(chan struct{}),
+	}
+}
+
+// ready makes the job waiting on the wake channel runnable.
+func (s *jobScheduler) ready(wake chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runq = append(s.runq, wake)
+}
+
+// runReady runs all runnable jobs until all of them either wait for requests or finish.
+func (s *jobScheduler) runReady() {
+	for {
+		s.mu.Lock()
+		if len(s.runq) == 0 {
+			s.mu.Unlock()
+			return
+		}
+		wake := s.runq[0]
+		s.runq = s.runq[1:]
+		s.mu.Unlock()
+		select {
+		case wake <- struct{}{}:
+		case <-s.ctx.Done():
+			return
+		}
+		select {
+		case <-s.yield:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// wait blocks the job until it's its turn to run.
+func (s *jobScheduler) wait(wake chan struct{}) {
+	select {
+	case <-wake:
+	case <-s.ctx.Done():
+	}
+}
+
+// done gives the turn back to runReady.
+func (s *jobScheduler) done() {
+	select {
+	case s.yield <- struct{}{}:
+	case <-s.ctx.Done():
+	}
+}
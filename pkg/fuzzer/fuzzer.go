@@ -33,6 +33,11 @@ type Fuzzer struct {
 	target       *prog.Target
 	hintsLimiter prog.HintsLimiter
 	runningJobs  map[jobIntrospector]struct{}
+	mutators     *mutationScheduler
+	strategies   *strategySet
+	transMu      sync.Mutex
+	transitions  prog.CallTransitions
+	sched        *jobScheduler // only in the deterministic mode
 
 	ct           *prog.ChoiceTable
 	ctProgs      int
@@ -58,13 +63,19 @@ func NewFuzzer(ctx context.Context, cfg *Config, rnd *rand.Rand,
 		rnd:         rnd,
 		target:      target,
 		runningJobs: map[jobIntrospector]struct{}{},
+		mutators:    newMutationScheduler(cfg.AdaptiveMutations, cfg.mutateOpts()),
+		transitions: make(prog.CallTransitions),
 
 		// We're okay to lose some of the messages -- if we are already
 		// regenerating the table, we don't want to repeat it right away.
 		ctRegenerate: make(chan struct{}),
 	}
+	if cfg.Deterministic {
+		f.sched = newJobScheduler(ctx)
+	}
 	f.execQueues = newExecQueues(f)
 	f.updateChoiceTable(nil)
+	f.strategies = newStrategySet(f, cfg.Strategies)
 	go f.choiceTableUpdater()
 	if cfg.Debug {
 		go f.logCurrentStats()
@@ -77,7 +88,10 @@ type execQueues struct {
 	candidateQueue       *queue.PlainQueue
 	triageQueue          *queue.DynamicOrderer
 	smashQueue           *queue.PlainQueue
-	source               queue.Source
+	hintsQueue           *queue.PlainQueue
+	faultQueue           *queue.PlainQueue
+	deadlines            map[string]time.Duration
+	source               queue.VMSource
 }
 
 func newExecQueues(fuzzer *Fuzzer) execQueues {
@@ -86,26 +100,58 @@ func newExecQueues(fuzzer *Fuzzer) execQueues {
 		candidateQueue:       queue.Plain(),
 		triageQueue:          queue.DynamicOrder(),
 		smashQueue:           queue.Plain(),
-	}
-	// Alternate smash jobs with exec/fuzz to spread attention to the wider area.
-	skipQueue := 3
-	if fuzzer.Config.PatchTest {
-		// When we do patch fuzzing, we do not focus on finding and persisting
-		// new coverage that much, so it's reasonable to spend more time just
-		// mutating various corpus programs.
-		skipQueue = 2
-	}
-	// Sources are listed in the order, in which they will be polled.
-	ret.source = queue.Order(
-		ret.triageCandidateQueue,
-		ret.candidateQueue,
-		ret.triageQueue,
-		queue.Alternate(ret.smashQueue, skipQueue),
-		queue.Callback(fuzzer.genFuzz),
+		hintsQueue:           queue.Plain(),
+		faultQueue:           queue.Plain(),
+	}
+	budget := fuzzer.Config.ExecBudget
+	if budget == nil {
+		// Alternate smash jobs with exec/fuzz to spread attention to the wider area.
+		skipQueue := 3
+		if fuzzer.Config.PatchTest {
+			// When we do patch fuzzing, we do not focus on finding and persisting
+			// new coverage that much, so it's reasonable to spend more time just
+			// mutating various corpus programs.
+			skipQueue = 2
+		}
+		// Sources are listed in the order, in which they will be polled.
+		ret.source = queue.Order(
+			ret.triageCandidateQueue,
+			ret.candidateQueue,
+			ret.triageQueue,
+			queue.Alternate(queue.Order(ret.smashQueue, ret.hintsQueue, ret.faultQueue), skipQueue),
+			queue.VMCallback(fuzzer.genFuzz),
+		)
+		return ret
+	}
+	if !fuzzer.Config.Deterministic {
+		// Deadlines depend on timing, so they would make the deterministic mode non-deterministic.
+		ret.deadlines = budget.Deadlines
+	}
+	ret.source = queue.Budget(
+		queue.BudgetClass{
+			Name:   ExecCandidate,
+			Source: queue.Order(ret.triageCandidateQueue, ret.candidateQueue),
+			Share:  budget.Shares[ExecCandidate],
+		},
+		queue.BudgetClass{Name: ExecTriage, Source: ret.triageQueue, Share: budget.Shares[ExecTriage]},
+		queue.BudgetClass{Name: ExecSmash, Source: ret.smashQueue, Share: budget.Shares[ExecSmash]},
+		queue.BudgetClass{Name: ExecHints, Source: ret.hintsQueue, Share: budget.Shares[ExecHints]},
+		queue.BudgetClass{Name: ExecFault, Source: ret.faultQueue, Share: budget.Shares[ExecFault]},
+		queue.BudgetClass{
+			Name:   ExecGenerate,
+			Source: queue.VMCallback(fuzzer.genFuzz),
+			Share:  budget.Shares[ExecGenerate],
+			Lazy:   true,
+		},
 	)
 	return ret
 }
 
+// withDeadline sets deadlines for the requests of the class submitted to exec.
+func (eq *execQueues) withDeadline(exec queue.Executor, class string) queue.Executor {
+	return queue.Deadline(exec, eq.deadlines[class])
+}
+
 func (fuzzer *Fuzzer) CandidatesToTriage() int {
 	return fuzzer.statCandidates.Val() + fuzzer.statJobsTriageCandidate.Val()
 }
@@ -119,13 +165,64 @@ func (fuzzer *Fuzzer) execute(executor queue.Executor, req *queue.Request) *queu
 }
 
 func (fuzzer *Fuzzer) executeWithFlags(executor queue.Executor, req *queue.Request, flags ProgFlags) *queue.Result {
-	fuzzer.enqueue(executor, req, flags, 0)
-	return req.Wait(fuzzer.ctx)
+	return fuzzer.executeJobRequest(executor, req, func(req *queue.Request, res *queue.Result) bool {
+		return fuzzer.processResult(req, res, flags, 0, nil, nil)
+	})
+}
+
+// executeMutant executes a program derived from the job input and returns
+// the amount of new signal the program has given.
+// If the mutation is not nil, the feedback is also given to the mutation scheduler and to the corpus.
+func (fuzzer *Fuzzer) executeMutant(executor queue.Executor, req *queue.Request,
+	mutation *mutationInfo) (*queue.Result, int) {
+	newSignal := 0
+	res := fuzzer.executeJobRequest(executor, req, func(req *queue.Request, res *queue.Result) bool {
+		return fuzzer.processResult(req, res, 0, 0, mutation, &newSignal)
+	})
+	// The callback is done before Wait returns, so it's safe to read newSignal.
+	return res, newSignal
+}
+
+// executeJobRequest submits a request on behalf of a job and waits for its completion.
+func (fuzzer *Fuzzer) executeJobRequest(executor queue.Executor, req *queue.Request,
+	process queue.DoneCallback) *queue.Result {
+	if fuzzer.sched == nil {
+		req.OnDone(process)
+		executor.Submit(req)
+		return req.Wait(fuzzer.ctx)
+	}
+	// Callbacks are called in the LIFO order, so the job becomes runnable
+	// only after the result is processed and the request is not going to be retried.
+	wake := make(chan struct{})
+	req.OnDone(func(*queue.Request, *queue.Result) bool {
+		fuzzer.sched.ready(wake)
+		return true
+	})
+	req.OnDone(process)
+	executor.Submit(req)
+	fuzzer.sched.done()
+	res := req.Wait(fuzzer.ctx)
+	fuzzer.sched.wait(wake)
+	return res
 }
 
 func (fuzzer *Fuzzer) prepare(req *queue.Request, flags ProgFlags, attempt int) {
 	req.OnDone(func(req *queue.Request, res *queue.Result) bool {
-		return fuzzer.processResult(req, res, flags, attempt)
+		return fuzzer.processResult(req, res, flags, attempt, nil, nil)
+	})
+}
+
+// mutationInfo describes how a program was obtained by mutation.
+type mutationInfo struct {
+	seed  *prog.Prog // the mutated corpus program
+	stats prog.MutationStats
+}
+
+// prepareMutated is like prepare, but also gives feedback about the applied mutations
+// to the mutation scheduler and to the corpus.
+func (fuzzer *Fuzzer) prepareMutated(req *queue.Request, mutation *mutationInfo) {
+	req.OnDone(func(req *queue.Request, res *queue.Result) bool {
+		return fuzzer.processResult(req, res, 0, 0, mutation, nil)
 	})
 }
 
@@ -134,7 +231,8 @@ func (fuzzer *Fuzzer) enqueue(executor queue.Executor, req *queue.Request, flags
 	executor.Submit(req)
 }
 
-func (fuzzer *Fuzzer) processResult(req *queue.Request, res *queue.Result, flags ProgFlags, attempt int) bool {
+func (fuzzer *Fuzzer) processResult(req *queue.Request, res *queue.Result, flags ProgFlags, attempt int,
+	mutation *mutationInfo, newSignal *int) bool {
 	// If we are already triaging this exact prog, this is flaky coverage.
 	// Hanged programs are harmful as they consume executor procs.
 	dontTriage := flags&progInTriage > 0 || res.Status == queue.Hanged
@@ -149,27 +247,37 @@ func (fuzzer *Fuzzer) processResult(req *queue.Request, res *queue.Result, flags
 		fuzzer.triageProgCall(req.Prog, res.Info.Extra, -1, &triage)
 
 		if len(triage) != 0 {
-			queue, stat := fuzzer.triageQueue, fuzzer.statJobsTriage
+			queue, stat, class := fuzzer.triageQueue, fuzzer.statJobsTriage, ExecTriage
 			if flags&progCandidate > 0 {
-				queue, stat = fuzzer.triageCandidateQueue, fuzzer.statJobsTriageCandidate
+				queue, stat, class = fuzzer.triageCandidateQueue, fuzzer.statJobsTriageCandidate, ExecCandidate
 			}
 			job := &triageJob{
 				p:        req.Prog.Clone(),
 				executor: res.Executor,
 				flags:    flags,
-				queue:    queue.Append(),
+				queue:    fuzzer.withDeadline(queue.Append(), class),
 				calls:    triage,
 				info: &JobInfo{
 					Name: req.Prog.String(),
 					Type: "triage",
 				},
 			}
+			job.info.p = job.p
 			for id := range triage {
 				job.info.Calls = append(job.info.Calls, job.p.CallName(id))
 			}
 			sort.Strings(job.info.Calls)
 			fuzzer.startJob(stat, job)
 		}
+		if newSignal != nil {
+			for _, info := range triage {
+				*newSignal += info.newSignal.Len()
+			}
+		}
+	}
+	if mutation != nil && res.Info != nil {
+		fuzzer.mutators.record(res.Executor.VM, mutation.stats, len(triage) != 0)
+		fuzzer.Config.Corpus.RecordMutation(mutation.seed, len(triage) != 0)
 	}
 
 	if res.Info != nil {
@@ -214,6 +322,33 @@ type Config struct {
 	FetchRawCover  bool
 	NewInputFilter func(call string) bool
 	PatchTest      bool
+	// Adjust mutation operator weights based on the new signal they produce.
+	AdaptiveMutations bool
+	// Exchange argument subtrees between corpus programs (see prog.MutationCrossover).
+	Crossover bool
+	// Substitute comparison operands only into the arguments that influence the comparisons.
+	HintsTaint bool
+	// If set, a record about every finished job is written to the log.
+	JobLog *JobLog
+	// Distribution of executions between smash/hints/triage/etc.
+	// If nil, candidates and triage are always served first.
+	ExecBudget *ExecBudget
+	// Weights of the enabled strategies (see RegisterStrategy), the built-in generation/mutation
+	// has DefaultStrategyWeight unless it's specified explicitly under the DefaultStrategy name.
+	Strategies map[string]float64
+	// In the deterministic mode the sequence of requests returned by Next depends only on
+	// the random source passed to NewFuzzer and on the results of the previous requests.
+	// Jobs are run one at a time from within Next, so Next must not be called concurrently
+	// (e.g. there must be a single VM with a single proc).
+	Deterministic bool
+}
+
+func (cfg *Config) mutateOpts() prog.MutateOpts {
+	opts := prog.DefaultMutateOpts
+	if cfg.Crossover {
+		opts.CrossoverWeight = prog.DefaultCrossoverWeight
+	}
+	return opts
 }
 
 func (fuzzer *Fuzzer) triageProgCall(p *prog.Prog, info *flatrpc.CallInfo, call int, triage *map[int]*triageCall) {
@@ -268,7 +403,8 @@ func signalPrio(p *prog.Prog, info *flatrpc.CallInfo, call int) (prio uint8) {
 	return
 }
 
-func (fuzzer *Fuzzer) genFuzz() *queue.Request {
+// genFuzz generates or mutates a program to be executed on the given vm (negative, if it's not known).
+func (fuzzer *Fuzzer) genFuzz(vm int) *queue.Request {
 	// Either generate a new input or mutate an existing one.
 	mutateRate := 0.95
 	if !fuzzer.Config.Coverage {
@@ -277,9 +413,17 @@ func (fuzzer *Fuzzer) genFuzz() *queue.Request {
 		mutateRate = 0.5
 	}
 	var req *queue.Request
-	rnd := fuzzer.rand()
+	var mutation *mutationInfo
+	seed := fuzzer.randSeed()
+	rnd := rand.New(rand.NewSource(seed))
+	if strategy := fuzzer.strategies.choose(rnd); strategy != nil {
+		if req = strategy.next(fuzzer, rnd); req != nil {
+			req.Seed = seed
+			return req
+		}
+	}
 	if rnd.Float64() < mutateRate {
-		req = mutateProgRequest(fuzzer, rnd)
+		req, mutation = mutateProgRequest(fuzzer, rnd, vm)
 	}
 	if req == nil {
 		req = genProgRequest(fuzzer, rnd)
@@ -289,21 +433,40 @@ func (fuzzer *Fuzzer) genFuzz() *queue.Request {
 			Prog: randomCollide(req.Prog, rnd),
 			Stat: fuzzer.statExecCollide,
 		}
+		mutation = nil
+	}
+	req.Seed = seed
+	if mutation != nil {
+		fuzzer.prepareMutated(req, mutation)
+	} else {
+		fuzzer.prepare(req, 0, 0)
 	}
-	fuzzer.prepare(req, 0, 0)
 	return req
 }
 
 func (fuzzer *Fuzzer) startJob(stat *stat.Val, newJob job) {
 	fuzzer.Logf(2, "started %T", newJob)
+	var wake chan struct{}
+	if fuzzer.sched != nil {
+		wake = make(chan struct{})
+		fuzzer.sched.ready(wake)
+	}
 	go func() {
+		if wake != nil {
+			fuzzer.sched.wait(wake)
+			defer fuzzer.sched.done()
+		}
 		stat.Add(1)
 		defer stat.Add(-1)
 
 		fuzzer.statJobs.Add(1)
 		defer fuzzer.statJobs.Add(-1)
 
+		var info *JobInfo
 		if obj, ok := newJob.(jobIntrospector); ok {
+			info = obj.getInfo()
+			info.start = time.Now()
+			info.recording = fuzzer.Config.JobLog != nil
 			fuzzer.mu.Lock()
 			fuzzer.runningJobs[obj] = struct{}{}
 			fuzzer.mu.Unlock()
@@ -316,11 +479,25 @@ func (fuzzer *Fuzzer) startJob(stat *stat.Val, newJob job) {
 		}
 
 		newJob.run(fuzzer)
+		if info != nil && info.recording {
+			if err := fuzzer.Config.JobLog.Write(info.record()); err != nil {
+				fuzzer.Logf(0, "failed to write the job log: %v", err)
+			}
+		}
 	}()
 }
 
 func (fuzzer *Fuzzer) Next() *queue.Request {
-	req := fuzzer.source.Next()
+	return fuzzer.NextVM(-1)
+}
+
+// NextVM returns the next request to execute on the given vm.
+// Mutated programs use the mutation weights learned for the vm.
+func (fuzzer *Fuzzer) NextVM(vm int) *queue.Request {
+	if fuzzer.sched != nil {
+		fuzzer.sched.runReady()
+	}
+	req := fuzzer.source.NextVM(vm)
 	if req == nil {
 		// The fuzzer is not supposed to issue nil requests.
 		panic("nil request from the fuzzer")
@@ -366,13 +543,39 @@ func (fuzzer *Fuzzer) AddCandidates(candidates []Candidate) {
 }
 
 func (fuzzer *Fuzzer) rand() *rand.Rand {
+	return rand.New(rand.NewSource(fuzzer.randSeed()))
+}
+
+func (fuzzer *Fuzzer) randSeed() int64 {
 	fuzzer.mu.Lock()
 	defer fuzzer.mu.Unlock()
-	return rand.New(rand.NewSource(fuzzer.rnd.Int63()))
+	return fuzzer.rnd.Int63()
+}
+
+// recordTransition notes that the call of p has given new stable signal after the previous call.
+func (fuzzer *Fuzzer) recordTransition(p *prog.Prog, call int) {
+	if call <= 0 {
+		return
+	}
+	fuzzer.transMu.Lock()
+	defer fuzzer.transMu.Unlock()
+	fuzzer.transitions.Add(p.Calls[call-1].Meta.ID, p.Calls[call].Meta.ID)
+}
+
+// CallTransitions returns pairs of consecutive calls that have given new signal.
+func (fuzzer *Fuzzer) CallTransitions() prog.CallTransitions {
+	fuzzer.transMu.Lock()
+	defer fuzzer.transMu.Unlock()
+	return fuzzer.transitions.Clone()
+}
+
+func (fuzzer *Fuzzer) buildChoiceTable(programs []*prog.Prog) *prog.ChoiceTable {
+	return fuzzer.target.BuildWeightedChoiceTable(programs, fuzzer.Config.EnabledCalls,
+		fuzzer.Config.Corpus.DirectedCallWeights(), fuzzer.CallTransitions())
 }
 
 func (fuzzer *Fuzzer) updateChoiceTable(programs []*prog.Prog) {
-	newCt := fuzzer.target.BuildChoiceTable(programs, fuzzer.Config.EnabledCalls)
+	newCt := fuzzer.buildChoiceTable(programs)
 
 	fuzzer.ctMu.Lock()
 	defer fuzzer.ctMu.Unlock()
@@ -405,6 +608,12 @@ func (fuzzer *Fuzzer) ChoiceTable() *prog.ChoiceTable {
 		regenerateEveryProgs = 33
 	}
 	if fuzzer.ctProgs+regenerateEveryProgs < len(progs) {
+		if fuzzer.sched != nil {
+			// In the deterministic mode the table must not depend on timing of background updates.
+			fuzzer.ct = fuzzer.buildChoiceTable(progs)
+			fuzzer.ctProgs = len(progs)
+			return fuzzer.ct
+		}
 		select {
 		case fuzzer.ctRegenerate <- struct{}{}:
 		default:
@@ -92,6 +92,53 @@ func TestFuzz(t *testing.T) {
 	}
 }
 
+func TestFuzzDeterministic(t *testing.T) {
+	defer checkGoroutineLeaks()
+
+	target, err := prog.GetTarget(targets.TestOS, targets.TestArch64Fuzz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sysTarget := targets.Get(target.OS, target.Arch)
+	if sysTarget.BrokenCompiler != "" {
+		t.Skipf("skipping, broken cross-compiler: %v", sysTarget.BrokenCompiler)
+	}
+	executor := csource.BuildExecutor(t, target, "../..", "-fsanitize-coverage=trace-pc", "-g")
+	seed := time.Now().UnixNano()
+	t.Logf("seed=%v", seed)
+	const iters = 500
+	run := func() []string {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		fuzzer := NewFuzzer(ctx, &Config{
+			Corpus:   corpus.NewCorpus(ctx),
+			Coverage: true,
+			EnabledCalls: map[*prog.Syscall]bool{
+				target.SyscallMap["syz_test_fuzzer1"]: true,
+			},
+			Deterministic: true,
+		}, rand.New(rand.NewSource(seed)), target)
+		tf := &testFuzzer{
+			t:         t,
+			target:    target,
+			fuzzer:    fuzzer,
+			executor:  executor,
+			iterLimit: iters,
+			procs:     1,
+			trace:     []string{},
+		}
+		tf.run()
+		assert.GreaterOrEqual(t, len(tf.trace), iters)
+		return tf.trace[:iters]
+	}
+	trace := run()
+	for i, req := range run() {
+		if req != trace[i] {
+			t.Fatalf("request #%v differs:\n%v\nvs\n%v", i, trace[i], req)
+		}
+	}
+}
+
 func BenchmarkFuzzer(b *testing.B) {
 	b.ReportAllocs()
 	target, err := prog.GetTarget(targets.TestOS, targets.TestArch64Fuzz)
@@ -151,8 +198,12 @@ type testFuzzer struct {
 	expectedCrashes map[string]bool
 	iter            int
 	iterLimit       int
+	procs           int
 	done            func()
 	finished        atomic.Bool
+
+	// If not nil, seeds and programs of all requests are recorded.
+	trace []string
 }
 
 func (f *testFuzzer) run() {
@@ -160,6 +211,10 @@ func (f *testFuzzer) run() {
 	ctx, done := context.WithCancel(context.Background())
 	f.done = done
 	var output bytes.Buffer
+	procs := 4
+	if f.procs != 0 {
+		procs = f.procs
+	}
 	cfg := &rpcserver.LocalConfig{
 		Config: rpcserver.Config{
 			Config: vminfo.Config{
@@ -168,7 +223,7 @@ func (f *testFuzzer) run() {
 				Features: flatrpc.FeatureSandboxNone,
 				Sandbox:  flatrpc.ExecEnvSandboxNone,
 			},
-			Procs:    4,
+			Procs:    procs,
 			Slowdown: 1,
 		},
 		Executor:     f.executor,
@@ -183,7 +238,9 @@ func (f *testFuzzer) run() {
 		f.t.Logf("executor output:\n%s", output.String())
 		f.t.Fatal(err)
 	}
-	assert.Equal(f.t, len(f.expectedCrashes), len(f.crashes), "not all expected crashes were found")
+	if f.expectedCrashes != nil {
+		assert.Equal(f.t, len(f.expectedCrashes), len(f.crashes), "not all expected crashes were found")
+	}
 }
 
 func (f *testFuzzer) Next() *queue.Request {
@@ -191,6 +248,11 @@ func (f *testFuzzer) Next() *queue.Request {
 		return nil
 	}
 	req := f.fuzzer.Next()
+	if f.trace != nil {
+		f.mu.Lock()
+		f.trace = append(f.trace, fmt.Sprintf("seed=%v\n%s", req.Seed, req.Prog.Serialize()))
+		f.mu.Unlock()
+	}
 	req.ExecOpts.EnvFlags |= flatrpc.ExecEnvSignal | flatrpc.ExecEnvSandboxNone
 	req.ReturnOutput = true
 	req.ReturnError = true
@@ -212,7 +274,7 @@ func (f *testFuzzer) OnDone(req *queue.Request, res *queue.Result) bool {
 		crash := string(match[1])
 		f.t.Logf("CRASH: %s", crash)
 		res.Status = queue.Crashed
-		if !f.expectedCrashes[crash] {
+		if f.expectedCrashes != nil && !f.expectedCrashes[crash] {
 			f.t.Errorf("unexpected crash: %q", crash)
 		}
 		f.crashes[crash]++
@@ -223,7 +285,7 @@ func (f *testFuzzer) OnDone(req *queue.Request, res *queue.Result) bool {
 			f.iter, f.fuzzer.Config.Corpus.StatProgs.Val(), f.fuzzer.Config.Corpus.StatSignal.Val(),
 			len(f.fuzzer.Cover.maxSignal), len(f.crashes), f.fuzzer.statJobs.Val())
 	}
-	if f.iter > f.iterLimit || len(f.crashes) == len(f.expectedCrashes) {
+	if f.iter > f.iterLimit || f.expectedCrashes != nil && len(f.crashes) == len(f.expectedCrashes) {
 		f.done()
 		f.finished.Store(true)
 	}
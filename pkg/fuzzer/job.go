@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"fmt"
 	"math/rand"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -16,6 +17,7 @@ import (
 	"github.com/google/syzkaller/pkg/cover"
 	"github.com/google/syzkaller/pkg/flatrpc"
 	"github.com/google/syzkaller/pkg/fuzzer/queue"
+	"github.com/google/syzkaller/pkg/hash"
 	"github.com/google/syzkaller/pkg/signal"
 	"github.com/google/syzkaller/prog"
 )
@@ -35,12 +37,51 @@ type JobInfo struct {
 	Execs atomic.Int32
 
 	syncBuffer
+
+	// The rest is used to fill JobRecord.
+	p         *prog.Prog
+	start     time.Time
+	newSignal atomic.Int64
+	recording bool
+	mutantsMu sync.Mutex
+	mutants   []JobMutant
 }
 
 func (ji *JobInfo) ID() string {
 	return fmt.Sprintf("%p", ji)
 }
 
+func (ji *JobInfo) addMutant(p *prog.Prog, ops map[string]int, newSignal int) {
+	ji.newSignal.Add(int64(newSignal))
+	if !ji.recording {
+		return
+	}
+	ji.mutantsMu.Lock()
+	defer ji.mutantsMu.Unlock()
+	ji.mutants = append(ji.mutants, JobMutant{
+		Prog:      string(p.Serialize()),
+		Ops:       ops,
+		NewSignal: newSignal,
+	})
+}
+
+func (ji *JobInfo) record() *JobRecord {
+	data := ji.p.Serialize()
+	ji.mutantsMu.Lock()
+	defer ji.mutantsMu.Unlock()
+	return &JobRecord{
+		Type:      ji.Type,
+		Input:     hash.String(data),
+		Prog:      string(data),
+		Calls:     ji.Calls,
+		Start:     ji.start,
+		Duration:  time.Since(ji.start).Milliseconds(),
+		Execs:     int(ji.Execs.Load()),
+		NewSignal: int(ji.newSignal.Load()),
+		Mutants:   ji.mutants,
+	}
+}
+
 func genProgRequest(fuzzer *Fuzzer, rnd *rand.Rand) *queue.Request {
 	p := fuzzer.target.Generate(rnd,
 		prog.RecommendedCalls,
@@ -52,23 +93,24 @@ func genProgRequest(fuzzer *Fuzzer, rnd *rand.Rand) *queue.Request {
 	}
 }
 
-func mutateProgRequest(fuzzer *Fuzzer, rnd *rand.Rand) *queue.Request {
+func mutateProgRequest(fuzzer *Fuzzer, rnd *rand.Rand, vm int) (*queue.Request, *mutationInfo) {
 	p := fuzzer.Config.Corpus.ChooseProgram(rnd)
 	if p == nil {
-		return nil
+		return nil, nil
 	}
 	newP := p.Clone()
-	newP.Mutate(rnd,
+	stats := newP.MutateWithOpts(rnd,
 		prog.RecommendedCalls,
 		fuzzer.ChoiceTable(),
 		fuzzer.Config.NoMutateCalls,
 		fuzzer.Config.Corpus.Programs(),
+		fuzzer.mutators.mutateOpts(vm),
 	)
 	return &queue.Request{
 		Prog:     newP,
 		ExecOpts: setFlags(flatrpc.ExecFlagCollectSignal),
 		Stat:     fuzzer.statExecFuzz,
-	}
+	}, &mutationInfo{seed: p, stats: stats}
 }
 
 // triageJob are programs for which we noticed potential new coverage during
@@ -150,6 +192,13 @@ func (job *triageJob) run(fuzzer *Fuzzer) {
 	if stop {
 		return
 	}
+	if fuzzer.sched != nil {
+		// Don't let the order of corpus updates depend on the goroutine scheduling.
+		for _, call := range job.sortedCalls() {
+			job.handleCall(call, job.calls[call])
+		}
+		return
+	}
 	var wg sync.WaitGroup
 	for call, info := range job.calls {
 		wg.Add(1)
@@ -165,6 +214,8 @@ func (job *triageJob) handleCall(call int, info *triageCall) {
 	if info.newStableSignal.Empty() {
 		return
 	}
+	job.info.newSignal.Add(int64(info.newStableSignal.Len()))
+	job.fuzzer.recordTransition(job.p, call)
 
 	p := job.p
 	if job.flags&ProgMinimized == 0 {
@@ -179,31 +230,39 @@ func (job *triageJob) handleCall(call int, info *triageCall) {
 	}
 	if job.flags&ProgSmashed == 0 {
 		job.fuzzer.startJob(job.fuzzer.statJobsSmash, &smashJob{
-			exec: job.fuzzer.smashQueue,
+			exec: job.fuzzer.withDeadline(job.fuzzer.smashQueue, ExecSmash),
 			p:    p.Clone(),
 			info: &JobInfo{
 				Name:  p.String(),
 				Type:  "smash",
 				Calls: []string{p.CallName(call)},
+				p:     p,
 			},
 		})
 		if job.fuzzer.Config.Comparisons && call >= 0 {
 			job.fuzzer.startJob(job.fuzzer.statJobsHints, &hintsJob{
-				exec: job.fuzzer.smashQueue,
+				exec: job.fuzzer.withDeadline(job.fuzzer.hintsQueue, ExecHints),
 				p:    p.Clone(),
 				call: call,
 				info: &JobInfo{
 					Name:  p.String(),
 					Type:  "hints",
 					Calls: []string{p.CallName(call)},
+					p:     p,
 				},
 			})
 		}
 		if job.fuzzer.Config.FaultInjection && call >= 0 {
 			job.fuzzer.startJob(job.fuzzer.statJobsFaultInjection, &faultInjectionJob{
-				exec: job.fuzzer.smashQueue,
+				exec: job.fuzzer.withDeadline(job.fuzzer.faultQueue, ExecFault),
 				p:    p.Clone(),
 				call: call,
+				info: &JobInfo{
+					Name:  p.String(),
+					Type:  "fault",
+					Calls: []string{p.CallName(call)},
+					p:     p,
+				},
 			})
 		}
 	}
@@ -231,9 +290,8 @@ func (job *triageJob) deflake(exec func(*queue.Request, ProgFlags) *queue.Result
 	prevTotalNewSignal := 0
 	for run := 1; ; run++ {
 		totalNewSignal := 0
-		indices := make([]int, 0, len(job.calls))
-		for call, info := range job.calls {
-			indices = append(indices, call)
+		indices := job.sortedCalls()
+		for _, info := range job.calls {
 			totalNewSignal += len(info.newSignal)
 		}
 		if job.stopDeflake(run, needRuns, prevTotalNewSignal == totalNewSignal) {
@@ -300,6 +358,15 @@ func (job *triageJob) deflake(exec func(*queue.Request, ProgFlags) *queue.Result
 	return false
 }
 
+func (job *triageJob) sortedCalls() []int {
+	calls := make([]int, 0, len(job.calls))
+	for call := range job.calls {
+		calls = append(calls, call)
+	}
+	sort.Ints(calls)
+	return calls
+}
+
 func (job *triageJob) stopDeflake(run, needRuns int, noNewSignal bool) bool {
 	if job.fuzzer.Config.Snapshot {
 		return run >= needRuns+1
@@ -451,20 +518,24 @@ func (job *smashJob) run(fuzzer *Fuzzer) {
 	const iters = 25
 	rnd := fuzzer.rand()
 	for i := 0; i < iters; i++ {
+		seed := rnd.Int63()
 		p := job.p.Clone()
-		p.Mutate(rnd, prog.RecommendedCalls,
+		stats := p.MutateWithOpts(rand.New(rand.NewSource(seed)), prog.RecommendedCalls,
 			fuzzer.ChoiceTable(),
 			fuzzer.Config.NoMutateCalls,
-			fuzzer.Config.Corpus.Programs())
-		result := fuzzer.execute(job.exec, &queue.Request{
+			fuzzer.Config.Corpus.Programs(),
+			fuzzer.mutators.mutateOpts(-1))
+		result, newSignal := fuzzer.executeMutant(job.exec, &queue.Request{
 			Prog:     p,
 			ExecOpts: setFlags(flatrpc.ExecFlagCollectSignal),
 			Stat:     fuzzer.statExecSmash,
-		})
+			Seed:     seed,
+		}, &mutationInfo{seed: job.p, stats: stats})
 		if result.Stop() {
 			return
 		}
 		job.info.Execs.Add(1)
+		job.info.addMutant(p, mutationOps(stats), newSignal)
 	}
 }
 
@@ -498,6 +569,11 @@ type faultInjectionJob struct {
 	exec queue.Executor
 	p    *prog.Prog
 	call int
+	info *JobInfo
+}
+
+func (job *faultInjectionJob) getInfo() *JobInfo {
+	return job.info
 }
 
 func (job *faultInjectionJob) run(fuzzer *Fuzzer) {
@@ -506,13 +582,15 @@ func (job *faultInjectionJob) run(fuzzer *Fuzzer) {
 			job.call, nth)
 		newProg := job.p.Clone()
 		newProg.Calls[job.call].Props.FailNth = nth
-		result := fuzzer.execute(job.exec, &queue.Request{
+		result, newSignal := fuzzer.executeMutant(job.exec, &queue.Request{
 			Prog: newProg,
 			Stat: fuzzer.statExecFaultInject,
-		})
+		}, nil)
 		if result.Stop() {
 			return
 		}
+		job.info.Execs.Add(1)
+		job.info.addMutant(newProg, nil, newSignal)
 		info := result.Info
 		if info != nil && len(info.Calls) > job.call &&
 			info.Calls[job.call].Flags&flatrpc.CallFlagFaultInjected == 0 {
@@ -562,19 +640,60 @@ func (job *hintsJob) run(fuzzer *Fuzzer) {
 	job.info.Logf("stable comps: %d", comps.Len())
 	fuzzer.hintsLimiter.Limit(comps)
 	job.info.Logf("stable comps (after the hints limiter): %d", comps.Len())
+	if comps.Len() == 0 {
+		return
+	}
+
+	var taint *prog.HintsTaint
+	if fuzzer.Config.HintsTaint {
+		// Execute the program with the call arguments marked with random values
+		// to learn which arguments influence which comparisons.
+		seed := fuzzer.randSeed()
+		marked := p.MarkHints(rand.NewSource(seed), job.call)
+		result := fuzzer.execute(job.exec, &queue.Request{
+			Prog:     marked,
+			ExecOpts: setFlags(flatrpc.ExecFlagCollectComps),
+			Stat:     fuzzer.statExecHintsTaint,
+			Seed:     seed,
+		})
+		if result.Stop() {
+			return
+		}
+		job.info.Execs.Add(1)
+		if result.Info != nil {
+			markedComps := make(prog.CompMap)
+			for _, cmp := range result.Info.Calls[job.call].Comps {
+				markedComps.Add(cmp.Pc, cmp.Op1, cmp.Op2, cmp.IsConst)
+			}
+			taint = marked.HintsTaint(job.call, markedComps)
+			job.info.Logf("tainted comps: %d", taint.Len())
+		}
+		if taint == nil || taint.Len() == 0 {
+			// No comparison matched the markers, e.g. the kernel transforms the argument values
+			// before comparing them. Filtering would then drop all comparisons of the reached code,
+			// so substitute the comparisons into all arguments.
+			taint = nil
+			fuzzer.statHintsTaintFallback.Add(1)
+			job.info.Logf("no tainted comps, using all comps")
+		}
+	}
 
 	// Then mutate the initial program for every match between
 	// a syscall argument and a comparison operand.
 	// Execute each of such mutants to check if it gives new coverage.
-	p.MutateWithHints(job.call, comps,
+	p.MutateWithTaintedHints(job.call, comps, taint,
 		func(p *prog.Prog) bool {
 			defer job.info.Execs.Add(1)
-			result := fuzzer.execute(job.exec, &queue.Request{
+			result, newSignal := fuzzer.executeMutant(job.exec, &queue.Request{
 				Prog:     p,
 				ExecOpts: setFlags(flatrpc.ExecFlagCollectSignal),
 				Stat:     fuzzer.statExecHint,
-			})
-			return !result.Stop()
+			}, nil)
+			if result.Stop() {
+				return false
+			}
+			job.info.addMutant(p, nil, newSignal)
+			return true
 		})
 }
 
@@ -0,0 +1,161 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package fuzzer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/syzkaller/prog"
+)
+
+// JobRecord is a structured summary of a finished fuzzer job.
+type JobRecord struct {
+	Type string `json:"type"`
+	// Hash of the serialized input program.
+	Input string    `json:"input"`
+	Prog  string    `json:"prog"`
+	Calls []string  `json:"calls,omitempty"`
+	Start time.Time `json:"start"`
+	// Duration in milliseconds.
+	Duration  int64 `json:"duration"`
+	Execs     int   `json:"execs"`
+	NewSignal int   `json:"new_signal"`
+	// Programs derived from the input that were executed by smash/hints/fault injection jobs.
+	// Replaying them gives the same executions as the job did.
+	Mutants []JobMutant `json:"mutants,omitempty"`
+}
+
+type JobMutant struct {
+	Prog string `json:"prog"`
+	// Mutation operators that were applied to the input (only for smash jobs).
+	Ops       map[string]int `json:"ops,omitempty"`
+	NewSignal int            `json:"new_signal"`
+}
+
+// JobLog writes job records to a JSONL file.
+// Once the file grows beyond the size limit, it's renamed to file.1 and a new file is started.
+// If the rotation fails, records are appended to the current file and rotation is not retried.
+type JobLog struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	file       *os.File
+	size       int64
+	rotateFail bool
+}
+
+func NewJobLog(path string, maxSize int64) (*JobLog, error) {
+	jl := &JobLog{
+		path:    path,
+		maxSize: maxSize,
+	}
+	if err := jl.open(); err != nil {
+		return nil, err
+	}
+	return jl, nil
+}
+
+func (jl *JobLog) open() error {
+	file, err := os.OpenFile(jl.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open job log: %w", err)
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat job log: %w", err)
+	}
+	jl.file = file
+	jl.size = stat.Size()
+	return nil
+}
+
+func (jl *JobLog) Write(rec *JobRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	jl.mu.Lock()
+	defer jl.mu.Unlock()
+	if jl.file == nil {
+		return fmt.Errorf("job log is closed")
+	}
+	var rotateErr error
+	if !jl.rotateFail && jl.size != 0 && jl.size+int64(len(data)) > jl.maxSize {
+		if rotateErr = jl.rotate(); rotateErr != nil {
+			jl.rotateFail = true
+		}
+	}
+	n, err := jl.file.Write(data)
+	jl.size += int64(n)
+	if err != nil {
+		return err
+	}
+	return rotateErr
+}
+
+func (jl *JobLog) rotate() error {
+	// The file stays open, so if anything fails, we continue writing to it.
+	if err := os.Rename(jl.path, jl.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate job log: %w", err)
+	}
+	old := jl.file
+	if err := jl.open(); err != nil {
+		return fmt.Errorf("failed to rotate job log: %w", err)
+	}
+	old.Close()
+	return nil
+}
+
+func (jl *JobLog) Close() error {
+	jl.mu.Lock()
+	defer jl.mu.Unlock()
+	if jl.file == nil {
+		return nil
+	}
+	err := jl.file.Close()
+	jl.file = nil
+	return err
+}
+
+// ReadJobLog parses a file written by JobLog.
+func ReadJobLog(path string) ([]*JobRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var recs []*JobRecord
+	s := bufio.NewScanner(file)
+	s.Buffer(nil, 64<<20)
+	for line := 1; s.Scan(); line++ {
+		rec := new(JobRecord)
+		if err := json.Unmarshal(s.Bytes(), rec); err != nil {
+			return nil, fmt.Errorf("%v:%v: %w", path, line, err)
+		}
+		recs = append(recs, rec)
+	}
+	return recs, s.Err()
+}
+
+func mutationOps(stats prog.MutationStats) map[string]int {
+	ops := make(map[string]int)
+	for op, count := range stats.Ops {
+		if count != 0 {
+			ops[prog.MutationOp(op).String()] += count
+		}
+	}
+	for arg, count := range stats.Args {
+		if count != 0 {
+			ops["arg "+prog.ArgMutation(arg).String()] += count
+		}
+	}
+	return ops
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package fuzzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.jsonl")
+	jl, err := NewJobLog(path, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recs []*JobRecord
+	for i := 0; i < 4; i++ {
+		rec := &JobRecord{
+			Type:      "smash",
+			Prog:      "getpid()\n",
+			Execs:     i,
+			NewSignal: i * 10,
+			Mutants: []JobMutant{
+				{Prog: "getpid()\ngetpid()\n", Ops: map[string]int{"insert": 1}, NewSignal: i},
+			},
+		}
+		recs = append(recs, rec)
+		assert.NoError(t, jl.Write(rec))
+	}
+	assert.NoError(t, jl.Close())
+	// Each record is ~200 bytes, so every record must have triggered the rotation.
+	got, err := ReadJobLog(path)
+	assert.NoError(t, err)
+	assert.Equal(t, recs[3:], got)
+	got, err = ReadJobLog(path + ".1")
+	assert.NoError(t, err)
+	assert.Equal(t, recs[2:3], got)
+}
+
+func TestJobLogRotateFail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.jsonl")
+	// Renaming the log over a non-empty directory fails.
+	if err := os.MkdirAll(filepath.Join(path+".1", "dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	jl, err := NewJobLog(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recs []*JobRecord
+	for i := 0; i < 3; i++ {
+		rec := &JobRecord{Type: "triage", Prog: "getpid()\n", Execs: i}
+		recs = append(recs, rec)
+		err := jl.Write(rec)
+		if i == 1 {
+			assert.ErrorContains(t, err, "failed to rotate job log")
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+	assert.NoError(t, jl.Close())
+	got, err := ReadJobLog(path)
+	assert.NoError(t, err)
+	assert.Equal(t, recs, got)
+}
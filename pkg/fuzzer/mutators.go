@@ -0,0 +1,211 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package fuzzer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/syzkaller/pkg/stat"
+	"github.com/google/syzkaller/prog"
+)
+
+// mutationScheduler learns which mutation operators and which kinds of mutated arguments
+// lead to new signal and adjusts prog.MutateOpts weights accordingly.
+// It's a simple discounted multi-armed bandit: every mutation operator and every argument kind
+// is an arm, and the reward is whether the mutated program has produced new signal.
+// Weights are learned separately for every VM since kernel state in VMs diverges over time.
+// Until a VM executes enough mutated programs (and for requests not bound to a VM),
+// the weights learned from all VMs are used. The metrics show the weights learned from all VMs.
+type mutationScheduler struct {
+	mu       sync.Mutex
+	adaptive bool
+	base     prog.MutateOpts
+	all      mutationWeights
+	vms      []*mutationWeights
+}
+
+type mutationWeights struct {
+	// The learned weights are scaled relative to base, disabled (0) operators stay disabled.
+	base    prog.MutateOpts
+	opts    prog.MutateOpts
+	total   mutationArm
+	ops     [prog.MutationOpCount]mutationArm
+	args    [prog.ArgMutationCount]mutationArm
+	results int
+}
+
+type mutationArm struct {
+	uses float64
+	hits float64
+}
+
+const (
+	// Weights are recalculated after this many executions of mutated programs.
+	mutationUpdatePeriod = 1000
+	// On every update old observations are multiplied by this factor,
+	// so that weights follow the changes during the fuzzing campaign.
+	mutationDecay = 0.5
+	// Learned weights stay within [default/mutationMaxScale, default*mutationMaxScale],
+	// so that no operator is ever starved.
+	mutationMaxScale = 4
+	// The number of virtual observations with the average yield added to every operator,
+	// it prevents rarely applied operators from getting extreme weights.
+	mutationPrior = 50
+)
+
+func newMutationScheduler(adaptive bool, base prog.MutateOpts) *mutationScheduler {
+	ms := &mutationScheduler{
+		adaptive: adaptive,
+		base:     base,
+		all:      mutationWeights{base: base, opts: base},
+	}
+	for op := prog.MutationOp(0); op < prog.MutationOpCount; op++ {
+		stat.New(fmt.Sprintf("mutator %v", op),
+			fmt.Sprintf("Current weight of the %v mutation operator", op),
+			stat.Graph("mutator weights"), func() int {
+				ms.mu.Lock()
+				defer ms.mu.Unlock()
+				return ms.all.opts.Weight(op)
+			})
+		stat.New(fmt.Sprintf("mutator %v yield", op),
+			fmt.Sprintf("Mutated programs with new signal per 10000 applications of %v", op),
+			stat.Graph("mutator yield"), func() int {
+				ms.mu.Lock()
+				defer ms.mu.Unlock()
+				return ms.all.ops[op].yield()
+			})
+	}
+	for kind := prog.ArgMutation(0); kind < prog.ArgMutationCount; kind++ {
+		stat.New(fmt.Sprintf("mutator %v arg", kind),
+			fmt.Sprintf("Current weight of %v arguments when choosing an argument to mutate", kind),
+			stat.Graph("arg mutator weights"), func() int {
+				ms.mu.Lock()
+				defer ms.mu.Unlock()
+				return ms.all.opts.ArgWeight(kind)
+			})
+		stat.New(fmt.Sprintf("mutator %v arg yield", kind),
+			fmt.Sprintf("Mutated programs with new signal per 10000 mutations of %v arguments", kind),
+			stat.Graph("arg mutator yield"), func() int {
+				ms.mu.Lock()
+				defer ms.mu.Unlock()
+				return ms.all.args[kind].yield()
+			})
+	}
+	return ms
+}
+
+// mutateOpts returns the weights for programs executed on the given vm (negative, if it's not known).
+func (ms *mutationScheduler) mutateOpts(vm int) prog.MutateOpts {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if vm >= 0 && vm < len(ms.vms) && ms.vms[vm] != nil && ms.vms[vm].results >= mutationUpdatePeriod {
+		return ms.vms[vm].opts
+	}
+	return ms.all.opts
+}
+
+// restore sets the weights learned during the previous run.
+// Per-VM weights are not restored since VMs don't keep their kernel state across restarts.
+func (ms *mutationScheduler) restore(opts prog.MutateOpts) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.adaptive && opts != (prog.MutateOpts{}) {
+		// Operators that did not exist in the previous run get the default weights,
+		// and operators that are disabled now stay disabled.
+		for op := prog.MutationOp(0); op < prog.MutationOpCount; op++ {
+			if opts.Weight(op) == 0 || ms.base.Weight(op) == 0 {
+				opts.SetWeight(op, ms.base.Weight(op))
+			}
+		}
+		ms.all.opts = opts
+	}
+}
+
+// record accounts the result of execution of a program mutated with the given mutations on the vm.
+func (ms *mutationScheduler) record(vm int, mutations prog.MutationStats, newSignal bool) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.all.record(mutations, newSignal, ms.adaptive)
+	if vm < 0 {
+		return
+	}
+	for len(ms.vms) <= vm {
+		ms.vms = append(ms.vms, nil)
+	}
+	if ms.vms[vm] == nil {
+		ms.vms[vm] = &mutationWeights{base: ms.base, opts: ms.base}
+	}
+	ms.vms[vm].record(mutations, newSignal, ms.adaptive)
+}
+
+func (mw *mutationWeights) record(mutations prog.MutationStats, newSignal, adaptive bool) {
+	mw.total.add(newSignal)
+	for op, n := range mutations.Ops {
+		if n != 0 {
+			mw.ops[op].add(newSignal)
+		}
+	}
+	for kind, n := range mutations.Args {
+		if n != 0 {
+			mw.args[kind].add(newSignal)
+		}
+	}
+	mw.results++
+	if mw.results%mutationUpdatePeriod == 0 {
+		mw.update(adaptive)
+	}
+}
+
+func (mw *mutationWeights) update(adaptive bool) {
+	if adaptive && mw.total.hits != 0 {
+		avg := mw.total.hits / mw.total.uses
+		for op := prog.MutationOp(0); op < prog.MutationOpCount; op++ {
+			mw.opts.SetWeight(op, mw.ops[op].weight(avg, mw.base.Weight(op)))
+		}
+		// Argument kinds are compared with the average yield of argument mutations.
+		if argArm := mw.ops[prog.MutationMutateArg]; argArm.hits != 0 {
+			argAvg := argArm.hits / argArm.uses
+			for kind := prog.ArgMutation(0); kind < prog.ArgMutationCount; kind++ {
+				mw.opts.ArgWeights[kind] = mw.args[kind].weight(argAvg, mw.base.ArgWeight(kind))
+			}
+		}
+	}
+	mw.total.decay()
+	for i := range mw.ops {
+		mw.ops[i].decay()
+	}
+	for i := range mw.args {
+		mw.args[i].decay()
+	}
+}
+
+func (arm *mutationArm) add(hit bool) {
+	arm.uses++
+	if hit {
+		arm.hits++
+	}
+}
+
+func (arm *mutationArm) decay() {
+	arm.uses *= mutationDecay
+	arm.hits *= mutationDecay
+}
+
+// weight scales the default weight def by the arm yield relative to the average yield avg.
+func (arm *mutationArm) weight(avg float64, def int) int {
+	if def == 0 {
+		return 0
+	}
+	rate := (arm.hits + mutationPrior*avg) / (arm.uses + mutationPrior)
+	weight := min(max(float64(def)*rate/avg, float64(def)/mutationMaxScale), float64(def)*mutationMaxScale)
+	return max(int(weight+0.5), 1)
+}
+
+func (arm *mutationArm) yield() int {
+	if arm.uses == 0 {
+		return 0
+	}
+	return int(arm.hits * 10000 / arm.uses)
+}
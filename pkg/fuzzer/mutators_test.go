@@ -0,0 +1,95 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package fuzzer
+
+import (
+	"testing"
+
+	"github.com/google/syzkaller/prog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMutationScheduler(t *testing.T) {
+	ms := newMutationScheduler(true, prog.DefaultMutateOpts)
+	var splice, insert prog.MutationStats
+	splice.Ops[prog.MutationSplice] = 1
+	insert.Ops[prog.MutationInsert] = 1
+	for i := 0; i < 10*mutationUpdatePeriod; i++ {
+		// Splice always gives new signal, insert never does.
+		ms.record(-1, splice, true)
+		ms.record(-1, insert, false)
+	}
+	opts := ms.mutateOpts(-1)
+	def := prog.DefaultMutateOpts
+	assert.Greater(t, opts.SpliceWeight, def.SpliceWeight)
+	assert.LessOrEqual(t, opts.SpliceWeight, def.SpliceWeight*mutationMaxScale)
+	assert.Less(t, opts.InsertWeight, def.InsertWeight)
+	assert.GreaterOrEqual(t, opts.InsertWeight, def.InsertWeight/mutationMaxScale)
+	// Operators without observations keep their default weights.
+	assert.Equal(t, def.SquashWeight, opts.SquashWeight)
+	assert.Equal(t, def.RemoveCallWeight, opts.RemoveCallWeight)
+}
+
+func TestMutationSchedulerDisabled(t *testing.T) {
+	ms := newMutationScheduler(false, prog.DefaultMutateOpts)
+	var splice prog.MutationStats
+	splice.Ops[prog.MutationSplice] = 1
+	for i := 0; i < 2*mutationUpdatePeriod; i++ {
+		ms.record(-1, splice, true)
+	}
+	assert.Equal(t, prog.DefaultMutateOpts, ms.mutateOpts(-1))
+	assert.Equal(t, 10000, ms.all.ops[prog.MutationSplice].yield())
+}
+
+func TestMutationSchedulerCrossover(t *testing.T) {
+	assert.Equal(t, 0, (&Config{}).mutateOpts().CrossoverWeight)
+	base := (&Config{Crossover: true}).mutateOpts()
+	assert.Equal(t, prog.DefaultCrossoverWeight, base.CrossoverWeight)
+	// Crossover stays disabled even if it produces new signal.
+	ms := newMutationScheduler(true, prog.DefaultMutateOpts)
+	var crossover prog.MutationStats
+	crossover.Ops[prog.MutationCrossover] = 1
+	for i := 0; i < 2*mutationUpdatePeriod; i++ {
+		ms.record(-1, crossover, true)
+	}
+	assert.Equal(t, 0, ms.mutateOpts(-1).CrossoverWeight)
+}
+
+func TestMutationSchedulerPerVM(t *testing.T) {
+	ms := newMutationScheduler(true, prog.DefaultMutateOpts)
+	var splice, insert prog.MutationStats
+	splice.Ops[prog.MutationSplice] = 1
+	insert.Ops[prog.MutationInsert] = 1
+	for i := 0; i < 10*mutationUpdatePeriod; i++ {
+		// Splice is good on VM 0, insert is good on VM 1.
+		ms.record(0, splice, true)
+		ms.record(0, insert, false)
+		ms.record(1, splice, false)
+		ms.record(1, insert, true)
+	}
+	vm0, vm1 := ms.mutateOpts(0), ms.mutateOpts(1)
+	assert.Greater(t, vm0.SpliceWeight, vm1.SpliceWeight)
+	assert.Less(t, vm0.InsertWeight, vm1.InsertWeight)
+	// VMs without enough observations use the weights learned from all VMs.
+	assert.Equal(t, ms.mutateOpts(-1), ms.mutateOpts(2))
+	ms.record(2, splice, false)
+	assert.Equal(t, ms.mutateOpts(-1), ms.mutateOpts(2))
+}
+
+func TestMutationSchedulerArgs(t *testing.T) {
+	ms := newMutationScheduler(true, prog.DefaultMutateOpts)
+	var ints, buffers prog.MutationStats
+	ints.Ops[prog.MutationMutateArg] = 1
+	ints.Args[prog.ArgMutationInt] = 1
+	buffers.Ops[prog.MutationMutateArg] = 1
+	buffers.Args[prog.ArgMutationBuffer] = 1
+	for i := 0; i < 10*mutationUpdatePeriod; i++ {
+		ms.record(-1, ints, true)
+		ms.record(-1, buffers, false)
+	}
+	opts := ms.mutateOpts(-1)
+	assert.Greater(t, opts.ArgWeight(prog.ArgMutationInt), prog.DefaultArgWeight)
+	assert.Less(t, opts.ArgWeight(prog.ArgMutationBuffer), prog.DefaultArgWeight)
+	assert.Equal(t, prog.DefaultArgWeight, opts.ArgWeight(prog.ArgMutationStruct))
+}
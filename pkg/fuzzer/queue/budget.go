@@ -0,0 +1,154 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package queue
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/syzkaller/pkg/stat"
+)
+
+// BudgetClass is a class of requests served by Budget.
+type BudgetClass struct {
+	Name   string
+	Source Source
+	// Share of executions that the class gets when all classes have requests.
+	// Shares are relative to each other, they don't need to sum up to 1.
+	Share float64
+	// Lazy sources are polled only when the class is selected.
+	// It's intended for sources that never run out of requests (e.g. program generation),
+	// since polling them in advance is expensive.
+	Lazy bool
+}
+
+// BudgetSource serves requests from several classes so that every class gets its share of executions.
+// If a class has no requests, its share is distributed among other classes.
+// Requests with expired deadlines (see Deadline) are served before all other requests.
+type BudgetSource struct {
+	mu      sync.Mutex
+	classes []*budgetClass
+	vtime   float64
+	window  int
+}
+
+type budgetClass struct {
+	BudgetClass
+	pass   float64
+	head   *Request
+	served int
+	share  atomic.Int64
+}
+
+// The actual shares are calculated over that many last requests.
+const budgetWindow = 1000
+
+func Budget(classes ...BudgetClass) *BudgetSource {
+	bs := &BudgetSource{}
+	for _, class := range classes {
+		if class.Share <= 0 {
+			panic(fmt.Sprintf("class %v has non-positive share", class.Name))
+		}
+		c := &budgetClass{BudgetClass: class}
+		bs.classes = append(bs.classes, c)
+		stat.New(fmt.Sprintf("exec share %v", class.Name),
+			fmt.Sprintf("Share of %v executions (%% of the last %v requests)", class.Name, budgetWindow),
+			stat.StackedGraph("exec shares"), func() int {
+				return int(c.share.Load())
+			})
+	}
+	return bs
+}
+
+func (bs *BudgetSource) Next() *Request {
+	return bs.NextVM(-1)
+}
+
+// NextVM passes vm only to lazy sources, other sources are polled in advance.
+func (bs *BudgetSource) NextVM(vm int) *Request {
+	bs.mu.Lock()
+	for _, c := range bs.classes {
+		if c.head == nil && !c.Lazy {
+			c.head = c.Source.Next()
+		}
+	}
+	pick := bs.overdue(time.Now())
+	if pick == nil {
+		// Stride scheduling: every class advances its pass by 1/share on each request,
+		// and the class with the smallest pass is served first. Classes that had no requests
+		// for a while are moved to the current virtual time, so that they don't get a burst.
+		for _, c := range bs.classes {
+			if c.head == nil && !c.Lazy {
+				continue
+			}
+			if pick == nil || max(c.pass, bs.vtime) < max(pick.pass, bs.vtime) {
+				pick = c
+			}
+		}
+	}
+	if pick == nil {
+		bs.mu.Unlock()
+		return nil
+	}
+	bs.vtime = max(pick.pass, bs.vtime)
+	pick.pass = bs.vtime + 1/pick.Share
+	bs.account(pick)
+	req := pick.head
+	pick.head = nil
+	bs.mu.Unlock()
+	if req == nil {
+		req = NextVM(pick.Source, vm)
+	}
+	return req
+}
+
+func (bs *BudgetSource) overdue(now time.Time) *budgetClass {
+	var pick *budgetClass
+	for _, c := range bs.classes {
+		if c.head == nil || c.head.deadline.IsZero() || c.head.deadline.After(now) {
+			continue
+		}
+		if pick == nil || c.head.deadline.Before(pick.head.deadline) {
+			pick = c
+		}
+	}
+	return pick
+}
+
+func (bs *BudgetSource) account(pick *budgetClass) {
+	pick.served++
+	bs.window++
+	if bs.window < budgetWindow {
+		return
+	}
+	for _, c := range bs.classes {
+		c.share.Store(int64(c.served * 100 / bs.window))
+		c.served = 0
+	}
+	bs.window = 0
+}
+
+type deadline struct {
+	exec    Executor
+	timeout time.Duration
+}
+
+// Deadline sets deadline of all requests submitted to exec to timeout after the submission.
+// Budget serves requests with expired deadlines before other requests.
+func Deadline(exec Executor, timeout time.Duration) Executor {
+	if timeout == 0 {
+		return exec
+	}
+	return &deadline{
+		exec:    exec,
+		timeout: timeout,
+	}
+}
+
+func (d *deadline) Submit(req *Request) {
+	req.deadline = time.Now().Add(d.timeout)
+	d.exec.Submit(req)
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudget(t *testing.T) {
+	a, b := Plain(), Plain()
+	var generated int
+	gen := Callback(func() *Request {
+		generated++
+		return &Request{}
+	})
+	bs := Budget(
+		BudgetClass{Name: "a", Source: a, Share: 3},
+		BudgetClass{Name: "b", Source: b, Share: 1},
+		BudgetClass{Name: "gen", Source: gen, Share: 1, Lazy: true},
+	)
+	classes := map[*Request]string{}
+	submit := func(exec Executor, name string, count int) {
+		for i := 0; i < count; i++ {
+			req := &Request{}
+			classes[req] = name
+			exec.Submit(req)
+		}
+	}
+	next := func(count int) map[string]int {
+		got := map[string]int{}
+		for i := 0; i < count; i++ {
+			req := bs.Next()
+			name := classes[req]
+			if name == "" {
+				name = "gen"
+			}
+			got[name]++
+		}
+		return got
+	}
+
+	submit(a, "a", 1000)
+	submit(b, "b", 1000)
+	assert.Equal(t, map[string]int{"a": 300, "b": 100, "gen": 100}, next(500))
+	assert.Equal(t, 100, generated)
+
+	// The empty class share is distributed among other classes.
+	for a.Len() != 0 {
+		a.Next()
+	}
+	assert.Equal(t, map[string]int{"b": 50, "gen": 50}, next(100))
+
+	// Requests with expired deadlines go first.
+	submit(Deadline(a, time.Nanosecond), "a", 10)
+	time.Sleep(time.Millisecond)
+	assert.Equal(t, map[string]int{"a": 10}, next(10))
+}
@@ -6,17 +6,21 @@ package queue
 import (
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/google/syzkaller/pkg/stat"
 )
 
 // Distributor distributes requests to different VMs during input triage
 // (allows to avoid already used VMs).
+// It also tracks health of the VMs (see RecordExec/RecordCrash): risky and important (e.g. triage)
+// requests are not given to unstable VMs, if there are other active VMs that can take them,
+// and important requests are preferably given to the healthiest VMs.
 type Distributor struct {
 	source        Source
 	seq           atomic.Uint64
 	empty         atomic.Bool
-	active        atomic.Pointer[[]atomic.Uint64]
+	vms           atomic.Pointer[[]*vmState]
 	mu            sync.Mutex
 	queue         []*Request
 	statDelayed   *stat.Val
@@ -36,6 +40,64 @@ func Distribute(source Source) *Distributor {
 	}
 }
 
+type vmState struct {
+	active    atomic.Uint64
+	firstSeen atomic.Int64
+	execs     atomic.Uint64
+	hangs     atomic.Uint64
+	crashes   atomic.Uint64
+	tier      atomic.Int32
+}
+
+type HealthTier int
+
+const (
+	HealthNormal HealthTier = iota
+	HealthUnstable
+	HealthBest
+)
+
+func (tier HealthTier) String() string {
+	switch tier {
+	case HealthUnstable:
+		return "unstable"
+	case HealthBest:
+		return "best"
+	default:
+		return "normal"
+	}
+}
+
+// ExecutorHealth describes health of a single VM.
+type ExecutorHealth struct {
+	VM      int
+	Execs   uint64
+	Hangs   uint64
+	Crashes uint64
+	// Executions per second since the VM was first seen.
+	Throughput float64
+	// Score is in (0, 1], higher is better.
+	Score float64
+	Tier  HealthTier
+}
+
+const (
+	// VMs with fewer executions are considered normal.
+	healthMinExecs = 1000
+	// Tiers are recalculated every that many requests.
+	healthUpdatePeriod = 1024
+	// Important requests wait that many requests for one of the best VMs,
+	// before they are given to any other VM that suits them.
+	healthPreferDelay = 100
+)
+
+// Ranks of VMs for a request, see Distributor.rank.
+const (
+	rankAvoid = iota
+	rankSuits
+	rankPrefer
+)
+
 // Next returns the next request to execute on the given vm.
 func (dist *Distributor) Next(vm int) *Request {
 	dist.noteActive(vm)
@@ -43,14 +105,94 @@ func (dist *Distributor) Next(vm int) *Request {
 		return req
 	}
 	for {
-		req := dist.source.Next()
-		if req == nil || !contains(req.Avoid, vm) || !dist.hasOtherActive(req.Avoid) {
+		req := NextVM(dist.source, vm)
+		if req == nil {
+			return nil
+		}
+		if rank := dist.rank(req, vm); rank == rankPrefer || !dist.hasBetterActive(req, vm, rank) {
 			return req
 		}
 		dist.delay(req)
 	}
 }
 
+// RecordExec notes completion of a request on the given vm.
+func (dist *Distributor) RecordExec(vm int, hanged bool) {
+	st := dist.vm(vm)
+	st.execs.Add(1)
+	if hanged {
+		st.hangs.Add(1)
+	}
+}
+
+// RecordCrash notes that the given vm has crashed.
+func (dist *Distributor) RecordCrash(vm int) {
+	dist.vm(vm).crashes.Add(1)
+}
+
+// Health returns health of all VMs that have ever requested or executed programs.
+func (dist *Distributor) Health() []ExecutorHealth {
+	vms := dist.vms.Load()
+	if vms == nil {
+		return nil
+	}
+	var ret []ExecutorHealth
+	for vm, st := range *vms {
+		first := st.firstSeen.Load()
+		if first == 0 {
+			continue
+		}
+		execs := st.execs.Load()
+		ret = append(ret, ExecutorHealth{
+			VM:         vm,
+			Execs:      execs,
+			Hangs:      st.hangs.Load(),
+			Crashes:    st.crashes.Load(),
+			Throughput: float64(execs) / max(time.Since(time.Unix(0, first)).Seconds(), 1),
+			Score:      st.score(),
+			Tier:       HealthTier(st.tier.Load()),
+		})
+	}
+	return ret
+}
+
+// rank says how well the given vm suits the request: rankAvoid VMs get the request only if
+// no other active VM can take it, and rankSuits VMs get it only if no rankPrefer VM takes it
+// within healthPreferDelay requests.
+func (dist *Distributor) rank(req *Request, vm int) int {
+	if contains(req.Avoid, vm) {
+		return rankAvoid
+	}
+	switch dist.tier(vm) {
+	case HealthUnstable:
+		if req.Important || riskyRequest(req) {
+			return rankAvoid
+		}
+	case HealthNormal:
+		if req.Important {
+			return rankSuits
+		}
+	}
+	return rankPrefer
+}
+
+// riskyRequest says if the request is likely to crash or hang the VM.
+func riskyRequest(req *Request) bool {
+	if req.Risky() {
+		return true
+	}
+	if req.Prog == nil {
+		return false
+	}
+	for _, call := range req.Prog.Calls {
+		if call.Props.FailNth > 0 || call.Props.Async || call.Props.Rerun > 0 {
+			// Fault injection or collide.
+			return true
+		}
+	}
+	return false
+}
+
 func (dist *Distributor) delay(req *Request) {
 	dist.mu.Lock()
 	defer dist.mu.Unlock()
@@ -68,12 +210,14 @@ func (dist *Distributor) delayed(vm int) *Request {
 	defer dist.mu.Unlock()
 	seq := dist.seq.Load()
 	for i, req := range dist.queue {
-		violation := contains(req.Avoid, vm)
+		rank := dist.rank(req, vm)
+		violation := rank == rankAvoid
 		// The delayedSince check protects from a situation when we had another VM available,
 		// and delayed a request, but then the VM was taken for reproduction and does not
 		// serve requests any more. If we could not dispatch a request in 1000 attempts,
 		// we gave up and give it to any VM.
-		if violation && req.delayedSince+1000 > seq {
+		if violation && req.delayedSince+1000 > seq ||
+			rank == rankSuits && req.delayedSince+healthPreferDelay > seq {
 			continue
 		}
 		dist.statUndelayed.Add(1)
@@ -90,31 +234,64 @@ func (dist *Distributor) delayed(vm int) *Request {
 	return nil
 }
 
-func (dist *Distributor) noteActive(vm int) {
-	active := dist.active.Load()
-	if active == nil || len(*active) <= vm {
+func (dist *Distributor) vm(vm int) *vmState {
+	vms := dist.vms.Load()
+	if vms == nil || len(*vms) <= vm {
 		dist.mu.Lock()
-		active = dist.active.Load()
-		if active == nil || len(*active) <= vm {
-			tmp := make([]atomic.Uint64, vm+10)
-			active = &tmp
-			dist.active.Store(active)
+		vms = dist.vms.Load()
+		if vms == nil || len(*vms) <= vm {
+			tmp := make([]*vmState, vm+10)
+			if vms != nil {
+				copy(tmp, *vms)
+			}
+			for i := range tmp {
+				if tmp[i] == nil {
+					tmp[i] = new(vmState)
+				}
+			}
+			vms = &tmp
+			dist.vms.Store(vms)
 		}
 		dist.mu.Unlock()
 	}
-	(*active)[vm].Store(dist.seq.Add(1))
+	st := (*vms)[vm]
+	if st.firstSeen.Load() == 0 {
+		st.firstSeen.CompareAndSwap(0, time.Now().UnixNano())
+	}
+	return st
 }
 
-// hasOtherActive says if we recently seen activity from VMs not in the set.
-func (dist *Distributor) hasOtherActive(set []ExecutorID) bool {
+func (dist *Distributor) tier(vm int) HealthTier {
+	vms := dist.vms.Load()
+	if vms == nil || vm < 0 || vm >= len(*vms) {
+		return HealthNormal
+	}
+	return HealthTier((*vms)[vm].tier.Load())
+}
+
+func (dist *Distributor) noteActive(vm int) {
+	st := dist.vm(vm)
+	seq := dist.seq.Add(1)
+	st.active.Store(seq)
+	if seq%healthUpdatePeriod == 0 {
+		dist.updateTiers()
+	}
+}
+
+// hasBetterActive says if we recently seen activity from other VMs that suit the request
+// better than the given rank.
+func (dist *Distributor) hasBetterActive(req *Request, self, rank int) bool {
+	vms := dist.vms.Load()
+	if vms == nil {
+		return false
+	}
 	seq := dist.seq.Load()
-	active := *dist.active.Load()
-	for vm := range active {
-		if contains(set, vm) {
+	for vm, st := range *vms {
+		if vm == self || dist.rank(req, vm) <= rank {
 			continue
 		}
 		// 1000 is semi-random notion of recency.
-		if active[vm].Load()+1000 < seq {
+		if st.active.Load()+1000 < seq {
 			continue
 		}
 		return true
@@ -122,6 +299,44 @@ func (dist *Distributor) hasOtherActive(set []ExecutorID) bool {
 	return false
 }
 
+// updateTiers splits the VMs with enough executions into tiers relative to the best score:
+// VMs with a score close to the best one are the best, and VMs with less than half of it are unstable.
+func (dist *Distributor) updateTiers() {
+	vms := dist.vms.Load()
+	if vms == nil {
+		return
+	}
+	seq := dist.seq.Load()
+	best := 0.0
+	for _, st := range *vms {
+		if st.execs.Load() >= healthMinExecs && st.active.Load()+1000 >= seq {
+			best = max(best, st.score())
+		}
+	}
+	for _, st := range *vms {
+		tier := HealthNormal
+		if score := st.score(); best != 0 && st.execs.Load() >= healthMinExecs {
+			if score >= best*0.9 {
+				tier = HealthBest
+			} else if score < best/2 {
+				tier = HealthUnstable
+			}
+		}
+		st.tier.Store(int32(tier))
+	}
+}
+
+// score is the fraction of executions that did not hang divided by (1 + crashes per 1000 executions).
+func (st *vmState) score() float64 {
+	execs := st.execs.Load()
+	if execs == 0 {
+		return 1
+	}
+	hangRate := float64(st.hangs.Load()) / float64(execs)
+	crashRate := float64(st.crashes.Load()) * 1000 / float64(execs)
+	return max(1-hangRate, 0.001) / (1 + crashRate)
+}
+
 func contains(set []ExecutorID, vm int) bool {
 	for _, id := range set {
 		if id.VM == vm {
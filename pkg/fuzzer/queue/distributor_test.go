@@ -46,3 +46,61 @@ func TestDistributor(t *testing.T) {
 	q.Submit(req)
 	assert.Equal(t, req, dist.Next(1))
 }
+
+func TestDistributorHealth(t *testing.T) {
+	q := Plain()
+	dist := Distribute(q)
+	for vm := 0; vm < 4; vm++ {
+		for i := 0; i < 2000; i++ {
+			dist.RecordExec(vm, vm == 1 && i%10 < 3 || vm == 2 && i%10 < 6)
+		}
+	}
+	// VM 3 does not hang, but crashes too often.
+	for i := 0; i < 3; i++ {
+		dist.RecordCrash(3)
+	}
+	for i := 0; i < healthUpdatePeriod; i++ {
+		assert.Nil(t, dist.Next(i%4))
+	}
+	tiers := map[int]HealthTier{}
+	for _, health := range dist.Health() {
+		tiers[health.VM] = health.Tier
+	}
+	assert.Equal(t, map[int]HealthTier{0: HealthBest, 1: HealthNormal, 2: HealthUnstable, 3: HealthUnstable}, tiers)
+
+	// Risky requests are not given to the unstable VMs.
+	risky := &Request{onceCrashed: true}
+	q.Submit(risky)
+	assert.Nil(t, dist.Next(2))
+	assert.Nil(t, dist.Next(3))
+	assert.Equal(t, risky, dist.Next(1))
+
+	// Important requests are not given to the unstable VMs either,
+	// and are preferably given to the best VMs.
+	important := &Request{Important: true}
+	q.Submit(important)
+	assert.Nil(t, dist.Next(2))
+	assert.Nil(t, dist.Next(1))
+	assert.Equal(t, important, dist.Next(0))
+
+	// But normal VMs get them if the best VMs don't take them for a while.
+	q.Submit(important)
+	attempts := 0
+	for ; dist.Next(1) != important; attempts++ {
+		assert.Less(t, attempts, healthPreferDelay)
+	}
+	assert.Greater(t, attempts, healthPreferDelay/2)
+
+	// Other requests go anywhere.
+	req := &Request{}
+	q.Submit(req)
+	assert.Equal(t, req, dist.Next(2))
+}
+
+func TestDistributorNoVMs(t *testing.T) {
+	dist := Distribute(Plain())
+	assert.Equal(t, HealthNormal, dist.tier(0))
+	assert.False(t, dist.hasBetterActive(&Request{}, 0, rankAvoid))
+	dist.updateTiers()
+	assert.Nil(t, dist.Health())
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/google/syzkaller/pkg/flatrpc"
 	"github.com/google/syzkaller/pkg/hash"
@@ -25,11 +26,15 @@ type Request struct {
 	// RequestTypeProgram executes Prog, and is used by most requests (also the default zero value).
 	// RequestTypeBinary executes binary with file name stored in Data.
 	// RequestTypeGlob expands glob pattern stored in Data.
+	// RequestTypeReadFile reads files (or all files matching glob patterns) stored in Files.
+	// Files are read without blocking and the total size is limited to 256KB,
+	// larger and blocking files are truncated (see FileInfo.Error).
 	Type        flatrpc.RequestType
 	ExecOpts    flatrpc.ExecOpts
 	Prog        *prog.Prog // for RequestTypeProgram
 	BinaryFile  string     // for RequestTypeBinary
 	GlobPattern string     // for 	RequestTypeGlob
+	Files       []string   // for RequestTypeReadFile
 
 	// Return all signal for these calls instead of new signal.
 	ReturnAllSignal []int
@@ -39,6 +44,9 @@ type Request struct {
 	// This stat will be incremented on request completion.
 	Stat *stat.Val
 
+	// Seed of the random source that was used to generate or mutate Prog (0 if none was used).
+	Seed int64
+
 	// Important requests will be retried even from crashed VMs.
 	Important bool
 
@@ -53,6 +61,7 @@ type Request struct {
 
 	onceCrashed  bool
 	delayedSince uint64
+	deadline     time.Time
 
 	mu     sync.Mutex
 	result *Result
@@ -140,6 +149,15 @@ func (r *Request) Validate() error {
 		if r.GlobPattern == "" {
 			return fmt.Errorf("glob pattern is not set")
 		}
+	case flatrpc.RequestTypeReadFile:
+		if len(r.Files) == 0 {
+			return fmt.Errorf("files are not set")
+		}
+		for _, file := range r.Files {
+			if file == "" || strings.IndexByte(file, 0) != -1 {
+				return fmt.Errorf("bad file name %q", file)
+			}
+		}
 	default:
 		return fmt.Errorf("unknown request type")
 	}
@@ -163,6 +181,8 @@ func (r *Request) hash() hash.Sig {
 		data = []byte(r.BinaryFile)
 	case flatrpc.RequestTypeGlob:
 		data = []byte(r.GlobPattern)
+	case flatrpc.RequestTypeReadFile:
+		data = []byte(strings.Join(r.Files, "\x00"))
 	default:
 		panic("unknown request type")
 	}
@@ -181,6 +201,7 @@ type Result struct {
 	Info     *flatrpc.ProgInfo
 	Executor ExecutorID
 	Output   []byte
+	Files    []*flatrpc.FileInfo // for RequestTypeReadFile
 	Status   Status
 	Err      error // More details in case of ExecFailure.
 }
@@ -234,6 +255,21 @@ type Source interface {
 	Next() *Request
 }
 
+// VMSource is implemented by sources that can tailor requests to the VM that will execute them.
+type VMSource interface {
+	Source
+	NextVM(vm int) *Request
+}
+
+// NextVM returns the next request from source for the given vm.
+// Negative vm means that the VM is not known.
+func NextVM(source Source, vm int) *Request {
+	if vs, ok := source.(VMSource); ok {
+		return vs.NextVM(vm)
+	}
+	return source.Next()
+}
+
 // PlainQueue is a straighforward thread-safe Request queue implementation.
 type PlainQueue struct {
 	mu    sync.Mutex
@@ -298,13 +334,17 @@ type orderImpl struct {
 	sources []Source
 }
 
-func Order(sources ...Source) Source {
+func Order(sources ...Source) VMSource {
 	return &orderImpl{sources: sources}
 }
 
 func (o *orderImpl) Next() *Request {
+	return o.NextVM(-1)
+}
+
+func (o *orderImpl) NextVM(vm int) *Request {
 	for _, s := range o.sources {
-		req := s.Next()
+		req := NextVM(s, vm)
 		if req != nil {
 			return req
 		}
@@ -325,6 +365,24 @@ func (cb *callback) Next() *Request {
 	return cb.cb()
 }
 
+type vmCallback struct {
+	cb func(vm int) *Request
+}
+
+// VMCallback is like Callback, but the callback also gets the VM that will execute the request
+// (negative, if it's not known).
+func VMCallback(cb func(vm int) *Request) VMSource {
+	return &vmCallback{cb}
+}
+
+func (cb *vmCallback) Next() *Request {
+	return cb.cb(-1)
+}
+
+func (cb *vmCallback) NextVM(vm int) *Request {
+	return cb.cb(vm)
+}
+
 type alternate struct {
 	base Source
 	nth  int
@@ -414,6 +472,10 @@ func (ds *DynamicSourceCtl) Next() *Request {
 	return (*ds.value.Load()).Next()
 }
 
+func (ds *DynamicSourceCtl) NextVM(vm int) *Request {
+	return NextVM(*ds.value.Load(), vm)
+}
+
 // Deduplicator() keeps track of the previously run requests to avoid re-running them.
 type Deduplicator struct {
 	mu     sync.Mutex
@@ -479,7 +541,7 @@ func (d *Deduplicator) onDone(req *Request, res *Result) bool {
 }
 
 // DefaultOpts applies opts to all requests in source.
-func DefaultOpts(source Source, opts flatrpc.ExecOpts) Source {
+func DefaultOpts(source Source, opts flatrpc.ExecOpts) VMSource {
 	return &defaultOpts{source, opts}
 }
 
@@ -489,7 +551,11 @@ type defaultOpts struct {
 }
 
 func (do *defaultOpts) Next() *Request {
-	req := do.source.Next()
+	return do.NextVM(-1)
+}
+
+func (do *defaultOpts) NextVM(vm int) *Request {
+	req := NextVM(do.source, vm)
 	if req == nil {
 		return nil
 	}
@@ -556,12 +622,16 @@ type tee struct {
 	src   Source
 }
 
-func Tee(src Source, queue Executor) Source {
+func Tee(src Source, queue Executor) VMSource {
 	return &tee{src: src, queue: queue}
 }
 
 func (t *tee) Next() *Request {
-	req := t.src.Next()
+	return t.NextVM(-1)
+}
+
+func (t *tee) NextVM(vm int) *Request {
+	req := NextVM(t.src, vm)
 	if req == nil {
 		return nil
 	}
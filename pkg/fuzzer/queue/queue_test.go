@@ -6,6 +6,7 @@ package queue
 import (
 	"testing"
 
+	"github.com/google/syzkaller/pkg/flatrpc"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -50,3 +51,16 @@ func TestGlobFiles(t *testing.T) {
 	r.Output = []byte{'a', 'b', 0, 'c', 0}
 	assert.Equal(t, r.GlobFiles(), []string{"ab", "c"})
 }
+
+func TestNextVM(t *testing.T) {
+	var vms []int
+	source := VMCallback(func(vm int) *Request {
+		vms = append(vms, vm)
+		return &Request{}
+	})
+	wrapped := Distribute(Retry(DefaultOpts(DynamicSource(source), flatrpc.ExecOpts{})))
+	wrapped.Next(3)
+	Retry(source).Next()
+	Order(Plain(), source).NextVM(5)
+	assert.Equal(t, []int{3, -1, 5}, vms)
+}
@@ -13,7 +13,7 @@ type retryer struct {
 }
 
 // Retry adds a layer that resends results with Status=Restarted.
-func Retry(base Source) Source {
+func Retry(base Source) VMSource {
 	return &retryer{
 		base: base,
 		pq:   Plain(),
@@ -21,9 +21,13 @@ func Retry(base Source) Source {
 }
 
 func (r *retryer) Next() *Request {
+	return r.NextVM(-1)
+}
+
+func (r *retryer) NextVM(vm int) *Request {
 	req := r.pq.tryNext()
 	if req == nil {
-		req = r.base.Next()
+		req = NextVM(r.base, vm)
 	}
 	if req != nil {
 		req.OnDone(r.done)
@@ -0,0 +1,143 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package fuzzer
+
+import (
+	"github.com/google/syzkaller/pkg/corpus"
+	"github.com/google/syzkaller/pkg/hash"
+	"github.com/google/syzkaller/pkg/signal"
+	"github.com/google/syzkaller/prog"
+)
+
+// State is a snapshot of the fuzzer state that allows to resume fuzzing after a restart
+// without re-triaging the programs that were already in the corpus.
+// The choice table and the focus area assignments are not saved since they are
+// recalculated from the restored corpus and call transitions.
+type State struct {
+	MaxSignal  signal.Signal
+	Corpus     []StateItem
+	MutateOpts prog.MutateOpts
+	// Call transitions (see prog.CallTransitions) by call names since syscall IDs may change.
+	Transitions map[string]map[string]int32
+	// Programs that were being triaged (except for the corpus candidates),
+	// they are triaged again after the restart.
+	Triage [][]byte
+}
+
+type StateItem struct {
+	Prog   []byte
+	Call   int
+	Signal signal.Signal
+	Cover  []uint64
+	// Mutation statistics used to schedule seeds.
+	Mutations uint64
+	NewSignal uint64
+}
+
+func (fuzzer *Fuzzer) State() *State {
+	items := fuzzer.Config.Corpus.Items()
+	state := &State{
+		MaxSignal:   fuzzer.Cover.CopyMaxSignal(),
+		Corpus:      make([]StateItem, 0, len(items)),
+		MutateOpts:  fuzzer.mutators.mutateOpts(-1),
+		Transitions: make(map[string]map[string]int32),
+	}
+	for prev, nexts := range fuzzer.CallTransitions() {
+		names := make(map[string]int32)
+		for next, n := range nexts {
+			names[fuzzer.target.Syscalls[next].Name] = n
+		}
+		state.Transitions[fuzzer.target.Syscalls[prev].Name] = names
+	}
+	for _, item := range items {
+		state.Corpus = append(state.Corpus, StateItem{
+			Prog:      item.Prog.Serialize(),
+			Call:      item.Call,
+			Signal:    item.Signal,
+			Cover:     item.Cover,
+			Mutations: item.Mutations(),
+			NewSignal: item.NewSignalMutations(),
+		})
+	}
+	fuzzer.mu.Lock()
+	for job := range fuzzer.runningJobs {
+		if triage, ok := job.(*triageJob); ok && triage.flags&progCandidate == 0 {
+			state.Triage = append(state.Triage, triage.p.Serialize())
+		}
+	}
+	fuzzer.mu.Unlock()
+	return state
+}
+
+// RestoreState loads the state previously returned by State.
+// Only corpus items with programs that contain just the enabled syscalls
+// and are accepted by the filter are restored.
+// Returns the set of signatures of the restored corpus programs and the programs
+// that were being triaged, they need to be added as candidates.
+func (fuzzer *Fuzzer) RestoreState(state *State, filter func(sig string) bool) (map[string]bool, []Candidate) {
+	restored := make(map[string]bool)
+	for _, item := range state.Corpus {
+		p, err := fuzzer.target.Deserialize(item.Prog, prog.NonStrict)
+		if err != nil {
+			fuzzer.Logf(1, "failed to restore corpus program: %v", err)
+			continue
+		}
+		if !fuzzer.callsEnabled(p) {
+			continue
+		}
+		if item.Call >= len(p.Calls) {
+			continue
+		}
+		// If deserialization has changed the program, or the program was removed
+		// from the persistent corpus, it must go through the normal triage.
+		sig := hash.String(p.Serialize())
+		if !filter(sig) {
+			continue
+		}
+		fuzzer.Config.Corpus.Restore(corpus.NewInput{
+			Prog:   p,
+			Call:   item.Call,
+			Signal: item.Signal,
+			Cover:  item.Cover,
+		}, item.Mutations, item.NewSignal)
+		restored[sig] = true
+	}
+	var triage []Candidate
+	for _, data := range state.Triage {
+		p, err := fuzzer.target.Deserialize(data, prog.NonStrict)
+		if err != nil || !fuzzer.callsEnabled(p) {
+			continue
+		}
+		triage = append(triage, Candidate{Prog: p})
+	}
+	fuzzer.Cover.restoreMaxSignal(state.MaxSignal)
+	fuzzer.mutators.restore(state.MutateOpts)
+	fuzzer.transMu.Lock()
+	for prevName, nexts := range state.Transitions {
+		prev := fuzzer.target.SyscallMap[prevName]
+		if prev == nil {
+			continue
+		}
+		for nextName, n := range nexts {
+			if next := fuzzer.target.SyscallMap[nextName]; next != nil {
+				if fuzzer.transitions[prev.ID] == nil {
+					fuzzer.transitions[prev.ID] = make(map[int]int32)
+				}
+				fuzzer.transitions[prev.ID][next.ID] += n
+			}
+		}
+	}
+	fuzzer.transMu.Unlock()
+	fuzzer.updateChoiceTable(fuzzer.Config.Corpus.Programs())
+	return restored, triage
+}
+
+func (fuzzer *Fuzzer) callsEnabled(p *prog.Prog) bool {
+	for _, c := range p.Calls {
+		if !fuzzer.Config.EnabledCalls[c.Meta] {
+			return false
+		}
+	}
+	return true
+}
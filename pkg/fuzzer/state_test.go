@@ -0,0 +1,80 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package fuzzer
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/google/syzkaller/pkg/corpus"
+	"github.com/google/syzkaller/pkg/hash"
+	"github.com/google/syzkaller/pkg/signal"
+	"github.com/google/syzkaller/pkg/testutil"
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/sys/targets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreState(t *testing.T) {
+	target, err := prog.GetTarget(targets.TestOS, targets.TestArch64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := map[*prog.Syscall]bool{}
+	for _, c := range target.Syscalls {
+		calls[c] = true
+	}
+	newFuzzer := func() *Fuzzer {
+		return NewFuzzer(ctx, &Config{
+			Corpus:       corpus.NewCorpus(ctx),
+			EnabledCalls: calls,
+		}, rand.New(testutil.RandSource(t)), target)
+	}
+	rs := testutil.RandSource(t)
+	ct := target.DefaultChoiceTable()
+	fuzzer := newFuzzer()
+	fuzzer.Cover.addRawMaxSignal([]uint64{1, 2, 3, 4, 5}, 0)
+	var sigs []string
+	for i := 0; i < 10; i++ {
+		p := target.Generate(rs, 5, ct)
+		fuzzer.Config.Corpus.Save(corpus.NewInput{
+			Prog:   p,
+			Call:   0,
+			Signal: signal.FromRaw([]uint64{uint64(i)}, 0),
+			Cover:  []uint64{uint64(i)},
+		})
+		sigs = append(sigs, hash.String(p.Serialize()))
+	}
+	seed := fuzzer.Config.Corpus.Item(sigs[1])
+	fuzzer.Config.Corpus.RecordMutation(seed.Prog, true)
+	fuzzer.recordTransition(seed.Prog, 1)
+	inTriage := target.Generate(rs, 5, ct)
+	fuzzer.runningJobs[&triageJob{p: inTriage}] = struct{}{}
+	fuzzer.runningJobs[&triageJob{p: target.Generate(rs, 5, ct), flags: progCandidate}] = struct{}{}
+	state := fuzzer.State()
+	assert.Len(t, state.Corpus, 10)
+
+	// The program that is no longer in the persistent corpus must not be restored.
+	dropped := sigs[0]
+	restoredFuzzer := newFuzzer()
+	restored, triage := restoredFuzzer.RestoreState(state, func(sig string) bool {
+		return sig != dropped
+	})
+	assert.Len(t, restored, 9)
+	assert.False(t, restored[dropped])
+	assert.Equal(t, 9, restoredFuzzer.Config.Corpus.StatProgs.Val())
+	assert.Nil(t, restoredFuzzer.Config.Corpus.Item(dropped))
+	assert.Equal(t, fuzzer.Cover.CopyMaxSignal(), restoredFuzzer.Cover.CopyMaxSignal())
+	assert.Equal(t, fuzzer.CallTransitions(), restoredFuzzer.CallTransitions())
+	restoredSeed := restoredFuzzer.Config.Corpus.Item(sigs[1])
+	assert.Equal(t, uint64(1), restoredSeed.Mutations())
+	assert.Equal(t, uint64(1), restoredSeed.NewSignalMutations())
+	// Only the programs that were triaged after fuzzing are restored, candidates come from the corpus.
+	if assert.Len(t, triage, 1) {
+		assert.Equal(t, inTriage.Serialize(), triage[0].Prog.Serialize())
+	}
+}
@@ -32,6 +32,8 @@ type Stats struct {
 	statExecFaultInject     *stat.Val
 	statExecHint            *stat.Val
 	statExecSeed            *stat.Val
+	statExecHintsTaint      *stat.Val
+	statHintsTaintFallback  *stat.Val
 	statExecCollide         *stat.Val
 }
 
@@ -56,7 +58,8 @@ func newStats(target *prog.Target) Stats {
 			stat.StackedGraph("jobs"), stat.Link("/jobs?type=triage")),
 		statJobsSmash: stat.New("smash jobs", "Running smash jobs", stat.StackedGraph("jobs"),
 			stat.Link("/jobs?type=smash")),
-		statJobsFaultInjection: stat.New("fault jobs", "Running fault injection jobs", stat.StackedGraph("jobs")),
+		statJobsFaultInjection: stat.New("fault jobs", "Running fault injection jobs", stat.StackedGraph("jobs"),
+			stat.Link("/jobs?type=fault")),
 		statJobsHints: stat.New("hints jobs", "Running hints jobs", stat.StackedGraph("jobs"),
 			stat.Link("/jobs?type=hints")),
 		statExecTime: stat.New("prog exec time", "Test program execution time (ms)", stat.Distribution{}),
@@ -78,6 +81,11 @@ func newStats(target *prog.Target) Stats {
 			stat.Rate{}, stat.StackedGraph("exec")),
 		statExecSeed: stat.New("exec seeds", "Executions of programs for hints extraction",
 			stat.Rate{}, stat.StackedGraph("exec")),
+		statExecHintsTaint: stat.New("exec hints taint", "Executions of programs with marked arguments for hints taint",
+			stat.Rate{}, stat.StackedGraph("exec")),
+		statHintsTaintFallback: stat.New("hints taint fallback",
+			"Hints jobs that used all comparisons because no comparison matched the argument markers",
+			stat.Graph("hints taint")),
 		statExecCollide: stat.New("exec collide", "Executions of programs in collide mode",
 			stat.Rate{}, stat.StackedGraph("exec")),
 	}
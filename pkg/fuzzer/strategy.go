@@ -0,0 +1,172 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package fuzzer
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/google/syzkaller/pkg/flatrpc"
+	"github.com/google/syzkaller/pkg/fuzzer/queue"
+	"github.com/google/syzkaller/pkg/stat"
+)
+
+// Strategy is a pluggable source of test programs for the generate execution class
+// (e.g. a protocol-specific mutator, or a source of externally generated seeds).
+// Strategies are registered with RegisterStrategy (usually in an init function),
+// and are enabled by name in Config.Strategies. On every call genFuzz chooses one of the enabled
+// strategies or the built-in generation/mutation (DefaultStrategy) proportionally to their weights.
+// Methods may be called concurrently.
+type Strategy interface {
+	// Next returns a request to execute, or nil if the strategy has nothing to execute right now
+	// (then the built-in generation/mutation is used instead). Only Prog and ExecOpts need to be set,
+	// signal collection is always enabled.
+	Next(rnd *rand.Rand) *queue.Request
+	// Done is called once the request returned by Next is executed.
+	// newSignal is the amount of new signal the program has given.
+	Done(req *queue.Request, res *queue.Result, newSignal int)
+	// Stats returns strategy-specific metrics, they are exported as "strategy NAME STAT".
+	Stats() []StrategyStat
+}
+
+type StrategyStat struct {
+	Name  string
+	Desc  string
+	Value func() int
+}
+
+// StrategyCtor creates a strategy for the fuzzer. The fuzzer may be used to access the corpus,
+// the choice table, etc.
+type StrategyCtor func(fuzzer *Fuzzer) Strategy
+
+const (
+	// DefaultStrategy is the name of the built-in generation/mutation in Config.Strategies.
+	DefaultStrategy = "default"
+	// The weight of the built-in generation/mutation if it's not specified in Config.Strategies.
+	DefaultStrategyWeight = 100
+)
+
+var (
+	strategiesMu sync.Mutex
+	strategies   = map[string]StrategyCtor{}
+)
+
+func RegisterStrategy(name string, ctor StrategyCtor) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	if name == DefaultStrategy || strategies[name] != nil {
+		panic(fmt.Sprintf("strategy %q is already registered", name))
+	}
+	strategies[name] = ctor
+}
+
+// ValidateStrategies checks that the strategy weights are suitable for Config.Strategies.
+func ValidateStrategies(weights map[string]float64) error {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	for name, weight := range weights {
+		if name == DefaultStrategy {
+			if weight < 0 {
+				return fmt.Errorf("weight of strategy %v can't be negative", name)
+			}
+			continue
+		}
+		if strategies[name] == nil {
+			var known []string
+			for name := range strategies {
+				known = append(known, name)
+			}
+			sort.Strings(known)
+			return fmt.Errorf("unknown strategy %q, known strategies: %v", name, known)
+		}
+		if weight <= 0 {
+			return fmt.Errorf("weight of strategy %v must be positive", name)
+		}
+	}
+	return nil
+}
+
+type strategySet struct {
+	list []*strategyInstance
+	// Sum of weights of all strategies, including the built-in one.
+	total float64
+}
+
+type strategyInstance struct {
+	name     string
+	weight   float64
+	impl     Strategy
+	statExec *stat.Val
+}
+
+func newStrategySet(fuzzer *Fuzzer, weights map[string]float64) *strategySet {
+	if err := ValidateStrategies(weights); err != nil {
+		panic(err)
+	}
+	set := &strategySet{
+		total: DefaultStrategyWeight,
+	}
+	if weight, ok := weights[DefaultStrategy]; ok {
+		set.total = weight
+	}
+	for name, weight := range weights {
+		if name == DefaultStrategy {
+			continue
+		}
+		strategiesMu.Lock()
+		ctor := strategies[name]
+		strategiesMu.Unlock()
+		s := &strategyInstance{
+			name:   name,
+			weight: weight,
+			impl:   ctor(fuzzer),
+			statExec: stat.New("exec strategy "+name, fmt.Sprintf("Executions of programs from %v strategy", name),
+				stat.Rate{}, stat.StackedGraph("exec")),
+		}
+		for _, st := range s.impl.Stats() {
+			stat.New(fmt.Sprintf("strategy %v %v", name, st.Name), st.Desc,
+				stat.Graph("strategy "+name), st.Value)
+		}
+		set.list = append(set.list, s)
+		set.total += weight
+	}
+	// The order affects choice, so it must not depend on the map iteration order.
+	sort.Slice(set.list, func(i, j int) bool {
+		return set.list[i].name < set.list[j].name
+	})
+	return set
+}
+
+// choose returns a randomly chosen strategy, or nil if the built-in one is chosen.
+func (set *strategySet) choose(rnd *rand.Rand) *strategyInstance {
+	if len(set.list) == 0 {
+		return nil
+	}
+	val := rnd.Float64() * set.total
+	for _, s := range set.list {
+		if val < s.weight {
+			return s
+		}
+		val -= s.weight
+	}
+	return nil
+}
+
+func (s *strategyInstance) next(fuzzer *Fuzzer, rnd *rand.Rand) *queue.Request {
+	req := s.impl.Next(rnd)
+	if req == nil {
+		return nil
+	}
+	req.ExecOpts.ExecFlags |= flatrpc.ExecFlagCollectSignal
+	req.Stat = s.statExec
+	req.OnDone(func(req *queue.Request, res *queue.Result) bool {
+		newSignal := 0
+		ret := fuzzer.processResult(req, res, 0, 0, nil, &newSignal)
+		s.impl.Done(req, res, newSignal)
+		return ret
+	})
+	return req
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package fuzzer
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/syzkaller/pkg/corpus"
+	"github.com/google/syzkaller/pkg/flatrpc"
+	"github.com/google/syzkaller/pkg/fuzzer/queue"
+	"github.com/google/syzkaller/pkg/testutil"
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/sys/targets"
+	"github.com/stretchr/testify/assert"
+)
+
+type testStrategy struct {
+	fuzzer *Fuzzer
+	done   atomic.Int64
+}
+
+var testStrategyInstance atomic.Pointer[testStrategy]
+
+func init() {
+	RegisterStrategy("test", func(fuzzer *Fuzzer) Strategy {
+		s := &testStrategy{fuzzer: fuzzer}
+		testStrategyInstance.Store(s)
+		return s
+	})
+}
+
+func (s *testStrategy) Next(rnd *rand.Rand) *queue.Request {
+	return &queue.Request{
+		Prog: s.fuzzer.target.Generate(rnd, 1, s.fuzzer.ChoiceTable()),
+	}
+}
+
+func (s *testStrategy) Done(req *queue.Request, res *queue.Result, newSignal int) {
+	s.done.Add(1)
+}
+
+func (s *testStrategy) Stats() []StrategyStat {
+	return []StrategyStat{{"done", "Executed test programs", func() int { return int(s.done.Load()) }}}
+}
+
+func TestStrategies(t *testing.T) {
+	target, err := prog.GetTarget(targets.TestOS, targets.TestArch64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fuzzer := NewFuzzer(ctx, &Config{
+		Corpus:     corpus.NewCorpus(ctx),
+		Strategies: map[string]float64{"test": 1, DefaultStrategy: 3},
+	}, rand.New(testutil.RandSource(t)), target)
+	strategy := testStrategyInstance.Load()
+	const iters = 1000
+	fromStrategy := 0
+	for i := 0; i < iters; i++ {
+		req := fuzzer.genFuzz(-1)
+		if req.Stat == fuzzer.strategies.list[0].statExec {
+			fromStrategy++
+			assert.NotZero(t, req.ExecOpts.ExecFlags&flatrpc.ExecFlagCollectSignal)
+		}
+		req.Done(&queue.Result{Status: queue.Success})
+	}
+	assert.InDelta(t, iters/4, fromStrategy, iters/10)
+	assert.Equal(t, int64(fromStrategy), strategy.done.Load())
+}
+
+func TestValidateStrategies(t *testing.T) {
+	assert.NoError(t, ValidateStrategies(nil))
+	assert.NoError(t, ValidateStrategies(map[string]float64{"test": 1, DefaultStrategy: 0}))
+	assert.Error(t, ValidateStrategies(map[string]float64{"unknown": 1}))
+	assert.Error(t, ValidateStrategies(map[string]float64{"test": 0}))
+	assert.Error(t, ValidateStrategies(map[string]float64{DefaultStrategy: -1}))
+}
@@ -157,6 +157,8 @@ type ExecParams struct {
 	// Only one of these will be used, depending on the function.
 	CProg   *prog.Prog
 	SyzProg []byte
+	// If set, RunCProg() runs this source instead of the one generated from CProg.
+	CSource []byte
 
 	Opts     csource.Options
 	Duration time.Duration
@@ -166,9 +168,13 @@ type ExecParams struct {
 }
 
 func (inst *ExecProgInstance) RunCProg(params ExecParams) (*RunResult, error) {
-	src, err := csource.Write(params.CProg, params.Opts)
-	if err != nil {
-		return nil, err
+	src := params.CSource
+	if src == nil {
+		var err error
+		src, err = csource.Write(params.CProg, params.Opts)
+		if err != nil {
+			return nil, err
+		}
 	}
 	inst.Logf(2, "testing compiled C program (duration=%v, %+v): %s",
 		params.Duration, params.Opts, params.CProg)
@@ -0,0 +1,52 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package instance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/google/syzkaller/pkg/flatrpc"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/report"
+	"github.com/google/syzkaller/vm"
+)
+
+// SetupSnapshot starts executor in the snapshot mode on the instance and takes the snapshot
+// for the given features, environment flags and sandbox argument.
+// After that programs can be executed with inst.RunSnapshot.
+func SetupSnapshot(ctx context.Context, inst *vm.Instance, cfg *mgrconfig.Config, reporter *report.Reporter,
+	builder *flatbuffers.Builder, features flatrpc.Feature, env flatrpc.ExecEnv, sandboxArg int64) error {
+	executor := cfg.SysTarget.ExecutorBin
+	if executor == "" {
+		var err error
+		executor, err = inst.Copy(cfg.ExecutorBin)
+		if err != nil {
+			return fmt.Errorf("failed to copy syz-executor to VM: %w", err)
+		}
+	}
+	// All network connections (including ssh) will break once we start restoring snapshots.
+	// So we start a background process and log to /dev/kmsg.
+	cmd := fmt.Sprintf("nohup %v exec snapshot 1>/dev/null 2>/dev/kmsg </dev/null &", executor)
+	ctxTimeout, cancel := context.WithTimeout(ctx, time.Hour)
+	defer cancel()
+	if _, _, err := inst.Run(ctxTimeout, reporter, cmd); err != nil {
+		return err
+	}
+	msg := flatrpc.SnapshotHandshakeT{
+		CoverEdges:       cfg.Experimental.CoverEdges,
+		Kernel64Bit:      cfg.SysTarget.PtrSize == 8,
+		Slowdown:         int32(cfg.Timeouts.Slowdown),
+		SyscallTimeoutMs: int32(cfg.Timeouts.Syscall / time.Millisecond),
+		ProgramTimeoutMs: int32(cfg.Timeouts.Program / time.Millisecond),
+		Features:         features,
+		EnvFlags:         env,
+		SandboxArg:       sandboxArg,
+	}
+	builder.Reset()
+	builder.Finish(msg.Pack(builder))
+	return inst.SetupSnapshot(builder.FinishedBytes())
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/syzkaller/pkg/fuzzer"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/osutil"
+)
+
+// checkpoint is the fuzzer state persisted in the workdir.
+type checkpoint struct {
+	// Kernel identifies the kernel the state was collected on.
+	// Signal of a different kernel is meaningless, so such checkpoints are ignored.
+	Kernel string
+	State  *fuzzer.State
+}
+
+func checkpointFile(cfg *mgrconfig.Config) string {
+	return filepath.Join(cfg.Workdir, "fuzzer.state")
+}
+
+// SaveCheckpoint atomically writes the fuzzer state to workdir/fuzzer.state.
+// If the kernel can't be identified (see checkpointKernel), nothing is saved.
+func SaveCheckpoint(cfg *mgrconfig.Config, state *fuzzer.State) error {
+	kernel := checkpointKernel(cfg)
+	if kernel == "" {
+		return nil
+	}
+	buf := new(bytes.Buffer)
+	gz := gzip.NewWriter(buf)
+	err := gob.NewEncoder(gz).Encode(&checkpoint{
+		Kernel: kernel,
+		State:  state,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode the fuzzer state: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return osutil.WriteFileAtomically(checkpointFile(cfg), buf.Bytes())
+}
+
+// LoadCheckpoint reads the fuzzer state saved by SaveCheckpoint.
+// It returns nil if there's no checkpoint, or if it was made for a different
+// or an unknown kernel.
+func LoadCheckpoint(cfg *mgrconfig.Config) (*fuzzer.State, error) {
+	data, err := os.ReadFile(checkpointFile(cfg))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the fuzzer state: %w", err)
+	}
+	defer gz.Close()
+	var cp checkpoint
+	if err := gob.NewDecoder(gz).Decode(&cp); err != nil {
+		return nil, fmt.Errorf("failed to decode the fuzzer state: %w", err)
+	}
+	if kernel := checkpointKernel(cfg); cp.State == nil || kernel == "" || cp.Kernel != kernel {
+		return nil, nil
+	}
+	return cp.State, nil
+}
+
+// checkpointKernel returns the identity of the kernel, or "" if it's unknown.
+func checkpointKernel(cfg *mgrconfig.Config) string {
+	if cfg.Tag != "" {
+		return cfg.Tag
+	}
+	if cfg.KernelObj == "" || cfg.SysTarget == nil {
+		return ""
+	}
+	// Without a tag, use the kernel binary identity: a rebuilt kernel has a different modification time.
+	fi, err := os.Stat(filepath.Join(cfg.KernelObj, cfg.SysTarget.KernelObject))
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%v-%v", fi.Size(), fi.ModTime().UnixNano())
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/google/syzkaller/pkg/fuzzer"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/signal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpoint(t *testing.T) {
+	cfg := &mgrconfig.Config{
+		Workdir: t.TempDir(),
+		Tag:     "kernel-1",
+	}
+	state, err := LoadCheckpoint(cfg)
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+
+	saved := &fuzzer.State{
+		MaxSignal: signal.FromRaw([]uint64{1, 2, 3}, 1),
+		Corpus: []fuzzer.StateItem{
+			{
+				Prog:   []byte("getpid()\n"),
+				Signal: signal.FromRaw([]uint64{1, 2}, 1),
+				Cover:  []uint64{10, 20},
+			},
+		},
+	}
+	assert.NoError(t, SaveCheckpoint(cfg, saved))
+	state, err = LoadCheckpoint(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, saved, state)
+
+	// The state of a different kernel is ignored.
+	cfg.Tag = "kernel-2"
+	state, err = LoadCheckpoint(cfg)
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func TestCheckpointUnknownKernel(t *testing.T) {
+	cfg := &mgrconfig.Config{
+		Workdir: t.TempDir(),
+	}
+	saved := &fuzzer.State{
+		MaxSignal: signal.FromRaw([]uint64{1}, 1),
+	}
+	// The state of an unknown kernel is neither saved, nor restored.
+	assert.NoError(t, SaveCheckpoint(cfg, saved))
+	assert.NoFileExists(t, checkpointFile(cfg))
+	cfg.Tag = "kernel-1"
+	assert.NoError(t, SaveCheckpoint(cfg, saved))
+	cfg.Tag = ""
+	state, err := LoadCheckpoint(cfg)
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+}
@@ -4,6 +4,7 @@
 package manager
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -11,6 +12,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/syzkaller/pkg/hash"
@@ -25,6 +27,9 @@ type CrashStore struct {
 	BaseDir      string
 	MaxCrashLogs int
 	MaxReproLogs int
+
+	sigMu sync.Mutex
+	sigs  map[string]*bugSignatures // see loadSignatures
 }
 
 const reproFileName = "repro.prog"
@@ -89,6 +94,12 @@ func (cs *CrashStore) SaveCrash(crash *Crash) (bool, error) {
 	writeOrRemove("tag", []byte(cs.Tag))
 	writeOrRemove("report", crash.Report.Report)
 	writeOrRemove("machineInfo", crash.MachineInfo)
+	var signature []byte
+	if crash.Signature != nil {
+		signature, _ = json.Marshal(crash.Signature)
+	}
+	writeOrRemove("signature", signature)
+	cs.saveSignature(crash.Title, oldestI, crash.Signature)
 
 	return first, nil
 }
@@ -206,6 +217,8 @@ type CrashInfo struct {
 	Tag    string
 	Report string // filename relative to workdir
 	Time   time.Time
+	// Crashes of the bug with the same group are related (see CrashSignature), 0 if unknown.
+	Group int
 }
 
 type BugInfo struct {
@@ -218,6 +231,12 @@ type BugInfo struct {
 	StraceFile    string // relative to the workdir
 	ReproAttempts int
 	Crashes       []*CrashInfo
+
+	// These fields are only set if full=true.
+	// The number of groups of related crashes, more than 1 means the title may mask several bugs.
+	Groups int
+	// Bugs with crashes similar to the crashes of this bug.
+	Related []RelatedBug
 }
 
 func (cs *CrashStore) BugInfo(id string, full bool) (*BugInfo, error) {
@@ -275,6 +294,10 @@ func (cs *CrashStore) BugInfo(id string, full bool) (*BugInfo, error) {
 	sort.Slice(ret.Crashes, func(i, j int) bool {
 		return ret.Crashes[i].Time.After(ret.Crashes[j].Time)
 	})
+	if sigs := cs.crashSignatures(id); sigs != nil {
+		cs.groupCrashes(ret, sigs)
+		ret.Related = cs.relatedBugs(id, sigs)
+	}
 	return ret, nil
 }
 
@@ -0,0 +1,345 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/report"
+	"github.com/google/syzkaller/pkg/rpcserver"
+	"github.com/google/syzkaller/prog"
+)
+
+// CrashSignature summarizes a crash to find related crashes: crashes with different titles
+// caused by the same root bug, or crashes with the same title caused by different bugs.
+type CrashSignature struct {
+	// MinHash sketch of the signal of the programs executed shortly before the crash.
+	Cover []uint64 `json:"cover,omitempty"`
+	// System calls of the programs that were still executing at the time of the crash
+	// (there is no signal for them). Only compared if one of the signatures has no signal.
+	Calls []string `json:"calls,omitempty"`
+	// Functions from the crash stack.
+	Frames []string `json:"frames,omitempty"`
+}
+
+const (
+	// The number of hashes in the MinHash sketch.
+	signatureHashes = 64
+	// The number of the top stack frames considered.
+	signatureFrames = 16
+	// Crashes with signature similarity at least that much are considered related.
+	RelatedCrashThreshold = 0.5
+)
+
+var stackFrameRe = regexp.MustCompile(`(?m)^\s*(?:\[[^\]]*\]\s*)?(?:\? )?` +
+	`([a-zA-Z_][a-zA-Z0-9_.]*)\+0x[0-9a-f]+/0x[0-9a-f]+`)
+
+// NewCrashSignature calculates signature of the crash from the crash report and the programs
+// executed in the VM before the crash together with their signal (see rpcserver.LastExecuting).
+// Returns nil if there is not enough information for a signature.
+func NewCrashSignature(target *prog.Target, rep *report.Report, lastExec []rpcserver.ExecRecord) *CrashSignature {
+	sig := &CrashSignature{}
+	seen := make(map[uint64]bool)
+	calls := make(map[string]bool)
+	for _, rec := range lastExec {
+		if len(rec.Signal) == 0 {
+			p, err := target.Deserialize(rec.Prog, prog.NonStrict)
+			if err != nil {
+				continue
+			}
+			for _, call := range p.Calls {
+				calls[call.Meta.Name] = true
+			}
+			continue
+		}
+		for _, elem := range rec.Signal {
+			if seen[elem] {
+				continue
+			}
+			seen[elem] = true
+			if sig.Cover == nil {
+				sig.Cover = make([]uint64, signatureHashes)
+				for i := range sig.Cover {
+					sig.Cover[i] = ^uint64(0)
+				}
+			}
+			for i := range sig.Cover {
+				sig.Cover[i] = min(sig.Cover[i], mix64(elem^signatureSeed(i)))
+			}
+		}
+	}
+	for call := range calls {
+		sig.Calls = append(sig.Calls, call)
+	}
+	sort.Strings(sig.Calls)
+	frames := make(map[string]bool)
+	for _, match := range stackFrameRe.FindAllSubmatch(rep.Report, -1) {
+		frame := string(match[1])
+		if frames[frame] {
+			continue
+		}
+		frames[frame] = true
+		sig.Frames = append(sig.Frames, frame)
+		if len(sig.Frames) == signatureFrames {
+			break
+		}
+	}
+	if sig.Cover == nil && sig.Calls == nil && sig.Frames == nil {
+		return nil
+	}
+	return sig
+}
+
+// Similarity returns similarity of the signatures in the [0, 1] range.
+// It's the average of the estimated Jaccard index of the signal and the Jaccard index
+// of the stack frames (for the parts that are present in both signatures).
+// Executing calls are compared instead of the signal only if one of the signatures has no signal,
+// since unrelated crashes frequently share common calls.
+func (sig *CrashSignature) Similarity(other *CrashSignature) float64 {
+	if sig == nil || other == nil {
+		return 0
+	}
+	var sum float64
+	parts := 0
+	if len(sig.Cover) == signatureHashes && len(other.Cover) == signatureHashes {
+		same := 0
+		for i := range sig.Cover {
+			if sig.Cover[i] == other.Cover[i] {
+				same++
+			}
+		}
+		sum += float64(same) / signatureHashes
+		parts++
+	} else if len(sig.Calls) != 0 && len(other.Calls) != 0 {
+		sum += jaccardIndex(sig.Calls, other.Calls)
+		parts++
+	}
+	if len(sig.Frames) != 0 && len(other.Frames) != 0 {
+		sum += jaccardIndex(sig.Frames, other.Frames)
+		parts++
+	}
+	if parts == 0 {
+		return 0
+	}
+	return sum / float64(parts)
+}
+
+// jaccardIndex returns the Jaccard index of two sets represented by slices without duplicates.
+func jaccardIndex(a, b []string) float64 {
+	set := make(map[string]bool)
+	for _, elem := range a {
+		set[elem] = true
+	}
+	common := 0
+	for _, elem := range b {
+		if set[elem] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+func signatureSeed(i int) uint64 {
+	return mix64(uint64(i) + 0x9e3779b97f4a7c15)
+}
+
+// mix64 is the splitmix64 finalizer.
+func mix64(v uint64) uint64 {
+	v ^= v >> 30
+	v *= 0xbf58476d1ce4e5b9
+	v ^= v >> 27
+	v *= 0x94d049bb133111eb
+	v ^= v >> 31
+	return v
+}
+
+type RelatedBug struct {
+	ID         string
+	Title      string
+	Similarity float64
+}
+
+type bugSignatures struct {
+	title   string
+	crashes map[int]*CrashSignature
+}
+
+// loadSignatures returns signatures of the stored crashes by bug ID.
+// The signatures are read from the disk on the first use, and then kept up-to-date by SaveCrash.
+// Must be called with sigMu held.
+func (cs *CrashStore) loadSignatures() map[string]*bugSignatures {
+	if cs.sigs != nil {
+		return cs.sigs
+	}
+	cs.sigs = make(map[string]*bugSignatures)
+	dirs, _ := osutil.ListDir(filepath.Join(cs.BaseDir, "crashes"))
+	for _, id := range dirs {
+		if bug := readBugSignatures(filepath.Join(cs.BaseDir, "crashes", id)); bug != nil {
+			cs.sigs[id] = bug
+		}
+	}
+	return cs.sigs
+}
+
+func readBugSignatures(dir string) *bugSignatures {
+	desc, err := os.ReadFile(filepath.Join(dir, "description"))
+	if err != nil {
+		return nil
+	}
+	files, err := osutil.ListDir(dir)
+	if err != nil {
+		return nil
+	}
+	ret := &bugSignatures{
+		title:   strings.TrimSpace(string(desc)),
+		crashes: make(map[int]*CrashSignature),
+	}
+	for _, f := range files {
+		if !strings.HasPrefix(f, "signature") {
+			continue
+		}
+		index, err := strconv.Atoi(f[len("signature"):])
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f))
+		if err != nil {
+			continue
+		}
+		sig := new(CrashSignature)
+		if err := json.Unmarshal(data, sig); err != nil {
+			continue
+		}
+		ret.crashes[index] = sig
+	}
+	if len(ret.crashes) == 0 {
+		return nil
+	}
+	return ret
+}
+
+// crashSignatures returns signatures of the stored crashes of the bug by crash index.
+func (cs *CrashStore) crashSignatures(id string) map[int]*CrashSignature {
+	cs.sigMu.Lock()
+	defer cs.sigMu.Unlock()
+	bug := cs.loadSignatures()[id]
+	if bug == nil {
+		return nil
+	}
+	ret := make(map[int]*CrashSignature, len(bug.crashes))
+	for index, sig := range bug.crashes {
+		ret[index] = sig
+	}
+	return ret
+}
+
+// saveSignature updates the cached signatures after the crash with the index was written by SaveCrash.
+func (cs *CrashStore) saveSignature(title string, index int, sig *CrashSignature) {
+	cs.sigMu.Lock()
+	defer cs.sigMu.Unlock()
+	sigs := cs.loadSignatures()
+	id := crashHash(title)
+	bug := sigs[id]
+	if sig == nil {
+		if bug != nil {
+			delete(bug.crashes, index)
+		}
+		return
+	}
+	if bug == nil {
+		bug = &bugSignatures{
+			title:   title,
+			crashes: make(map[int]*CrashSignature),
+		}
+		sigs[id] = bug
+	}
+	bug.crashes[index] = sig
+}
+
+// groupCrashes splits crashes of the bug into groups of related crashes (numbered from 1).
+// Crashes without signatures get group 0. Several groups mean that the title may mask several bugs.
+func (cs *CrashStore) groupCrashes(info *BugInfo, sigs map[int]*CrashSignature) {
+	var indices []int
+	for _, crash := range info.Crashes {
+		if sigs[crash.Index] != nil {
+			indices = append(indices, crash.Index)
+		}
+	}
+	sort.Ints(indices)
+	// Single-linkage clustering with union-find.
+	parent := make(map[int]int)
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for _, i := range indices {
+		parent[i] = i
+	}
+	for x, i := range indices {
+		for _, j := range indices[x+1:] {
+			if sigs[i].Similarity(sigs[j]) >= RelatedCrashThreshold {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+	groups := make(map[int]int)
+	for _, i := range indices {
+		root := find(i)
+		if groups[root] == 0 {
+			groups[root] = len(groups) + 1
+		}
+	}
+	for _, crash := range info.Crashes {
+		if sigs[crash.Index] != nil {
+			crash.Group = groups[find(crash.Index)]
+		}
+	}
+	info.Groups = len(groups)
+}
+
+// relatedBugs returns other bugs that have crashes similar to the given crashes.
+func (cs *CrashStore) relatedBugs(id string, sigs map[int]*CrashSignature) []RelatedBug {
+	if len(sigs) == 0 {
+		return nil
+	}
+	cs.sigMu.Lock()
+	defer cs.sigMu.Unlock()
+	var ret []RelatedBug
+	for otherID, other := range cs.loadSignatures() {
+		if otherID == id {
+			continue
+		}
+		best := 0.0
+		for _, otherSig := range other.crashes {
+			for _, sig := range sigs {
+				best = max(best, sig.Similarity(otherSig))
+			}
+		}
+		if best < RelatedCrashThreshold {
+			continue
+		}
+		ret = append(ret, RelatedBug{
+			ID:         otherID,
+			Title:      other.title,
+			Similarity: best,
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Similarity != ret[j].Similarity {
+			return ret[i].Similarity > ret[j].Similarity
+		}
+		return ret[i].Title < ret[j].Title
+	})
+	return ret
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/google/syzkaller/pkg/report"
+	"github.com/google/syzkaller/pkg/rpcserver"
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/sys/targets"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCrashReport = `BUG: KASAN: use-after-free in foo_release+0x12/0x40 net/foo.c:10
+Call Trace:
+ <TASK>
+ __dump_stack lib/dump_stack.c:88 [inline]
+ dump_stack_lvl+0x1b/0x20 lib/dump_stack.c:106
+ foo_release+0x12/0x40 net/foo.c:10
+ ? bar_close+0x5/0x10
+ [  12.345] __fput+0x100/0x200 fs/file_table.c:300
+ </TASK>
+`
+
+func signalRange(from, to int) []rpcserver.ExecRecord {
+	var signal []uint64
+	for i := from; i < to; i++ {
+		signal = append(signal, uint64(i))
+	}
+	return []rpcserver.ExecRecord{{Prog: []byte("test()\n"), Signal: signal}}
+}
+
+func testTarget(t *testing.T) *prog.Target {
+	target, err := prog.GetTarget(targets.TestOS, targets.TestArch64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return target
+}
+
+func TestCrashSignature(t *testing.T) {
+	target := testTarget(t)
+	rep := &report.Report{Report: []byte(testCrashReport)}
+	sig := NewCrashSignature(target, rep, nil)
+	assert.Equal(t, []string{"dump_stack_lvl", "foo_release", "bar_close", "__fput"}, sig.Frames)
+	assert.Nil(t, sig.Cover)
+	assert.Nil(t, NewCrashSignature(target, &report.Report{}, nil))
+
+	a := NewCrashSignature(target, &report.Report{}, signalRange(0, 1000))
+	b := NewCrashSignature(target, &report.Report{}, signalRange(100, 1100))
+	c := NewCrashSignature(target, &report.Report{}, signalRange(5000, 6000))
+	assert.Equal(t, 1.0, a.Similarity(a))
+	assert.InDelta(t, 0.8, a.Similarity(b), 0.15)
+	assert.Less(t, a.Similarity(c), 0.1)
+	// The stack is the same, but the coverage is different.
+	a.Frames = sig.Frames
+	c.Frames = sig.Frames
+	assert.InDelta(t, 0.5, a.Similarity(c), 0.05)
+	assert.Equal(t, 0.0, a.Similarity(nil))
+
+	// Programs without signal were still executing, only their calls are used.
+	executing := []rpcserver.ExecRecord{
+		{Prog: []byte("test$int(0x1, 0x2, 0x3, 0x4, 0x5)\ntest()\n")},
+		{Prog: []byte("test$str0(&(0x7f0000000000)='foo\\x00')\n")},
+		{Prog: []byte("garbage")},
+	}
+	x := NewCrashSignature(target, &report.Report{}, executing)
+	assert.Nil(t, x.Cover)
+	assert.Equal(t, []string{"test", "test$int", "test$str0"}, x.Calls)
+	y := NewCrashSignature(target, &report.Report{}, append(signalRange(0, 1000), executing[0]))
+	assert.Equal(t, []string{"test", "test$int"}, y.Calls)
+	// Calls are compared only if there is no signal on one of the sides.
+	assert.InDelta(t, 2.0/3, x.Similarity(y), 0.01)
+	assert.Equal(t, 1.0, a.Similarity(y))
+}
+
+func TestCrashStoreRelated(t *testing.T) {
+	target := testTarget(t)
+	crashStore := &CrashStore{
+		BaseDir:      t.TempDir(),
+		MaxCrashLogs: 10,
+	}
+	save := func(title string, sig *CrashSignature) {
+		_, err := crashStore.SaveCrash(&Crash{
+			Signature: sig,
+			Report:    &report.Report{Title: title, Output: []byte("log")},
+		})
+		assert.NoError(t, err)
+	}
+	sigA := NewCrashSignature(target, &report.Report{}, signalRange(0, 1000))
+	sigB := NewCrashSignature(target, &report.Report{}, signalRange(5000, 6000))
+	save("title 1", sigA)
+	save("title 1", sigB)
+	save("title 1", nil)
+	save("title 2", sigA)
+	save("title 3", sigB)
+	save("title 4", NewCrashSignature(target, &report.Report{}, signalRange(10000, 11000)))
+
+	info, err := crashStore.BugInfo(crashHash("title 1"), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, info.Groups)
+	groups := map[int]int{}
+	for _, crash := range info.Crashes {
+		groups[crash.Index] = crash.Group
+	}
+	assert.Equal(t, map[int]int{0: 1, 1: 2, 2: 0}, groups)
+	var related []string
+	for _, bug := range info.Related {
+		related = append(related, bug.Title)
+		assert.Equal(t, 1.0, bug.Similarity)
+	}
+	assert.Equal(t, []string{"title 2", "title 3"}, related)
+
+	info, err = crashStore.BugInfo(crashHash("title 4"), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, info.Groups)
+	assert.Empty(t, info.Related)
+
+	// The cached signatures are updated once a new crash is saved.
+	save("title 4", sigA)
+	info, err = crashStore.BugInfo(crashHash("title 1"), true)
+	assert.NoError(t, err)
+	related = nil
+	for _, bug := range info.Related {
+		related = append(related, bug.Title)
+	}
+	assert.Equal(t, []string{"title 2", "title 3", "title 4"}, related)
+
+	// A new store lazily loads the saved signatures.
+	info, err = ReadCrashStore(crashStore.BaseDir).BugInfo(crashHash("title 1"), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, info.Groups)
+	assert.Len(t, info.Related, 3)
+}
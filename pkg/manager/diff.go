@@ -83,6 +83,7 @@ func RunDiffFuzzer(ctx context.Context, baseCfg, newCfg *mgrconfig.Config, cfg D
 	stream := queue.NewRandomQueue(4096, rand.New(rand.NewSource(time.Now().UnixNano())))
 	base.source = stream
 	new.duplicateInto = stream
+	new.patchTest = true
 
 	diffCtx := &diffContext{
 		cfg:           cfg,
@@ -164,9 +165,9 @@ loop:
 			}
 			data, _ := json.MarshalIndent(vals, "", "  ")
 			log.Logf(0, "STAT %s", data)
-		case rep := <-dc.base.crashes:
-			log.Logf(1, "base crash: %v", rep.Title)
-			dc.store.BaseCrashed(rep.Title, rep.Report)
+		case crash := <-dc.base.crashes:
+			log.Logf(1, "base crash: %v", crash.Title)
+			dc.store.BaseCrashed(crash.Title, crash.Report.Report)
 		case ret := <-runner.done:
 			// We have run the reproducer on the base instance.
 
@@ -208,13 +209,12 @@ loop:
 				log.Logf(1, "failed repro for %q, err=%s", origTitle, ret.Err)
 			}
 			dc.store.SaveRepro(ret)
-		case rep := <-dc.new.crashes:
+		case crash := <-dc.new.crashes:
 			// A new crash is found on the patched instance.
-			crash := &Crash{Report: rep}
 			need := dc.NeedRepro(crash)
 			log.Logf(0, "patched crashed: %v [need repro = %v]",
-				rep.Title, need)
-			dc.store.PatchedCrashed(rep.Title, rep.Report, rep.Output)
+				crash.Title, need)
+			dc.store.PatchedCrashed(crash.Title, crash.Report.Report, crash.Output, crash.Signature)
 			if need {
 				reproLoop.Enqueue(crash)
 			}
@@ -285,6 +285,7 @@ func (dc *diffContext) RunRepro(ctx context.Context, crash *Crash) *ReproResult
 		Reporter: dc.new.reporter,
 		Pool:     dc.new.pool,
 		Fast:     true,
+		Snapshot: dc.new.cfg.Experimental.SnapshotRepro,
 	})
 	if res != nil && res.Report != nil {
 		dc.mu.Lock()
@@ -318,7 +319,7 @@ type kernelContext struct {
 	fuzzer     atomic.Pointer[fuzzer.Fuzzer]
 	serv       rpcserver.Server
 	servStats  rpcserver.Stats
-	crashes    chan *report.Report
+	crashes    chan *Crash
 	pool       *vm.Dispatcher
 	features   flatrpc.Feature
 	candidates chan []fuzzer.Candidate
@@ -326,11 +327,14 @@ type kernelContext struct {
 	candidatesCount atomic.Int64
 
 	coverFilters    CoverageFilters
+	distances       map[uint64]uint32
 	reportGenerator *ReportGeneratorWrapper
 
 	http          *HTTPServer
+	corpusUpdates chan corpus.NewItemEvent
 	source        queue.Source
 	duplicateInto queue.Executor
+	patchTest     bool
 }
 
 func setup(ctx context.Context, name string, cfg *mgrconfig.Config, debug bool) (*kernelContext, error) {
@@ -341,7 +345,7 @@ func setup(ctx context.Context, name string, cfg *mgrconfig.Config, debug bool)
 		debug:           debug,
 		ctx:             ctx,
 		cfg:             cfg,
-		crashes:         make(chan *report.Report, 128),
+		crashes:         make(chan *Crash, 128),
 		candidates:      make(chan []fuzzer.Candidate),
 		servStats:       rpcserver.NewNamedStats(name),
 		reportGenerator: ReportGeneratorCache(cfg),
@@ -410,7 +414,14 @@ func (kc *kernelContext) MachineChecked(features flatrpc.Feature,
 
 	var source queue.Source
 	if kc.source == nil {
-		source = queue.Tee(kc.setupFuzzer(features, syscalls), kc.duplicateInto)
+		var err error
+		source, err = kc.setupFuzzer(features, syscalls)
+		if err != nil {
+			return nil, err
+		}
+		if kc.duplicateInto != nil {
+			source = queue.Tee(source, kc.duplicateInto)
+		}
 	} else {
 		source = kc.source
 	}
@@ -418,19 +429,36 @@ func (kc *kernelContext) MachineChecked(features flatrpc.Feature,
 	return queue.DefaultOpts(source, opts), nil
 }
 
-func (kc *kernelContext) setupFuzzer(features flatrpc.Feature, syscalls map[*prog.Syscall]bool) queue.Source {
+func (kc *kernelContext) setupFuzzer(features flatrpc.Feature,
+	syscalls map[*prog.Syscall]bool) (queue.Source, error) {
+	var budget *fuzzer.ExecBudget
+	if kc.cfg.Experimental.ExecBudget {
+		var err error
+		budget, err = fuzzer.ParseExecBudget(kc.patchTest, kc.cfg.Experimental.ExecShares,
+			kc.cfg.Experimental.ExecDeadlines)
+		if err != nil {
+			return nil, err
+		}
+	}
 	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
-	corpusObj := corpus.NewFocusedCorpus(kc.ctx, nil, kc.coverFilters.Areas)
+	corpusObj := corpus.NewFocusedCorpus(kc.ctx, kc.corpusUpdates, kc.coverFilters.Areas)
+	if kc.distances != nil {
+		corpusObj.SetDistances(kc.distances)
+	}
 	fuzzerObj := fuzzer.NewFuzzer(kc.ctx, &fuzzer.Config{
 		Corpus:   corpusObj,
 		Coverage: kc.cfg.Cover,
 		// Fault injection may bring instaibility into bug reproducibility, which may lead to false positives.
-		FaultInjection: false,
-		Comparisons:    features&flatrpc.FeatureComparisons != 0,
-		Collide:        true,
-		EnabledCalls:   syscalls,
-		NoMutateCalls:  kc.cfg.NoMutateCalls,
-		PatchTest:      true,
+		FaultInjection:    false,
+		Comparisons:       features&flatrpc.FeatureComparisons != 0,
+		Collide:           true,
+		EnabledCalls:      syscalls,
+		NoMutateCalls:     kc.cfg.NoMutateCalls,
+		PatchTest:         kc.patchTest,
+		AdaptiveMutations: kc.cfg.Experimental.AdaptiveMutations,
+		Crossover:         kc.cfg.Experimental.Crossover,
+		HintsTaint:        kc.cfg.Experimental.HintsTaint,
+		ExecBudget:        budget,
 		Logf: func(level int, msg string, args ...interface{}) {
 			if level != 0 {
 				return
@@ -477,7 +505,7 @@ func (kc *kernelContext) setupFuzzer(features flatrpc.Feature, syscalls map[*pro
 			kc.serv.DistributeSignalDelta(newSignal)
 		}
 	}()
-	return fuzzerObj
+	return fuzzerObj, nil
 }
 
 func (kc *kernelContext) CoverageFilter(modules []*vminfo.KernelModule) ([]uint64, error) {
@@ -488,6 +516,10 @@ func (kc *kernelContext) CoverageFilter(modules []*vminfo.KernelModule) ([]uint6
 	}
 	kc.coverFilters = filters
 	log.Logf(0, "cover filter size: %d", len(filters.ExecutorFilter))
+	kc.distances, err = DirectedDistances(kc.reportGenerator, kc.cfg, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate directed fuzzing distances: %w", err)
+	}
 	if kc.http != nil {
 		kc.http.Cover.Store(&CoverageInfo{
 			Modules:         modules,
@@ -510,8 +542,13 @@ func (kc *kernelContext) fuzzerInstance(ctx context.Context, inst *vm.Instance,
 	lastExec, _ := kc.serv.ShutdownInstance(index, rep != nil)
 	if rep != nil {
 		rpcserver.PrependExecuting(rep, lastExec)
+		crash := &Crash{
+			InstanceIndex: index,
+			Signature:     NewCrashSignature(kc.cfg.Target, rep, lastExec),
+			Report:        rep,
+		}
 		select {
-		case kc.crashes <- rep:
+		case kc.crashes <- crash:
 		case <-ctx.Done():
 		}
 	}
@@ -644,6 +681,8 @@ func PatchFocusAreas(cfg *mgrconfig.Config, gitPatches [][]byte) {
 	if len(direct) > 0 {
 		sort.Strings(direct)
 		log.Logf(0, "adding directly modified files to focus_order: %q", direct)
+		// Also direct fuzzing towards the modified code, even if the corpus does not reach it yet.
+		cfg.Experimental.DirectedTargets.Files = append(cfg.Experimental.DirectedTargets.Files, direct...)
 		cfg.Experimental.FocusAreas = append(cfg.Experimental.FocusAreas,
 			mgrconfig.FocusArea{
 				Name: "modified",
@@ -5,6 +5,7 @@ package manager
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"sort"
@@ -35,10 +36,11 @@ type DiffBugInfo struct {
 	NotCrashed bool // If were proven not to crash by running a repro.
 
 	// File paths.
-	Report   string
-	Repro    string
-	ReproLog string
-	CrashLog string
+	Report    string
+	Repro     string
+	ReproLog  string
+	CrashLog  string
+	Signature string
 }
 
 // DiffFuzzerStore provides the functionality of a database of the patch fuzzing.
@@ -73,7 +75,7 @@ func (s *DiffFuzzerStore) BaseNotCrashed(title string) {
 	})
 }
 
-func (s *DiffFuzzerStore) PatchedCrashed(title string, report, log []byte) {
+func (s *DiffFuzzerStore) PatchedCrashed(title string, report, log []byte, sig *CrashSignature) {
 	s.patch(title, func(obj *DiffBug) {
 		obj.Patched.Crashes++
 		if len(report) > 0 {
@@ -82,6 +84,10 @@ func (s *DiffFuzzerStore) PatchedCrashed(title string, report, log []byte) {
 		if len(log) > 0 && obj.Patched.CrashLog == "" {
 			obj.Patched.CrashLog = s.saveFile(title, "patched_crash_log", log)
 		}
+		if sig != nil && obj.Patched.Signature == "" {
+			data, _ := json.Marshal(sig)
+			obj.Patched.Signature = s.saveFile(title, "patched_signature", data)
+		}
 	})
 }
 
@@ -0,0 +1,88 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"fmt"
+
+	"github.com/google/syzkaller/pkg/cover/backend"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+)
+
+// DirectedDistances calculates call graph distances to the functions matching cfg.Experimental.DirectedTargets.
+// The result maps coverage PCs (as they are reported by KCOV) to the distance of the function
+// they belong to: 0 for the target functions, 1 for the functions that directly call them and so on.
+// PCs of functions that can't reach the targets are not included.
+// Returns nil if directed fuzzing is not configured, or if the targets don't match anything and strict is false.
+func DirectedDistances(source *ReportGeneratorWrapper, cfg *mgrconfig.Config,
+	strict bool) (map[uint64]uint32, error) {
+	if cfg.Experimental.DirectedTargets.Empty() {
+		return nil, nil
+	}
+	pcs, err := CoverageFilter(source, cfg.Experimental.DirectedTargets, strict)
+	if err != nil {
+		return nil, err
+	}
+	rg, err := source.Get()
+	if err != nil {
+		return nil, err
+	}
+	var targets []*backend.Symbol
+	for _, sym := range rg.Symbols {
+		for _, pc := range sym.PCs {
+			if _, ok := pcs[pc]; ok {
+				targets = append(targets, sym)
+				break
+			}
+		}
+	}
+	if len(targets) == 0 {
+		if strict {
+			return nil, fmt.Errorf("directed_targets don't match any kernel functions")
+		}
+		log.Logf(0, "directed fuzzing: the targets don't match any kernel functions")
+		return nil, nil
+	}
+	funcDistances := callGraphDistances(rg.Symbols, targets)
+	distances := make(map[uint64]uint32)
+	for sym, distance := range funcDistances {
+		for _, pc := range sym.PCs {
+			distances[backend.NextInstructionPC(cfg.SysTarget, cfg.Type, pc)] = distance
+		}
+	}
+	log.Logf(0, "directed fuzzing: %v target functions, %v functions can reach them",
+		len(targets), len(funcDistances))
+	return distances, nil
+}
+
+// callGraphDistances returns the minimal number of calls needed to reach any of the targets
+// from each function that can reach them.
+func callGraphDistances(symbols, targets []*backend.Symbol) map[*backend.Symbol]uint32 {
+	callers := make(map[*backend.Symbol][]*backend.Symbol)
+	for _, sym := range symbols {
+		for _, callee := range sym.Callees {
+			callers[callee] = append(callers[callee], sym)
+		}
+	}
+	distances := make(map[*backend.Symbol]uint32)
+	queue := make([]*backend.Symbol, 0, len(targets))
+	for _, sym := range targets {
+		if _, ok := distances[sym]; !ok {
+			distances[sym] = 0
+			queue = append(queue, sym)
+		}
+	}
+	for len(queue) != 0 {
+		sym := queue[0]
+		queue = queue[1:]
+		for _, caller := range callers[sym] {
+			if _, ok := distances[caller]; !ok {
+				distances[caller] = distances[sym] + 1
+				queue = append(queue, caller)
+			}
+		}
+	}
+	return distances
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/google/syzkaller/pkg/cover/backend"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallGraphDistances(t *testing.T) {
+	syms := make(map[string]*backend.Symbol)
+	var all []*backend.Symbol
+	for _, name := range []string{"entry", "middle", "target", "other", "unrelated"} {
+		sym := &backend.Symbol{ObjectUnit: backend.ObjectUnit{Name: name}}
+		syms[name] = sym
+		all = append(all, sym)
+	}
+	call := func(from string, to ...string) {
+		for _, name := range to {
+			syms[from].Callees = append(syms[from].Callees, syms[name])
+		}
+	}
+	call("entry", "middle", "other")
+	call("middle", "target")
+	call("other", "target", "unrelated")
+	call("target", "unrelated")
+
+	distances := callGraphDistances(all, []*backend.Symbol{syms["target"]})
+	got := make(map[string]uint32)
+	for sym, distance := range distances {
+		got[sym.Name] = distance
+	}
+	assert.Equal(t, map[string]uint32{
+		"target": 0,
+		"middle": 1,
+		"other":  1,
+		"entry":  2,
+	}, got)
+}
@@ -11,12 +11,14 @@ import (
 	"fmt"
 	"html/template"
 	"io"
+	"maps"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime/debug"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -26,6 +28,7 @@ import (
 	"github.com/google/syzkaller/pkg/corpus"
 	"github.com/google/syzkaller/pkg/cover"
 	"github.com/google/syzkaller/pkg/fuzzer"
+	"github.com/google/syzkaller/pkg/fuzzer/queue"
 	"github.com/google/syzkaller/pkg/html/pages"
 	"github.com/google/syzkaller/pkg/log"
 	"github.com/google/syzkaller/pkg/mgrconfig"
@@ -56,6 +59,12 @@ type HTTPServer struct {
 	Pool        *vm.Dispatcher
 	Pools       map[string]*vm.Dispatcher
 	TogglePause func(paused bool)
+	// Health of the VMs of the default pool.
+	ExecutorHealth func() []queue.ExecutorHealth
+	// Crashes of several kernels (see RunMultiFuzzer) keyed by the kernel name.
+	// Used instead of CrashStore and ReproLoop.
+	CrashStores map[string]*CrashStore
+	ReproLoops  map[string]*ReproLoop
 
 	// Can be set dynamically after calling Serve.
 	Corpus          atomic.Pointer[corpus.Corpus]
@@ -75,6 +84,10 @@ func (serv *HTTPServer) Serve(ctx context.Context) error {
 	if serv.Pool != nil {
 		serv.Pools = map[string]*vm.Dispatcher{"": serv.Pool}
 	}
+	if serv.CrashStore != nil {
+		serv.CrashStores = map[string]*CrashStore{"": serv.CrashStore}
+		serv.ReproLoops = map[string]*ReproLoop{"": serv.ReproLoop}
+	}
 	handle := func(pattern string, handler func(http.ResponseWriter, *http.Request)) {
 		http.Handle(pattern, handlers.CompressHandler(http.HandlerFunc(handler)))
 	}
@@ -106,7 +119,7 @@ func (serv *HTTPServer) Serve(ctx context.Context) error {
 	handle("/vm", serv.httpVM)
 	handle("/vms", serv.httpVMs)
 	// keep-sorted end
-	if serv.CrashStore != nil {
+	if len(serv.CrashStores) != 0 {
 		handle("/crash", serv.httpCrash)
 		handle("/report", serv.httpReport)
 	}
@@ -162,12 +175,13 @@ func (serv *HTTPServer) httpMain(w http.ResponseWriter, r *http.Request) {
 			Link:  stat.Link,
 		})
 	}
-	if serv.CrashStore != nil {
+	if len(serv.CrashStores) != 0 {
 		var err error
 		if data.Crashes, err = serv.collectCrashes(serv.Cfg.Workdir); err != nil {
 			http.Error(w, fmt.Sprintf("failed to collect crashes: %v", err), http.StatusInternalServerError)
 			return
 		}
+		data.MultiKernel = serv.CrashStore == nil
 	}
 	if serv.DiffStore != nil {
 		data.PatchedOnly, data.AffectsBoth, data.InProgress = serv.collectDiffCrashes()
@@ -282,6 +296,13 @@ func (serv *HTTPServer) httpVMs(w http.ResponseWriter, r *http.Request) {
 	data := &UIVMData{
 		UIPageHeader: serv.pageHeader(r, "VMs"),
 	}
+	health := make(map[int]queue.ExecutorHealth)
+	if r.FormValue("pool") == "" && serv.ExecutorHealth != nil {
+		for _, vm := range serv.ExecutorHealth() {
+			health[vm.VM] = vm
+		}
+		data.Health = true
+	}
 	// TODO: we could also query vmLoop for VMs that are idle (waiting to start reproducing),
 	// and query the exact bug that is being reproduced by a VM.
 	for id, state := range pool.State() {
@@ -310,6 +331,9 @@ func (serv *HTTPServer) httpVMs(w http.ResponseWriter, r *http.Request) {
 		if state.DetailedStatus != nil {
 			info.DetailedStatus = fmt.Sprintf("/vm?type=detailed-status&id=%v", id)
 		}
+		if vmHealth, ok := health[id]; ok {
+			info.Health = &vmHealth
+		}
 		data.VMs = append(data.VMs, info)
 	}
 	executeTemplate(w, vmsTemplate, data)
@@ -344,7 +368,7 @@ func (serv *HTTPServer) httpVM(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func makeUICrashType(info *BugInfo, startTime time.Time, repros map[string]bool) UICrashType {
+func makeUICrashType(info *BugInfo, kernel string, startTime time.Time, repros map[string]bool) UICrashType {
 	var crashes []UICrash
 	for _, crash := range info.Crashes {
 		crashes = append(crashes, UICrash{
@@ -356,6 +380,7 @@ func makeUICrashType(info *BugInfo, startTime time.Time, repros map[string]bool)
 		info.ReproAttempts >= MaxReproAttempts)
 	return UICrashType{
 		Description: info.Title,
+		Kernel:      kernel,
 		FirstTime:   info.FirstTime,
 		LastTime:    info.LastTime,
 		New:         info.FirstTime.After(startTime),
@@ -365,6 +390,8 @@ func makeUICrashType(info *BugInfo, startTime time.Time, repros map[string]bool)
 		Triaged:     triaged,
 		Strace:      info.StraceFile,
 		Crashes:     crashes,
+		Groups:      info.Groups,
+		Related:     info.Related,
 	}
 }
 
@@ -376,14 +403,20 @@ func (serv *HTTPServer) httpCrash(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid crash ID", http.StatusBadRequest)
 		return
 	}
-	info, err := serv.CrashStore.BugInfo(crashID, true)
+	kernel := r.FormValue("kernel")
+	store := serv.CrashStores[kernel]
+	if store == nil {
+		http.Error(w, "unknown kernel", http.StatusBadRequest)
+		return
+	}
+	info, err := store.BugInfo(crashID, true)
 	if err != nil {
 		http.Error(w, "failed to read crash info", http.StatusInternalServerError)
 		return
 	}
 	data := UICrashPage{
 		UIPageHeader: serv.pageHeader(r, info.Title),
-		UICrashType:  makeUICrashType(info, serv.StartTime, nil),
+		UICrashType:  makeUICrashType(info, kernel, serv.StartTime, nil),
 	}
 	executeTemplate(w, crashTemplate, data)
 }
@@ -398,16 +431,25 @@ func (serv *HTTPServer) httpCorpus(w http.ResponseWriter, r *http.Request) {
 		UIPageHeader: serv.pageHeader(r, "corpus"),
 		Call:         r.FormValue("call"),
 		RawCover:     serv.Cfg.RawCover,
+		Schedule:     corpus.Schedule().String(),
+		Directed:     corpus.Directed(),
 	}
 	for _, inp := range corpus.Items() {
 		if data.Call != "" && data.Call != inp.StringCall() {
 			continue
 		}
-		data.Inputs = append(data.Inputs, UIInput{
-			Sig:   inp.Sig,
-			Short: inp.Prog.String(),
-			Cover: len(inp.Cover),
-		})
+		uiInput := UIInput{
+			Sig:       inp.Sig,
+			Short:     inp.Prog.String(),
+			Cover:     len(inp.Cover),
+			Mutations: inp.Mutations(),
+			NewSignal: inp.NewSignalMutations(),
+			Distance:  -1,
+		}
+		if distance, ok := inp.Distance(); ok {
+			uiInput.Distance = int(distance)
+		}
+		data.Inputs = append(data.Inputs, uiInput)
 	}
 	sort.Slice(data.Inputs, func(i, j int) bool {
 		a, b := data.Inputs[i], data.Inputs[j]
@@ -667,14 +709,22 @@ func (serv *HTTPServer) httpPrio(w http.ResponseWriter, r *http.Request) {
 		progs = append(progs, inp.Prog)
 	}
 
-	prios := serv.Cfg.Target.CalculatePriorities(progs)
+	var transitions prog.CallTransitions
+	if fuzzer := serv.Fuzzer.Load(); fuzzer != nil {
+		transitions = fuzzer.CallTransitions()
+	}
+	prios := serv.Cfg.Target.CalculateLearnedPriorities(progs, transitions)
 
 	data := &UIPrioData{
 		UIPageHeader: serv.pageHeader(r, "syscall priorities"),
 		Call:         callName,
 	}
 	for i, p := range prios[call.ID] {
-		data.Prios = append(data.Prios, UIPrio{serv.Cfg.Target.Syscalls[i].Name, p})
+		data.Prios = append(data.Prios, UIPrio{
+			Call:        serv.Cfg.Target.Syscalls[i].Name,
+			Prio:        p,
+			Transitions: transitions[call.ID][i],
+		})
 	}
 	sort.Slice(data.Prios, func(i, j int) bool {
 		return data.Prios[i].Prio > data.Prios[j].Prio
@@ -688,7 +738,16 @@ func (serv *HTTPServer) httpFile(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "oh, oh, oh!", http.StatusInternalServerError)
 		return
 	}
-	data, err := os.ReadFile(filepath.Join(serv.Cfg.Workdir, file))
+	workdir := serv.Cfg.Workdir
+	if kernel := r.FormValue("kernel"); kernel != "" {
+		store := serv.CrashStores[kernel]
+		if store == nil {
+			http.Error(w, "unknown kernel", http.StatusBadRequest)
+			return
+		}
+		workdir = store.BaseDir
+	}
+	data, err := os.ReadFile(filepath.Join(workdir, file))
 	if err != nil {
 		http.Error(w, "failed to read the file", http.StatusInternalServerError)
 		return
@@ -820,7 +879,12 @@ func (serv *HTTPServer) httpReport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	info, err := serv.CrashStore.Report(crashID)
+	store := serv.CrashStores[r.FormValue("kernel")]
+	if store == nil {
+		http.Error(w, "unknown kernel", http.StatusBadRequest)
+		return
+	}
+	info, err := store.Report(crashID)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("%v", err), http.StatusBadRequest)
 		return
@@ -899,14 +963,19 @@ func (serv *HTTPServer) allDiffCrashes() []UIDiffBug {
 }
 
 func (serv *HTTPServer) collectCrashes(workdir string) ([]UICrashType, error) {
-	list, err := serv.CrashStore.BugList()
-	if err != nil {
-		return nil, err
-	}
-	repros := serv.ReproLoop.Reproducing()
 	var ret []UICrashType
-	for _, info := range list {
-		ret = append(ret, makeUICrashType(info, serv.StartTime, repros))
+	for _, kernel := range slices.Sorted(maps.Keys(serv.CrashStores)) {
+		list, err := serv.CrashStores[kernel].BugList()
+		if err != nil {
+			return nil, err
+		}
+		var repros map[string]bool
+		if loop := serv.ReproLoops[kernel]; loop != nil {
+			repros = loop.Reproducing()
+		}
+		for _, info := range list {
+			ret = append(ret, makeUICrashType(info, kernel, serv.StartTime, repros))
+		}
 	}
 	return ret, nil
 }
@@ -934,6 +1003,7 @@ func (serv *HTTPServer) httpJobs(w http.ResponseWriter, r *http.Request) {
 	case "triage":
 	case "smash":
 	case "hints":
+	case "fault":
 	default:
 		http.Error(w, "unknown job type", http.StatusBadRequest)
 		return
@@ -985,6 +1055,7 @@ type UISummaryData struct {
 	UIPageHeader
 	Stats       []UIStat
 	Crashes     []UICrashType
+	MultiKernel bool
 	PatchedOnly *UIDiffTable
 	AffectsBoth *UIDiffTable
 	InProgress  *UIDiffTable
@@ -998,7 +1069,8 @@ type UIDiffTable struct {
 
 type UIVMData struct {
 	UIPageHeader
-	VMs []UIVMInfo
+	VMs    []UIVMInfo
+	Health bool
 }
 
 type UIVMInfo struct {
@@ -1007,6 +1079,7 @@ type UIVMInfo struct {
 	Since          time.Duration
 	MachineInfo    string
 	DetailedStatus string
+	Health         *queue.ExecutorHealth
 }
 
 type UISyscallsData struct {
@@ -1021,6 +1094,7 @@ type UICrashPage struct {
 
 type UICrashType struct {
 	Description string
+	Kernel      string // set only if several kernels are fuzzed
 	FirstTime   time.Time
 	LastTime    time.Time
 	New         bool // was first found in the current run
@@ -1030,6 +1104,8 @@ type UICrashType struct {
 	Triaged     string
 	Strace      string
 	Crashes     []UICrash
+	Groups      int
+	Related     []RelatedBug
 }
 
 type UICrash struct {
@@ -1063,13 +1139,18 @@ type UICorpusPage struct {
 	UIPageHeader
 	Call     string
 	RawCover bool
+	Schedule string
+	Directed bool
 	Inputs   []UIInput
 }
 
 type UIInput struct {
-	Sig   string
-	Short string
-	Cover int
+	Sig       string
+	Short     string
+	Cover     int
+	Mutations uint64
+	NewSignal uint64
+	Distance  int // -1 if the program does not reach the directed fuzzing targets
 }
 
 type UIPageHeader struct {
@@ -1131,6 +1212,8 @@ type UIPrioData struct {
 type UIPrio struct {
 	Call string
 	Prio int32
+	// The number of times the call has given new signal after the selected call.
+	Transitions int32
 }
 
 type UIFallbackCoverData struct {
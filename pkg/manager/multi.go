@@ -0,0 +1,383 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/syzkaller/pkg/corpus"
+	"github.com/google/syzkaller/pkg/csource"
+	"github.com/google/syzkaller/pkg/db"
+	"github.com/google/syzkaller/pkg/fuzzer"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/report"
+	"github.com/google/syzkaller/pkg/repro"
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/vm"
+	"github.com/google/syzkaller/vm/dispatcher"
+	"golang.org/x/sync/errgroup"
+)
+
+// KernelConfig describes one of the kernels fuzzed by RunMultiFuzzer.
+type KernelConfig struct {
+	// Short name used in logs, stats and crash attribution (e.g. "stable-6.6").
+	Name   string
+	Config *mgrconfig.Config
+}
+
+type MultiFuzzerConfig struct {
+	Debug bool
+	// The total number of VMs that may run at once across all kernels.
+	// If 0, each kernel runs all VMs from its config.
+	VMs int
+	// If set, all crashes are sent to the channel.
+	// Regardless of that, crashes are saved in the workdir of the crashed kernel.
+	Crashes chan *KernelCrash
+}
+
+type KernelCrash struct {
+	// Name of the crashed kernel.
+	Kernel string
+	Report *report.Report
+	// Other kernels that have previously crashed with the same title.
+	AlsoCrashed []string
+}
+
+// RunMultiFuzzer fuzzes several kernels (e.g. several stable branches or config variants) at once.
+// It generalizes the diff fuzzer to N kernels that share one corpus and one VM budget.
+// Each kernel runs its own fuzzer. The corpus is stored in the workdir of the first kernel and
+// seeds all kernels. A program that brings new coverage on one kernel is saved to the shared corpus
+// and is cross-executed on all other kernels as a candidate, so it also enters their corpora
+// if it's interesting there. Crashes are attributed to and reproduced on the crashed kernel,
+// and are saved in its workdir. The HTTP server of the first kernel (if configured) shows
+// the crashes of all kernels.
+func RunMultiFuzzer(ctx context.Context, kernels []KernelConfig, cfg MultiFuzzerConfig) error {
+	if len(kernels) == 0 {
+		return fmt.Errorf("no kernels to fuzz")
+	}
+	names := map[string]bool{}
+	workdirs := map[string]bool{}
+	for _, kernel := range kernels {
+		if kernel.Name == "" || names[kernel.Name] {
+			return fmt.Errorf("kernel names must be non-empty and unique, got %q", kernel.Name)
+		}
+		if workdirs[kernel.Config.Workdir] {
+			return fmt.Errorf("kernel %v: workdir %v is used by another kernel", kernel.Name, kernel.Config.Workdir)
+		}
+		if kernel.Config.Target != kernels[0].Config.Target {
+			return fmt.Errorf("kernel %v: target %v differs from %v", kernel.Name,
+				kernel.Config.Target, kernels[0].Config.Target)
+		}
+		names[kernel.Name] = true
+		workdirs[kernel.Config.Workdir] = true
+	}
+	mc := &multiContext{
+		cfg:     cfg,
+		target:  kernels[0].Config.Target,
+		crashed: map[string]map[string]bool{},
+		pending: make([][]fuzzer.Candidate, len(kernels)),
+	}
+	var budget *dispatcher.Budget
+	if cfg.VMs != 0 {
+		budget = dispatcher.NewBudget(cfg.VMs)
+	}
+	for _, kernel := range kernels {
+		kc, err := setup(ctx, kernel.Name, kernel.Config, cfg.Debug)
+		if err != nil {
+			return err
+		}
+		kc.corpusUpdates = make(chan corpus.NewItemEvent, 128)
+		if budget != nil {
+			kc.pool.SetBudget(budget)
+		}
+		mr := &multiRepro{
+			kernel: kc,
+			store:  NewCrashStore(kernel.Config),
+		}
+		mr.loop = NewReproLoop(mr, kc.pool.Total()-kernel.Config.FuzzingVMs, false)
+		mc.kernels = append(mc.kernels, kc)
+		mc.repros = append(mc.repros, mr)
+	}
+	primary := mc.kernels[0]
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		info, err := LoadSeeds(primary.cfg, false)
+		if err != nil {
+			return err
+		}
+		mc.mu.Lock()
+		mc.corpusDB = info.CorpusDB
+		mc.mu.Unlock()
+		for _, kc := range mc.kernels {
+			// Each kernel filters the candidates in place.
+			candidates := cloneCandidates(info.Candidates)
+			eg.Go(func() error {
+				select {
+				case kc.candidates <- candidates:
+				case <-ctx.Done():
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if primary.cfg.HTTP != "" {
+		mc.http = &HTTPServer{
+			Cfg:         primary.cfg,
+			StartTime:   time.Now(),
+			CrashStores: map[string]*CrashStore{},
+			ReproLoops:  map[string]*ReproLoop{},
+			Pools:       map[string]*vm.Dispatcher{},
+		}
+		for i, kc := range mc.kernels {
+			mc.http.CrashStores[kc.name] = mc.repros[i].store
+			mc.http.ReproLoops[kc.name] = mc.repros[i].loop
+			mc.http.Pools[kc.name] = kc.pool
+		}
+		primary.http = mc.http
+	}
+	eg.Go(func() error {
+		return mc.Loop(ctx)
+	})
+	return eg.Wait()
+}
+
+type multiContext struct {
+	cfg     MultiFuzzerConfig
+	target  *prog.Target
+	http    *HTTPServer
+	kernels []*kernelContext
+	repros  []*multiRepro
+
+	mu sync.Mutex
+	// Crash title -> set of kernels that crashed with this title.
+	crashed  map[string]map[string]bool
+	corpusDB *db.DB
+	// Programs that are yet to be cross-executed on each of the kernels.
+	pending [][]fuzzer.Candidate
+}
+
+func (mc *multiContext) Loop(baseCtx context.Context) error {
+	g, ctx := errgroup.WithContext(baseCtx)
+	if mc.http != nil {
+		g.Go(func() error {
+			return mc.http.Serve(ctx)
+		})
+	}
+	for i, kc := range mc.kernels {
+		mr := mc.repros[i]
+		g.Go(kc.Loop)
+		g.Go(func() error {
+			mr.loop.Loop(ctx)
+			return nil
+		})
+		g.Go(func() error {
+			mc.corpusLoop(ctx, i)
+			return nil
+		})
+		g.Go(func() error {
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case crash := <-kc.crashes:
+					mc.handleCrash(ctx, mr, crash)
+				}
+			}
+		})
+	}
+	return g.Wait()
+}
+
+// corpusLoop saves new corpus programs of the kernel into the shared corpus and
+// passes the programs found on other kernels to the kernel's fuzzer.
+func (mc *multiContext) corpusLoop(ctx context.Context, idx int) {
+	kc := mc.kernels[idx]
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update := <-kc.corpusUpdates:
+			mc.shareInput(idx, update)
+		case <-time.After(time.Second):
+		}
+		// The fuzzer appears once the machine check is complete.
+		fuzzerObj := kc.fuzzer.Load()
+		if fuzzerObj == nil {
+			continue
+		}
+		mc.mu.Lock()
+		candidates := mc.pending[idx]
+		mc.pending[idx] = nil
+		mc.mu.Unlock()
+		if len(candidates) == 0 {
+			continue
+		}
+		filtered := FilterCandidates(candidates, fuzzerObj.Config.EnabledCalls, false).Candidates
+		log.Logf(1, "%s: cross-executing %d programs", kc.name, len(filtered))
+		fuzzerObj.AddCandidates(filtered)
+	}
+}
+
+func (mc *multiContext) shareInput(from int, update corpus.NewItemEvent) {
+	if update.Exists {
+		return
+	}
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if _, ok := mc.corpusDB.Records[update.Sig]; ok {
+		// It's either a seed or a program that has been found on another kernel.
+		return
+	}
+	mc.corpusDB.Save(update.Sig, update.ProgData, 0)
+	if err := mc.corpusDB.Flush(); err != nil {
+		log.Errorf("failed to save corpus database: %v", err)
+	}
+	p, err := mc.target.Deserialize(update.ProgData, prog.NonStrict)
+	if err != nil {
+		log.Errorf("failed to deserialize a corpus program: %v", err)
+		return
+	}
+	for i := range mc.pending {
+		if i != from {
+			mc.pending[i] = append(mc.pending[i], fuzzer.Candidate{
+				Prog:  p.Clone(),
+				Flags: fuzzer.ProgMinimized,
+			})
+		}
+	}
+}
+
+func (mc *multiContext) handleCrash(ctx context.Context, mr *multiRepro, saved *Crash) {
+	kernel := mr.kernel.name
+	crash := &KernelCrash{
+		Kernel:      kernel,
+		Report:      saved.Report,
+		AlsoCrashed: mc.noteCrash(kernel, saved.Title),
+	}
+	log.Logf(0, "%v: crashed: %v (also crashed: %v)", kernel, saved.Title, crash.AlsoCrashed)
+	if _, err := mr.store.SaveCrash(saved); err != nil {
+		log.Errorf("%v: %v", kernel, err)
+	}
+	if mr.NeedRepro(saved) {
+		mr.loop.Enqueue(saved)
+	}
+	if mc.cfg.Crashes == nil {
+		return
+	}
+	select {
+	case mc.cfg.Crashes <- crash:
+	case <-ctx.Done():
+	}
+}
+
+// noteCrash records that the kernel has crashed with the title,
+// and returns other kernels that have crashed with the same title.
+func (mc *multiContext) noteCrash(kernel, title string) []string {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	kernels := mc.crashed[title]
+	if kernels == nil {
+		kernels = map[string]bool{}
+		mc.crashed[title] = kernels
+	}
+	kernels[kernel] = true
+	var others []string
+	for other := range kernels {
+		if other != kernel {
+			others = append(others, other)
+		}
+	}
+	sort.Strings(others)
+	return others
+}
+
+func cloneCandidates(candidates []fuzzer.Candidate) []fuzzer.Candidate {
+	ret := make([]fuzzer.Candidate, len(candidates))
+	for i, candidate := range candidates {
+		ret[i] = fuzzer.Candidate{
+			Prog:  candidate.Prog.Clone(),
+			Flags: candidate.Flags,
+		}
+	}
+	return ret
+}
+
+// multiRepro reproduces the crashes of one of the kernels on its own VMs.
+type multiRepro struct {
+	kernel *kernelContext
+	store  *CrashStore
+	loop   *ReproLoop
+}
+
+func (mr *multiRepro) NeedRepro(crash *Crash) bool {
+	if !mr.kernel.cfg.Reproduce || crash.Corrupted || crash.Suppressed {
+		return false
+	}
+	if mr.store.HasRepro(crash.Title) {
+		return false
+	}
+	return mr.store.MoreReproAttempts(crash.Title)
+}
+
+func (mr *multiRepro) RunRepro(ctx context.Context, crash *Crash) *ReproResult {
+	kc := mr.kernel
+	res, stats, err := repro.Run(ctx, crash.Output, repro.Environment{
+		Config:   kc.cfg,
+		Features: kc.features,
+		Reporter: kc.reporter,
+		Pool:     kc.pool,
+		Parallel: kc.cfg.Experimental.ReproParallel,
+		Attempts: kc.cfg.Experimental.ReproAttempts,
+		Snapshot: kc.cfg.Experimental.SnapshotRepro,
+		Sched:    kc.cfg.Experimental.ReproSched,
+
+		MinimizeC: kc.cfg.Experimental.ReproMinimizeC,
+	})
+	ret := &ReproResult{
+		Crash: crash,
+		Repro: res,
+		Stats: stats,
+		Err:   err,
+	}
+	if err != nil {
+		log.Logf(0, "%s: repro of %q failed: %v", kc.name, crash.Title, err)
+	}
+	if res == nil {
+		var reproLog []byte
+		if stats != nil {
+			reproLog = stats.FullLog()
+		}
+		if err := mr.store.SaveFailedRepro(crash.Title, reproLog); err != nil {
+			log.Errorf("%s: failed to save repro log for %q: %v", kc.name, crash.Title, err)
+		}
+		return ret
+	}
+	progText := append([]byte(fmt.Sprintf("# %+v\n", res.Opts)), res.Prog.Serialize()...)
+	var cprogText []byte
+	if res.CRepro {
+		cprog, err := res.WriteC()
+		if err == nil {
+			if formatted, err := csource.Format(cprog); err == nil {
+				cprog = formatted
+			}
+			cprogText = cprog
+		} else {
+			log.Logf(0, "%s: failed to write C source: %v", kc.name, err)
+		}
+	}
+	if err := mr.store.SaveRepro(ret, progText, cprogText); err != nil {
+		log.Errorf("%s: failed to save repro for %q: %v", kc.name, res.Report.Title, err)
+	}
+	return ret
+}
+
+func (mr *multiRepro) ResizeReproPool(size int) {
+	mr.kernel.pool.ReserveForRun(size)
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/syzkaller/pkg/corpus"
+	"github.com/google/syzkaller/pkg/db"
+	"github.com/google/syzkaller/pkg/fuzzer"
+	"github.com/google/syzkaller/pkg/hash"
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/sys/targets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiNoteCrash(t *testing.T) {
+	mc := &multiContext{crashed: map[string]map[string]bool{}}
+	assert.Empty(t, mc.noteCrash("b", "title 1"))
+	assert.Empty(t, mc.noteCrash("b", "title 1"))
+	assert.Equal(t, []string{"b"}, mc.noteCrash("c", "title 1"))
+	assert.Equal(t, []string{"b", "c"}, mc.noteCrash("a", "title 1"))
+	assert.Empty(t, mc.noteCrash("a", "title 2"))
+}
+
+func TestMultiShareInput(t *testing.T) {
+	target, err := prog.GetTarget(targets.TestOS, targets.TestArch64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	corpusDB, err := db.Open(filepath.Join(t.TempDir(), "corpus.db"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mc := &multiContext{
+		target:   target,
+		corpusDB: corpusDB,
+		pending:  make([][]fuzzer.Candidate, 3),
+	}
+	data := []byte("test()\n")
+	update := corpus.NewItemEvent{Sig: hash.String(data), ProgData: data}
+	mc.shareInput(1, update)
+	assert.Len(t, mc.pending[0], 1)
+	assert.Empty(t, mc.pending[1])
+	assert.Len(t, mc.pending[2], 1)
+	assert.NotSame(t, mc.pending[0][0].Prog, mc.pending[2][0].Prog)
+	assert.Equal(t, data, mc.pending[0][0].Prog.Serialize())
+	assert.Contains(t, corpusDB.Records, update.Sig)
+
+	// The program is not shared again once it enters the corpus of another kernel.
+	mc.shareInput(0, update)
+	assert.Len(t, mc.pending[2], 1)
+	assert.Empty(t, mc.pending[1])
+
+	// Programs that already were in the kernel's corpus are not shared.
+	data = []byte("test$int(0x1, 0x2, 0x3, 0x4, 0x5)\n")
+	mc.shareInput(0, corpus.NewItemEvent{Sig: hash.String(data), ProgData: data, Exists: true})
+	assert.Len(t, mc.pending[2], 1)
+	assert.Len(t, corpusDB.Records, 1)
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/google/syzkaller/pkg/flatrpc"
+	"github.com/google/syzkaller/pkg/fuzzer"
+	"github.com/google/syzkaller/pkg/fuzzer/queue"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/report"
+	"github.com/google/syzkaller/pkg/rpcserver"
+	"github.com/google/syzkaller/pkg/signal"
+	"github.com/google/syzkaller/pkg/vminfo"
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/vm"
+	"github.com/google/syzkaller/vm/dispatcher"
+	"golang.org/x/sync/errgroup"
+)
+
+// ReplayResult is the result of execution of a single program by ReplayPrograms.
+type ReplayResult struct {
+	// PCs covered by all calls of the program.
+	Cover []uint64
+	// Err is set if the program could not be executed.
+	Err error
+}
+
+// ReplayPrograms boots VMs according to cfg, executes every program once with coverage collection
+// and returns the coverage of each of the programs. Programs that contain syscalls that are not
+// enabled/supported on the kernel are not executed.
+func ReplayPrograms(ctx context.Context, cfg *mgrconfig.Config, progs []*prog.Prog,
+	debug bool) ([]ReplayResult, error) {
+	if !cfg.Cover {
+		return nil, fmt.Errorf("coverage is required")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	rc := &replayContext{
+		ctx:     ctx,
+		cfg:     cfg,
+		debug:   debug,
+		progs:   progs,
+		results: make([]ReplayResult, len(progs)),
+		exec:    queue.Plain(),
+		done:    make(chan struct{}),
+	}
+	var err error
+	rc.reporter, err = report.NewReporter(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reporter: %w", err)
+	}
+	rc.serv, err = rpcserver.New(&rpcserver.RemoteConfig{
+		Config:  cfg,
+		Manager: rc,
+		Stats:   rpcserver.NewStats(),
+		Debug:   debug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rpc server: %w", err)
+	}
+	if err := rc.serv.Listen(); err != nil {
+		return nil, fmt.Errorf("failed to start rpc server: %w", err)
+	}
+	defer rc.serv.Close()
+	vmPool, err := vm.Create(cfg, debug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vm.Pool: %w", err)
+	}
+	defer vmPool.Close()
+	pool := vm.NewDispatcher(vmPool, rc.runInstance)
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		return rc.serv.Serve(egCtx)
+	})
+	eg.Go(func() error {
+		pool.Loop(egCtx)
+		return nil
+	})
+	aborted := false
+	select {
+	case <-rc.done:
+	case <-egCtx.Done():
+		aborted = true
+	}
+	cancel()
+	err = eg.Wait()
+	if !aborted {
+		return rc.results, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("replay was aborted")
+	}
+	return nil, err
+}
+
+type replayContext struct {
+	ctx      context.Context
+	cfg      *mgrconfig.Config
+	debug    bool
+	reporter *report.Reporter
+	serv     rpcserver.Server
+	progs    []*prog.Prog
+	results  []ReplayResult
+	exec     *queue.PlainQueue
+	once     sync.Once
+	done     chan struct{}
+}
+
+func (rc *replayContext) MaxSignal() signal.Signal {
+	return nil
+}
+
+func (rc *replayContext) BugFrames() (leaks, races []string) {
+	return nil, nil
+}
+
+func (rc *replayContext) CoverageFilter(modules []*vminfo.KernelModule) ([]uint64, error) {
+	return nil, nil
+}
+
+func (rc *replayContext) MachineChecked(features flatrpc.Feature,
+	syscalls map[*prog.Syscall]bool) (queue.Source, error) {
+	if len(syscalls) == 0 {
+		return nil, fmt.Errorf("all system calls are disabled")
+	}
+	// Machine check happens on every VM restart, but the programs need to be run only once.
+	rc.once.Do(func() {
+		log.Logf(0, "machine check complete, replaying %v programs", len(rc.progs))
+		go rc.replay(syscalls)
+	})
+	opts := fuzzer.DefaultExecOpts(rc.cfg, features, rc.debug)
+	return queue.DefaultOpts(rc.exec, opts), nil
+}
+
+func (rc *replayContext) replay(syscalls map[*prog.Syscall]bool) {
+	defer close(rc.done)
+	reqs := make([]*queue.Request, len(rc.progs))
+	for i, p := range rc.progs {
+		if !p.OnlyContains(syscalls) {
+			rc.results[i].Err = fmt.Errorf("the program contains disabled syscalls")
+			continue
+		}
+		reqs[i] = &queue.Request{
+			Prog: p,
+			ExecOpts: flatrpc.ExecOpts{
+				ExecFlags: flatrpc.ExecFlagCollectCover,
+			},
+		}
+		rc.exec.Submit(reqs[i])
+	}
+	for i, req := range reqs {
+		if req == nil {
+			continue
+		}
+		res := req.Wait(rc.ctx)
+		if res.Status != queue.Success || res.Info == nil {
+			rc.results[i].Err = fmt.Errorf("failed to execute: %v (%w)", res.Status, res.Err)
+			continue
+		}
+		pcs := make(map[uint64]bool)
+		for _, call := range append(res.Info.Calls, res.Info.Extra) {
+			if call == nil {
+				continue
+			}
+			for _, pc := range call.Cover {
+				pcs[pc] = true
+			}
+		}
+		for pc := range pcs {
+			rc.results[i].Cover = append(rc.results[i].Cover, pc)
+		}
+		if (i+1)%1000 == 0 {
+			log.Logf(0, "replayed %v/%v programs", i+1, len(rc.progs))
+		}
+	}
+}
+
+func (rc *replayContext) runInstance(ctx context.Context, inst *vm.Instance, updInfo dispatcher.UpdateInfo) {
+	index := inst.Index()
+	injectExec := make(chan bool, 10)
+	rc.serv.CreateInstance(index, injectExec, updInfo)
+	rep, err := rc.runExecutor(ctx, inst, injectExec)
+	rc.serv.ShutdownInstance(index, rep != nil)
+	if rep != nil {
+		log.Logf(0, "VM %v: crash: %v", index, rep.Title)
+	}
+	if err != nil {
+		log.Errorf("#%d run failed: %s", index, err)
+	}
+}
+
+func (rc *replayContext) runExecutor(ctx context.Context, inst *vm.Instance,
+	injectExec <-chan bool) (*report.Report, error) {
+	fwdAddr, err := inst.Forward(rc.serv.Port())
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup port forwarding: %w", err)
+	}
+	executorBin, err := inst.Copy(rc.cfg.ExecutorBin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy binary: %w", err)
+	}
+	host, port, err := net.SplitHostPort(fwdAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manager's address")
+	}
+	cmd := fmt.Sprintf("%v runner %v %v %v", executorBin, inst.Index(), host, port)
+	ctxTimeout, cancel := context.WithTimeout(ctx, rc.cfg.Timeouts.VMRunningTime)
+	defer cancel()
+	_, rep, err := inst.Run(ctxTimeout, rc.reporter, cmd, vm.ExitTimeout,
+		vm.InjectExecuting(injectExec),
+		vm.EarlyFinishCb(func() {
+			rc.serv.StopFuzzing(inst.Index())
+		}),
+	)
+	return rep, err
+}
@@ -29,6 +29,8 @@ type Crash struct {
 	FromHub       bool // this crash was created based on a repro from syz-hub
 	FromDashboard bool // .. or from dashboard
 	Manual        bool
+	// Signature of the crash to find related crashes (optional).
+	Signature *CrashSignature
 	*report.Report
 }
 
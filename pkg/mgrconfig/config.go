@@ -248,6 +248,111 @@ type Experimental struct {
 	// Use automatically (auto) generated or manually (manual) written descriptions or any (any) (default: manual)
 	DescriptionsMode string `json:"descriptions_mode"`
 
+	// Learn weights of mutation operators and of mutated argument kinds for every VM
+	// based on the new signal they produce (default: false).
+	// The weights learned from all VMs are exported as "mutator *" metrics.
+	AdaptiveMutations bool `json:"adaptive_mutations"`
+
+	// Mutate programs by exchanging argument subtrees of the same syscalls between corpus programs
+	// (grammar-aware crossover that also rewires resources) (default: false).
+	Crossover bool `json:"crossover"`
+
+	// Before hints mutations, execute the program once more with the call arguments marked
+	// with random values and substitute comparison operands only into the arguments
+	// that reach the comparisons (default: false).
+	// This approximates taint tracking on the host: a comparison is attributed to an argument
+	// only if one of its operands equals the argument marker (possibly truncated or extended),
+	// so comparisons of transformed values are missed, and the markers may change control flow.
+	// If no comparison matches the markers, operands are substituted into all arguments
+	// (see the "hints taint fallback" stat).
+	HintsTaint bool `json:"hints_taint"`
+
+	// Period (in minutes) of saving the fuzzer state (max signal, corpus signal and coverage,
+	// seed statistics, learned call transitions and mutation weights, programs in triage)
+	// to workdir/fuzzer.state. On restart the state allows to skip triage of the corpus programs
+	// that were already triaged by the previous run on the same kernel (default: 0, disabled).
+	// The kernel is identified by the tag or by the kernel_obj binary, without them nothing is saved.
+	CheckpointPeriod int `json:"checkpoint_period"`
+
+	// Corpus program selection schedule (default: signal):
+	//  - signal: programs are chosen proportionally to their signal size;
+	//  - explore: also prefer programs that were mutated less often, or whose mutants keep finding new signal;
+	//  - rare: like explore, but prefer programs with signal that is rare in the corpus instead of the signal size.
+	// Mutation counts of the programs are shown on the /corpus page.
+	CorpusSchedule string `json:"corpus_schedule"`
+
+	// Maximum size (in MB) of workdir/jobs.jsonl. If set, a structured record about every finished
+	// triage/smash/hints/fault injection job (input, executed mutants, new signal, duration)
+	// is appended to the file. When the file reaches the size, it's renamed to jobs.jsonl.1.
+	// Recorded jobs can be replayed with tools/syz-jobreplay (default: 0, disabled).
+	JobLogSize int `json:"job_log_size"`
+
+	// By default the classes of fuzzer requests are served in strict priority order:
+	// candidates first, then triage, then smash/hints/fault jobs and program generation.
+	// If set, the classes are served according to their shares and deadlines instead
+	// (see exec_shares and exec_deadlines), so that e.g. a large corpus import does not
+	// starve the jobs (default: false).
+	ExecBudget bool `json:"exec_budget"`
+
+	// Relative shares of executions given to the classes of fuzzer requests when all of them
+	// have pending requests: candidate, triage, smash, hints, fault, generate (requires exec_budget).
+	// Omitted classes use the default shares (candidate: 50, triage: 30, smash: 6, hints: 6,
+	// fault: 3, generate: 5, or 15 for the patched kernel in diff fuzzing).
+	// The actual shares are exported as "exec share *" metrics.
+	ExecShares map[string]float64 `json:"exec_shares"`
+
+	// Deadlines (in seconds) for requests of the same classes: requests that wait longer
+	// are executed before all other requests (requires exec_budget). 0 disables the deadline
+	// (default: triage: 600, smash/hints/fault: 3600).
+	ExecDeadlines map[string]int `json:"exec_deadlines"`
+
+	// Weights of the pluggable fuzzing strategies registered in pkg/fuzzer (see fuzzer.RegisterStrategy).
+	// Programs of the "generate" class are produced by one of the strategies chosen proportionally
+	// to the weights. The built-in generation/mutation is called "default" and has weight 100
+	// unless specified otherwise (e.g. "strategies": {"my-strategy": 10}).
+	Strategies map[string]float64 `json:"strategies"`
+
+	// Maximum number of programs sent to a VM in a single RPC message. Batching amortizes
+	// the RPC overhead for very short programs, which matters on VMs with lots of procs.
+	// Old executors that don't support batching get programs one-by-one
+	// (default: 0, batching is disabled).
+	ExecBatch int `json:"exec_batch"`
+
+	// Send signal and coverage from VMs as delta-encoded varints instead of raw 8-byte PCs.
+	// This considerably reduces RPC traffic when raw_cover is enabled or the kernel is large,
+	// at the cost of some CPU time in the VM and on the host (default: false).
+	CompactCover bool `json:"compact_cover"`
+
+	// Record all programs executed in each VM to workdir/sessions/vmN-TIMESTAMP.log.gz.
+	// Logs are kept only for VMs that crashed (at most the last 100 logs), and can be replayed
+	// with syz-execprog -replay to reproduce crashes that depend on long execution histories (default: false).
+	RecordSessions bool `json:"record_sessions"`
+
+	// The maximum number of test runs per candidate during bug reproduction.
+	// With more than 1 attempt, flaky reproducers are detected with repeated runs,
+	// and the reproduction rate of the final reproducer is measured
+	// and reported to the dashboard (e.g. "reproduces 3/20") (default: 1).
+	ReproAttempts int `json:"repro_attempts"`
+
+	// The maximum number of concurrent test runs during bisection of crash logs in bug reproduction.
+	// Parallel bisection finishes faster, but needs more test runs in total and is less reliable
+	// for flaky crashes (default: 1, i.e. sequential bisection).
+	ReproParallel int `json:"repro_parallel"`
+
+	// Execute single program test runs during bug reproduction on VMs restored from a snapshot
+	// instead of rebooting the VM for each run. VMs reserved for reproduction are then booted with
+	// snapshot support. Currently only qemu VMs with KVM on linux/amd64 support this (default: false).
+	SnapshotRepro bool `json:"snapshot_repro"`
+
+	// If no reproducer is found otherwise, run the last programs of the crash log under
+	// executor scheduling perturbations (yield, pin, spread, delay) to reproduce races.
+	// This adds up to 8 test runs with the longest timeout to every failed reproduction (default: false).
+	ReproSched bool `json:"repro_sched"`
+
+	// Remove unneeded setup code (e.g. network, cgroups and sysctl setup) from the source of found
+	// C reproducers. This may add up to 15 minutes of test runs to every C reproducer (default: false).
+	ReproMinimizeC bool `json:"repro_minimize_c"`
+
 	// FocusAreas configures what attention syzkaller should pay to the specific areas of the kernel.
 	// The probability of selecting a program from an area is at least `Weight / sum of weights`.
 	// If FocusAreas is non-empty, by default all kernel code not covered by any filter will be ignored.
@@ -255,6 +360,13 @@ type Experimental struct {
 	// with an empty Filter, but non-empty weight.
 	// E.g. "focus_areas": [ {"filter": {"files": ["^net"]}, "weight": 10.0}, {"weight": 1.0"} ].
 	FocusAreas []FocusArea `json:"focus_areas,omitempty"`
+
+	// DirectedTargets enables directed fuzzing towards the kernel code matching the filter
+	// (the same format as the FocusArea filter). Call graph distances to the target functions
+	// are used to prefer corpus programs and syscalls that get closer to the targets.
+	// Unlike FocusAreas, it also helps to reach code that is not yet covered by the corpus.
+	// E.g. "directed_targets": {"functions": ["^tcp_recvmsg$"]}.
+	DirectedTargets CovFilterCfg `json:"directed_targets,omitempty"`
 }
 
 type FocusArea struct {
@@ -10,9 +10,11 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 
 	"github.com/google/syzkaller/pkg/config"
+	"github.com/google/syzkaller/pkg/corpus/schedule"
 	"github.com/google/syzkaller/pkg/osutil"
 	"github.com/google/syzkaller/pkg/vminfo"
 	"github.com/google/syzkaller/prog"
@@ -105,6 +107,7 @@ func defaultValues() *Config {
 			RemoteCover:      true,
 			CoverEdges:       true,
 			DescriptionsMode: manualDescriptions,
+			CorpusSchedule:   "signal",
 		},
 	}
 }
@@ -193,6 +196,23 @@ func Complete(cfg *Config) error {
 	if cfg.FuzzingVMs < 0 {
 		return fmt.Errorf("fuzzing_vms cannot be less than 0")
 	}
+	if cfg.Experimental.CheckpointPeriod < 0 {
+		return fmt.Errorf("checkpoint_period cannot be less than 0")
+	}
+	if cfg.Experimental.JobLogSize < 0 {
+		return fmt.Errorf("job_log_size cannot be less than 0")
+	}
+	if cfg.Experimental.ExecBatch < 0 {
+		return fmt.Errorf("exec_batch cannot be less than 0")
+	}
+	if !cfg.Experimental.ExecBudget && (len(cfg.Experimental.ExecShares) != 0 ||
+		len(cfg.Experimental.ExecDeadlines) != 0) {
+		return fmt.Errorf("exec_shares and exec_deadlines require exec_budget")
+	}
+	if !slices.Contains(schedule.Names(), cfg.Experimental.CorpusSchedule) {
+		return fmt.Errorf("unknown corpus_schedule %q, supported: %q",
+			cfg.Experimental.CorpusSchedule, schedule.Names())
+	}
 
 	var err error
 	cfg.Syscalls, err = ParseEnabledSyscalls(cfg.Target, cfg.EnabledSyscalls, cfg.DisabledSyscalls,
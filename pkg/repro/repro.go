@@ -8,7 +8,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/google/syzkaller/pkg/bisect/minimize"
@@ -30,9 +32,24 @@ type Result struct {
 	Duration time.Duration
 	Opts     csource.Options
 	CRepro   bool
+	// Minimized C reproducer source, if CRepro is set and the minimization succeeded.
+	// If nil, the source is generated from Prog and Opts (see WriteC).
+	CSource []byte
 	// Information about the final (non-symbolized) crash that we reproduced.
 	// Can be different from what we started reproducing.
 	Report *report.Report
+	// The final reproducer crashed the kernel Crashes times out of Runs test runs.
+	// Runs is 0 if the reproduction rate was not measured (see Environment.Attempts).
+	Runs    int
+	Crashes int
+}
+
+// WriteC returns the C reproducer source.
+func (res *Result) WriteC() ([]byte, error) {
+	if res.CSource != nil {
+		return res.CSource, nil
+	}
+	return csource.Write(res.Prog, res.Opts)
 }
 
 type Stats struct {
@@ -43,6 +60,11 @@ type Stats struct {
 	SimplifyProgTime time.Duration
 	ExtractCTime     time.Duration
 	SimplifyCTime    time.Duration
+	MinimizeCTime    time.Duration
+	// The maximum number of concurrent test runs during log bisection.
+	Parallel int
+	// The total number of test runs.
+	TestRuns int
 }
 
 type reproContext struct {
@@ -62,6 +84,14 @@ type reproContext struct {
 	timeouts       targets.Timeouts
 	observedTitles map[string]bool
 	fast           bool
+	parallel       int
+	attempts       int
+	cMinimize      bool
+	sched          bool
+	// Test runs are executed concurrently during log bisection.
+	// mu protects report, observedTitles and stats.TestRuns, logMu protects stats.Log.
+	mu    sync.Mutex
+	logMu sync.Mutex
 }
 
 // execInterface describes the interfaces needed by pkg/repro.
@@ -78,20 +108,48 @@ type Environment struct {
 	// The Fast repro mode restricts the repro log bisection,
 	// it skips multiple simpifications and C repro generation.
 	Fast bool
+	// The maximum number of test runs executed concurrently during log bisection.
+	// If it's 0 or 1, the log is bisected sequentially.
+	Parallel int
+	// The maximum number of test runs per candidate. If it's greater than 1, the number of runs
+	// is chosen adaptively to detect flaky reproducers, and the final reproducer is run Attempts
+	// times to measure its reproduction rate. 0 means 1.
+	// A candidate that never crashes is run up to 12 times (see sprt), so Attempts >= 12 makes
+	// every rejected candidate up to 12x more expensive. Bisection and minimization probes
+	// that don't crash are rejected after maxProbeRuns runs instead.
+	Attempts int
+	// Remove unneeded setup code from the source of the C reproducer (see minimizeC).
+	// This may add up to minimizeCTime (15 minutes) of test runs to every found C reproducer.
+	MinimizeC bool
+	// If no reproducer is found otherwise, run the last programs under executor scheduling
+	// perturbations to reproduce races. This adds up to 8 test runs with the longest timeout
+	// to every failed reproduction (see extractProgSched).
+	Sched bool
+	// Execute single program test runs on VMs restored from a snapshot (see snapshotWrapper).
+	// The pool must boot reserved VMs with snapshot support (see snapshot_repro in mgrconfig).
+	Snapshot bool
 }
 
 func Run(ctx context.Context, log []byte, env Environment) (*Result, *Stats, error) {
-	return runInner(ctx, log, env.Config, env.Features, env.Reporter, env.Fast, &poolWrapper{
+	pw := &poolWrapper{
 		cfg:      env.Config,
 		reporter: env.Reporter,
 		pool:     env.Pool,
-	})
+	}
+	var exec execInterface = pw
+	if env.Snapshot {
+		// Runs fall back to pw if the VMs don't support snapshots.
+		sw := newSnapshotWrapper(ctx, pw, env.Features, env.Parallel)
+		defer sw.stop()
+		exec = sw
+	}
+	return runInner(ctx, log, env, exec)
 }
 
 var ErrEmptyCrashLog = errors.New("no programs")
 
-func runInner(ctx context.Context, crashLog []byte, cfg *mgrconfig.Config, features flatrpc.Feature,
-	reporter *report.Reporter, fast bool, exec execInterface) (*Result, *Stats, error) {
+func runInner(ctx context.Context, crashLog []byte, env Environment, exec execInterface) (*Result, *Stats, error) {
+	cfg, reporter := env.Config, env.Reporter
 	entries := cfg.Target.ParseLog(crashLog, prog.NonStrict)
 	if len(entries) == 0 {
 		return nil, nil, fmt.Errorf("log (%d bytes) parse failed: %w", len(crashLog), ErrEmptyCrashLog)
@@ -124,7 +182,7 @@ func runInner(ctx context.Context, crashLog []byte, cfg *mgrconfig.Config, featu
 	case crashType == crash.Hang:
 		testTimeouts = testTimeouts[2:]
 	}
-	if fast {
+	if env.Fast {
 		testTimeouts = []time.Duration{30 * time.Second, 5 * time.Minute}
 	}
 	reproCtx := &reproContext{
@@ -138,11 +196,15 @@ func runInner(ctx context.Context, crashLog []byte, cfg *mgrconfig.Config, featu
 
 		entries:        entries,
 		testTimeouts:   testTimeouts,
-		startOpts:      createStartOptions(cfg, features, crashType),
-		stats:          new(Stats),
+		startOpts:      createStartOptions(cfg, env.Features, crashType),
+		stats:          &Stats{Parallel: max(env.Parallel, 1)},
 		timeouts:       cfg.Timeouts,
 		observedTitles: map[string]bool{},
-		fast:           fast,
+		fast:           env.Fast,
+		parallel:       max(env.Parallel, 1),
+		attempts:       max(env.Attempts, 1),
+		cMinimize:      env.MinimizeC,
+		sched:          env.Sched,
 	}
 	return reproCtx.run()
 }
@@ -159,9 +221,9 @@ func (ctx *reproContext) run() (*Result, *Stats, error) {
 		for attempts := 0; ctx.report.Corrupted && attempts < 3; attempts++ {
 			ctx.reproLogf(3, "report is corrupted, running repro again")
 			if res.CRepro {
-				_, err = ctx.testCProg(res.Prog, res.Duration, res.Opts, false)
+				_, err = ctx.testCSource(res.Prog, res.CSource, res.Duration, res.Opts, false)
 			} else {
-				_, err = ctx.testProg(res.Prog, res.Duration, res.Opts, false)
+				_, err = ctx.testProg(res.Prog, res.Duration, res.Opts, false, false)
 			}
 			if err != nil {
 				return nil, nil, err
@@ -170,10 +232,44 @@ func (ctx *reproContext) run() (*Result, *Stats, error) {
 		ctx.reproLogf(3, "final repro crashed as (corrupted=%v):\n%s",
 			ctx.report.Corrupted, ctx.report.Report)
 		res.Report = ctx.report
+		if ctx.attempts > 1 {
+			ctx.measureRate(res)
+			ctx.report = res.Report
+		}
 	}
 	return res, ctx.stats, nil
 }
 
+// measureRate runs the final reproducer ctx.attempts times to estimate its reproduction rate.
+// The reproducer is already found at this point, so on errors the runs done so far are kept.
+func (ctx *reproContext) measureRate(res *Result) {
+	params := instance.ExecParams{
+		Opts:     res.Opts,
+		Duration: res.Duration,
+	}
+	if res.CRepro {
+		params.CProg = res.Prog
+		params.CSource = res.CSource
+	} else {
+		params.SyzProg = encodeEntries([]*prog.LogEntry{{P: res.Prog}})
+	}
+	ctx.reproLogf(2, "measuring the reproduction rate over %v runs", ctx.attempts)
+	for i := 0; i < ctx.attempts; i++ {
+		crashed, _, err := ctx.runOnce(func() (*instance.RunResult, error) {
+			return ctx.exec.Run(ctx.ctx, params, ctx.reproLogf)
+		}, true)
+		if err != nil {
+			ctx.reproLogf(0, "failed to measure the reproduction rate: %v", err)
+			break
+		}
+		res.Runs++
+		if crashed {
+			res.Crashes++
+		}
+	}
+	ctx.reproLogf(2, "the reproducer crashed the kernel %v/%v times", res.Crashes, res.Runs)
+}
+
 func createStartOptions(cfg *mgrconfig.Config, features flatrpc.Feature,
 	crashType crash.Type) csource.Options {
 	opts := csource.DefaultOpts(cfg)
@@ -258,6 +354,12 @@ func (ctx *reproContext) repro() (*Result, error) {
 			if err != nil {
 				return nil, err
 			}
+			if ctx.cMinimize {
+				res, err = ctx.minimizeC(res)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
 	}
 	return res, nil
@@ -321,6 +423,18 @@ func (ctx *reproContext) extractProg(entries []*prog.LogEntry) (*Result, error)
 		}
 	}
 
+	if ctx.sched && !ctx.fast {
+		// Races may need specific thread interleavings that plain runs don't produce.
+		res, err := ctx.extractProgSched(toTest, ctx.testTimeouts[len(ctx.testTimeouts)-1])
+		if err != nil {
+			return nil, err
+		}
+		if res != nil {
+			ctx.reproLogf(3, "found reproducer with %d syscalls", len(res.Prog.Calls))
+			return res, nil
+		}
+	}
+
 	ctx.reproLogf(2, "failed to extract reproducer")
 	return nil, nil
 }
@@ -348,7 +462,7 @@ func (ctx *reproContext) extractProgSingle(entries []*prog.LogEntry, duration ti
 
 	opts := ctx.startOpts
 	for _, ent := range entries {
-		ret, err := ctx.testProg(ent.P, duration, opts, false)
+		ret, err := ctx.testProg(ent.P, duration, opts, false, false)
 		if err != nil {
 			return nil, err
 		}
@@ -367,6 +481,56 @@ func (ctx *reproContext) extractProgSingle(entries []*prog.LogEntry, duration ti
 	return nil, nil
 }
 
+// maxSchedProgs is the number of programs tested by extractProgSched.
+const maxSchedProgs = 2
+
+// extractProgSched executes the first maxSchedProgs programs separately under executor scheduling
+// perturbations (see csource.Options.Sched). The winning perturbation is recorded in the result options.
+// Each program is tested once per perturbation with the given (the longest) timeout, i.e. a failed
+// extraction costs maxSchedProgs*len(csource.SchedPerturbations) test runs (8 runs, up to
+// NoOutputRunningTime each), so it only runs if Environment.Sched is set.
+func (ctx *reproContext) extractProgSched(entries []*prog.LogEntry, duration time.Duration) (*Result, error) {
+	entries = entries[:min(len(entries), maxSchedProgs)]
+	ctx.reproLogf(3, "sched: executing %d programs with scheduling perturbations, timeout %s",
+		len(entries), duration)
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for _, sched := range csource.SchedPerturbations {
+		opts := ctx.startOpts
+		opts.Sched = sched
+		if err := opts.Check(entries[0].P.Target.OS); err != nil {
+			ctx.reproLogf(3, "sched: skipping %v: %v", sched, err)
+			continue
+		}
+		for _, ent := range entries {
+			p := ent.P
+			if sched == csource.SchedDelay {
+				// Delays are inserted after async calls, so let some calls run asynchronously.
+				p = prog.AssignRandomAsync(p, rnd)
+			}
+			ret, err := ctx.testProg(p, duration, opts, false, false)
+			if err != nil {
+				return nil, err
+			}
+			if ret.Crashed {
+				res := &Result{
+					Prog:     p,
+					Duration: max(duration, ret.Duration*3/2),
+					Opts:     opts,
+				}
+				ctx.reproLogf(3, "sched: successfully extracted reproducer with sched=%v", sched)
+				return res, nil
+			}
+		}
+	}
+
+	ctx.reproLogf(3, "sched: failed to extract reproducer")
+	return nil, nil
+}
+
 func (ctx *reproContext) extractProgBisect(entries []*prog.LogEntry, baseDuration time.Duration) (*Result, error) {
 	ctx.reproLogf(3, "bisect: bisecting %d programs with base timeout %s", len(entries), baseDuration)
 
@@ -376,7 +540,7 @@ func (ctx *reproContext) extractProgBisect(entries []*prog.LogEntry, baseDuratio
 	}
 
 	// First check if replaying the log may crash the kernel at all.
-	ret, err := ctx.testProgs(entries, duration(len(entries)), opts, false)
+	ret, err := ctx.testProgs(entries, duration(len(entries)), opts, false, false)
 	if !ret.Crashed {
 		ctx.reproLogf(3, "replaying the whole log did not cause a kernel crash")
 		return nil, nil
@@ -387,7 +551,7 @@ func (ctx *reproContext) extractProgBisect(entries []*prog.LogEntry, baseDuratio
 
 	// Bisect the log to find multiple guilty programs.
 	entries, err = ctx.bisectProgs(entries, func(progs []*prog.LogEntry) (bool, error) {
-		ret, err := ctx.testProgs(progs, duration(len(progs)), opts, false)
+		ret, err := ctx.testProgs(progs, duration(len(progs)), opts, false, true)
 		return ret.Crashed, err
 	})
 	if err != nil {
@@ -434,7 +598,7 @@ func (ctx *reproContext) concatenateProgs(entries []*prog.LogEntry, dur time.Dur
 					if i+1 < len(entries) {
 						newEntries = append(newEntries, entries[i+1:]...)
 					}
-					ret, err := ctx.testProgs(newEntries, dur, ctx.startOpts, false)
+					ret, err := ctx.testProgs(newEntries, dur, ctx.startOpts, false, true)
 					if err != nil {
 						testErr = err
 						ctx.reproLogf(0, "concatenation step failed with %v", err)
@@ -458,7 +622,7 @@ func (ctx *reproContext) concatenateProgs(entries []*prog.LogEntry, dur time.Dur
 		ctx.reproLogf(2, "bisect: concatenated prog still exceeds %d calls", prog.MaxCalls)
 		return nil, nil
 	}
-	ret, err := ctx.testProg(p, dur, ctx.startOpts, false)
+	ret, err := ctx.testProg(p, dur, ctx.startOpts, false, false)
 	if err != nil {
 		ctx.reproLogf(3, "bisect: error during concatenation testing: %v", err)
 		return nil, err
@@ -498,7 +662,7 @@ func (ctx *reproContext) minimizeProg(res *Result) (*Result, error) {
 			// will immediately exit.
 			return false
 		}
-		ret, err := ctx.testProg(p1, res.Duration, res.Opts, false)
+		ret, err := ctx.testProg(p1, res.Duration, res.Opts, false, true)
 		if err != nil {
 			ctx.reproLogf(2, "minimization failed with %v", err)
 			testErr = err
@@ -526,7 +690,7 @@ func (ctx *reproContext) simplifyProg(res *Result) (*Result, error) {
 		if !simplify(&opts) || !checkOpts(&opts, ctx.timeouts, res.Duration) {
 			continue
 		}
-		ret, err := ctx.testProg(res.Prog, res.Duration, opts, true)
+		ret, err := ctx.testProg(res.Prog, res.Duration, opts, true, false)
 		if err != nil {
 			return nil, err
 		}
@@ -591,6 +755,34 @@ func (ctx *reproContext) simplifyC(res *Result) (*Result, error) {
 	return res, nil
 }
 
+// minimizeCTime limits the time spent on test runs during C reproducer minimization.
+const minimizeCTime = 15 * time.Minute
+
+// Try to remove unneeded setup code from the C reproducer source.
+func (ctx *reproContext) minimizeC(res *Result) (*Result, error) {
+	ctx.reproLogf(2, "minimizing C reproducer")
+	start := time.Now()
+	defer func() {
+		ctx.stats.MinimizeCTime = time.Since(start)
+	}()
+
+	src, err := csource.Write(res.Prog, res.Opts)
+	if err != nil {
+		return nil, err
+	}
+	src, err = csource.Minimize(src, minimizeCTime, func(src []byte) (bool, error) {
+		ret, err := ctx.testCSource(res.Prog, src, res.Duration, res.Opts, true)
+		return ret.Crashed, err
+	}, func(msg string, args ...interface{}) {
+		ctx.reproLogf(3, "minimize C: "+msg, args...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.CSource = src
+	return res, nil
+}
+
 func checkOpts(opts *csource.Options, timeouts targets.Timeouts, timeout time.Duration) bool {
 	if !opts.Repeat && timeout >= time.Minute {
 		// If we have a non-repeating C reproducer with timeout > vm.NoOutputTimeout and it hangs
@@ -615,18 +807,64 @@ func checkOpts(opts *csource.Options, timeouts targets.Timeouts, timeout time.Du
 }
 
 func (ctx *reproContext) testProg(p *prog.Prog, duration time.Duration, opts csource.Options,
-	strict bool) (ret verdict, err error) {
+	strict, probe bool) (ret verdict, err error) {
 	entry := prog.LogEntry{P: p}
-	return ctx.testProgs([]*prog.LogEntry{&entry}, duration, opts, strict)
+	return ctx.testProgs([]*prog.LogEntry{&entry}, duration, opts, strict, probe)
 }
 
 type verdict struct {
 	Crashed  bool
 	Duration time.Duration
+	// The candidate crashed the kernel Crashes times out of Runs test runs.
+	Runs    int
+	Crashes int
 }
 
-func (ctx *reproContext) getVerdict(callback func() (rep *instance.RunResult, err error), strict bool) (
+// maxProbeRuns is the number of runs after which a bisection or minimization probe
+// that has not crashed even once is rejected.
+// There are lots of such probes and most of them don't crash, so running each of them
+// up to ctx.attempts times would multiply the cost of the whole reproduction.
+const maxProbeRuns = 3
+
+// getVerdict runs the candidate up to ctx.attempts times and decides whether it reproduces the crash.
+// The number of runs is chosen adaptively with a sequential probability ratio test (see sprt).
+// If probe is set, the candidate is rejected after maxProbeRuns runs without crashes.
+func (ctx *reproContext) getVerdict(callback func() (rep *instance.RunResult, err error), strict, probe bool) (
 	verdict, error) {
+	var ret verdict
+	var test sprt
+	for ret.Runs < ctx.attempts {
+		if probe && ret.Crashes == 0 && ret.Runs >= maxProbeRuns {
+			break
+		}
+		crashed, duration, err := ctx.runOnce(callback, strict)
+		if err != nil {
+			return verdict{}, err
+		}
+		ret.Runs++
+		if crashed && ret.Crashes == 0 {
+			// Only the crashed runs matter for the duration from now on.
+			ret.Duration = 0
+		}
+		if crashed {
+			ret.Crashes++
+		}
+		if crashed || ret.Crashes == 0 {
+			ret.Duration = max(ret.Duration, duration)
+		}
+		if test.add(crashed) {
+			break
+		}
+	}
+	ret.Crashed = test.crashed()
+	if ret.Runs > 1 {
+		ctx.reproLogf(2, "crashed %v/%v times, verdict: crashed=%v", ret.Crashes, ret.Runs, ret.Crashed)
+	}
+	return ret, nil
+}
+
+func (ctx *reproContext) runOnce(callback func() (rep *instance.RunResult, err error), strict bool) (
+	bool, time.Duration, error) {
 	var result *instance.RunResult
 	var err error
 
@@ -642,30 +880,33 @@ func (ctx *reproContext) getVerdict(callback func() (rep *instance.RunResult, er
 		}
 	}
 	if err != nil {
-		return verdict{}, err
+		return false, 0, err
 	}
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.stats.TestRuns++
 	rep := result.Report
 	if rep == nil {
-		return verdict{false, result.Duration}, nil
+		return false, result.Duration, nil
 	}
 	if rep.Suppressed {
 		ctx.reproLogf(2, "suppressed program crash: %v", rep.Title)
-		return verdict{false, result.Duration}, nil
+		return false, result.Duration, nil
 	}
 	if ctx.crashType == crash.MemoryLeak && rep.Type != crash.MemoryLeak {
 		ctx.reproLogf(2, "not a leak crash: %v", rep.Title)
-		return verdict{false, result.Duration}, nil
+		return false, result.Duration, nil
 	}
 	if strict && len(ctx.observedTitles) > 0 {
 		if !ctx.observedTitles[rep.Title] {
 			ctx.reproLogf(2, "a never seen crash title: %v, ignore", rep.Title)
-			return verdict{false, result.Duration}, nil
+			return false, result.Duration, nil
 		}
 	} else {
 		ctx.observedTitles[rep.Title] = true
 	}
 	ctx.report = rep
-	return verdict{true, result.Duration}, nil
+	return true, result.Duration, nil
 }
 
 var ErrNoVMs = errors.New("all VMs failed to boot")
@@ -682,7 +923,7 @@ func encodeEntries(entries []*prog.LogEntry) []byte {
 }
 
 func (ctx *reproContext) testProgs(entries []*prog.LogEntry, duration time.Duration, opts csource.Options,
-	strict bool) (ret verdict, err error) {
+	strict, probe bool) (ret verdict, err error) {
 	if len(entries) == 0 {
 		return ret, fmt.Errorf("no programs to execute")
 	}
@@ -706,18 +947,25 @@ func (ctx *reproContext) testProgs(entries []*prog.LogEntry, duration time.Durat
 			Opts:     opts,
 			Duration: duration,
 		}, ctx.reproLogf)
-	}, strict)
+	}, strict, probe)
 }
 
 func (ctx *reproContext) testCProg(p *prog.Prog, duration time.Duration, opts csource.Options,
+	strict bool) (ret verdict, err error) {
+	return ctx.testCSource(p, nil, duration, opts, strict)
+}
+
+// testCSource runs the C source src, or the one generated from p if src is nil.
+func (ctx *reproContext) testCSource(p *prog.Prog, src []byte, duration time.Duration, opts csource.Options,
 	strict bool) (ret verdict, err error) {
 	return ctx.getVerdict(func() (*instance.RunResult, error) {
 		return ctx.exec.Run(ctx.ctx, instance.ExecParams{
 			CProg:    p,
+			CSource:  src,
 			Opts:     opts,
 			Duration: duration,
 		}, ctx.reproLogf)
-	}, strict)
+	}, strict, false)
 }
 
 func (ctx *reproContext) reproLogf(level int, format string, args ...interface{}) {
@@ -726,13 +974,15 @@ func (ctx *reproContext) reproLogf(level int, format string, args ...interface{}
 	}
 	prefix := fmt.Sprintf("reproducing crash '%v': ", ctx.crashTitle)
 	log.Logf(level, prefix+format, args...)
+	ctx.logMu.Lock()
+	defer ctx.logMu.Unlock()
 	ctx.stats.Log = append(ctx.stats.Log, []byte(fmt.Sprintf(format, args...)+"\n")...)
 }
 
 func (ctx *reproContext) bisectProgs(progs []*prog.LogEntry, pred func([]*prog.LogEntry) (bool, error)) (
 	[]*prog.LogEntry, error) {
 	// Set up progs bisection.
-	ctx.reproLogf(3, "bisect: bisecting %d programs", len(progs))
+	ctx.reproLogf(3, "bisect: bisecting %d programs (%d in parallel)", len(progs), ctx.parallel)
 	minimizePred := func(progs []*prog.LogEntry) (bool, error) {
 		// Don't waste time testing empty crash log.
 		if len(progs) == 0 {
@@ -749,6 +999,7 @@ func (ctx *reproContext) bisectProgs(progs []*prog.LogEntry, pred func([]*prog.L
 	ret, err := minimize.SliceWithFixed(minimize.Config[*prog.LogEntry]{
 		Pred:      minimizePred,
 		MaxChunks: chunks,
+		Parallel:  ctx.parallel,
 		Logf: func(msg string, args ...interface{}) {
 			ctx.reproLogf(3, "bisect: "+msg, args...)
 		},
@@ -783,24 +1034,7 @@ func (pw *poolWrapper) Run(ctx context.Context, params instance.ExecParams,
 	var result *instance.RunResult
 	var err error
 	runErr := pw.pool.Run(ctx, func(ctx context.Context, inst *vm.Instance, updInfo dispatcher.UpdateInfo) {
-		updInfo(func(info *dispatcher.Info) {
-			typ := "syz"
-			if params.CProg != nil {
-				typ = "C"
-			}
-			info.Status = fmt.Sprintf("reproducing (%s, %.1f min)", typ, params.Duration.Minutes())
-		})
-		var ret *instance.ExecProgInstance
-		ret, err = instance.SetupExecProg(inst, pw.cfg, pw.reporter,
-			&instance.OptionalConfig{Logf: logf})
-		if err != nil {
-			return
-		}
-		if params.CProg != nil {
-			result, err = ret.RunCProg(params)
-		} else {
-			result, err = ret.RunSyzProg(params)
-		}
+		result, err = pw.runInstance(inst, updInfo, params, logf)
 	})
 	if runErr != nil {
 		return nil, runErr
@@ -808,11 +1042,38 @@ func (pw *poolWrapper) Run(ctx context.Context, params instance.ExecParams,
 	return result, err
 }
 
+func (pw *poolWrapper) runInstance(inst *vm.Instance, updInfo dispatcher.UpdateInfo, params instance.ExecParams,
+	logf instance.ExecutorLogger) (*instance.RunResult, error) {
+	updInfo(func(info *dispatcher.Info) {
+		typ := "syz"
+		if params.CProg != nil {
+			typ = "C"
+		}
+		info.Status = fmt.Sprintf("reproducing (%s, %.1f min)", typ, params.Duration.Minutes())
+	})
+	ret, err := instance.SetupExecProg(inst, pw.cfg, pw.reporter,
+		&instance.OptionalConfig{Logf: logf})
+	if err != nil {
+		return nil, err
+	}
+	if params.CProg != nil {
+		return ret.RunCProg(params)
+	}
+	return ret.RunSyzProg(params)
+}
+
 type Simplify func(opts *csource.Options) bool
 
 var progSimplifies = []Simplify{
 	func(opts *csource.Options) bool {
-		if opts.Collide || !opts.Threaded {
+		if opts.Sched == "" {
+			return false
+		}
+		opts.Sched = ""
+		return true
+	},
+	func(opts *csource.Options) bool {
+		if opts.Collide || !opts.Threaded || opts.Sched != "" {
 			return false
 		}
 		opts.Threaded = false
@@ -985,7 +1246,9 @@ func (stats *Stats) FullLog() []byte {
 		return nil
 	}
 	return []byte(fmt.Sprintf("Extracting prog: %v\nMinimizing prog: %v\n"+
-		"Simplifying prog options: %v\nExtracting C: %v\nSimplifying C: %v\n\n\n%s",
+		"Simplifying prog options: %v\nExtracting C: %v\nSimplifying C: %v\nMinimizing C: %v\n"+
+		"Test runs: %v (up to %v in parallel)\n\n\n%s",
 		stats.ExtractProgTime, stats.MinimizeProgTime,
-		stats.SimplifyProgTime, stats.ExtractCTime, stats.SimplifyCTime, stats.Log))
+		stats.SimplifyProgTime, stats.ExtractCTime, stats.SimplifyCTime, stats.MinimizeCTime,
+		stats.TestRuns, stats.Parallel, stats.Log))
 }
@@ -4,11 +4,14 @@
 package repro
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/rand"
 	"regexp"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/syzkaller/pkg/csource"
@@ -30,9 +33,18 @@ func initTest(t *testing.T) (*rand.Rand, int) {
 }
 
 func TestBisect(t *testing.T) {
+	for _, parallel := range []int{1, 4} {
+		t.Run(fmt.Sprint(parallel), func(t *testing.T) {
+			testBisect(t, parallel)
+		})
+	}
+}
+
+func testBisect(t *testing.T, parallel int) {
 	ctx := &reproContext{
-		stats: new(Stats),
-		logf:  t.Logf,
+		stats:    new(Stats),
+		logf:     t.Logf,
+		parallel: parallel,
 	}
 
 	rd, iters := initTest(t)
@@ -90,6 +102,7 @@ func TestSimplifies(t *testing.T) {
 		Cgroups:      true,
 		UseTmpDir:    true,
 		HandleSegv:   true,
+		Sched:        csource.SchedYield,
 	}
 	var check func(opts csource.Options, i int)
 	check = func(opts csource.Options, i int) {
@@ -110,22 +123,45 @@ func TestSimplifies(t *testing.T) {
 type testExecInterface struct {
 	// For now only do the simplest imitation.
 	run func([]byte) (*instance.RunResult, error)
+	// If set, also receives the options.
+	runOpts func([]byte, csource.Options) (*instance.RunResult, error)
+	// If set, receives all parameters.
+	runParams func(instance.ExecParams) (*instance.RunResult, error)
 }
 
 func (tei *testExecInterface) Run(_ context.Context, params instance.ExecParams,
 	_ instance.ExecutorLogger) (*instance.RunResult, error) {
+	if tei.runParams != nil {
+		return tei.runParams(params)
+	}
 	syzProg := params.SyzProg
 	if params.CProg != nil {
 		syzProg = params.CProg.Serialize()
 	}
+	if tei.runOpts != nil {
+		return tei.runOpts(syzProg, params.Opts)
+	}
 	return tei.run(syzProg)
 }
 
 func runTestRepro(t *testing.T, log string, exec execInterface) (*Result, *Stats, error) {
+	return runTestReproParallel(t, log, 1, 1, exec)
+}
+
+func runTestReproParallel(t *testing.T, log string, parallel, attempts int, exec execInterface) (
+	*Result, *Stats, error) {
+	return runTestReproEnv(t, log, Environment{
+		Parallel: parallel,
+		Attempts: attempts,
+	}, exec)
+}
+
+func runTestReproEnv(t *testing.T, log string, env Environment, exec execInterface) (*Result, *Stats, error) {
 	mgrConfig := &mgrconfig.Config{
 		Derived: mgrconfig.Derived{
 			TargetOS:     targets.Linux,
 			TargetVMArch: targets.AMD64,
+			Timeouts:     targets.Get(targets.Linux, targets.AMD64).Timeouts(1),
 		},
 		Sandbox: "namespace",
 	}
@@ -138,8 +174,10 @@ func runTestRepro(t *testing.T, log string, exec execInterface) (*Result, *Stats
 	if err != nil {
 		t.Fatal(err)
 	}
-	return runInner(context.Background(), []byte(log), mgrConfig,
-		flatrpc.AllFeatures, reporter, false, exec)
+	env.Config = mgrConfig
+	env.Features = flatrpc.AllFeatures
+	env.Reporter = reporter
+	return runInner(context.Background(), []byte(log), env, exec)
 }
 
 const testReproLog = `
@@ -188,6 +226,312 @@ alarm(0xa)
 	}
 }
 
+func TestParallelRepro(t *testing.T) {
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	// Make the log long enough so that it's bisected.
+	log := testReproLog
+	for i := 0; i < 20; i++ {
+		log += fmt.Sprintf("2015/12/21 12:19:%02d executing program %d:\ngetpid()\n", i, i%4)
+	}
+	log += "2015/12/21 12:20:00 executing program 0:\ngetuid()\n"
+	result, stats, err := runTestReproParallel(t, log, 4, 1, &testExecInterface{
+		run: func(log []byte) (*instance.RunResult, error) {
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return testExecRunner(log)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(`pause()
+alarm(0xa)
+`, string(result.Prog.Serialize())); diff != "" {
+		t.Fatal(diff)
+	}
+	if maxRunning < 2 || maxRunning > 4 {
+		t.Fatalf("unexpected number of concurrent runs: %v", maxRunning)
+	}
+	if stats.Parallel != 4 || stats.TestRuns == 0 {
+		t.Fatalf("unexpected stats: parallel=%v test runs=%v", stats.Parallel, stats.TestRuns)
+	}
+}
+
+func TestFlakyRepro(t *testing.T) {
+	rnd, _ := initTest(t)
+	var mu sync.Mutex
+	result, _, err := runTestReproParallel(t, testReproLog, 1, 20, &testExecInterface{
+		run: func(log []byte) (*instance.RunResult, error) {
+			res, err := testExecRunner(log)
+			mu.Lock()
+			defer mu.Unlock()
+			// The crash reproduces only in 1/3 of runs.
+			if res.Report != nil && rnd.Intn(3) != 0 {
+				res.Report = nil
+			}
+			return res, err
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result == nil {
+		t.Fatal("failed to reproduce a flaky crash")
+	}
+	if result.Runs != 20 || result.Crashes == 0 || result.Crashes == 20 {
+		t.Fatalf("unexpected reproduction rate: %v/%v", result.Crashes, result.Runs)
+	}
+}
+
+func TestProbeRuns(t *testing.T) {
+	// Make the log long enough so that it's bisected.
+	log := testReproLog
+	for i := 0; i < 20; i++ {
+		log += fmt.Sprintf("2015/12/21 12:19:%02d executing program %d:\ngetpid()\n", i, i%4)
+	}
+	var mu sync.Mutex
+	runs := map[string]int{}
+	crashed := map[string]bool{}
+	result, _, err := runTestReproParallel(t, log, 1, 20, &testExecInterface{
+		run: func(log []byte) (*instance.RunResult, error) {
+			res, err := testExecRunner(log)
+			mu.Lock()
+			defer mu.Unlock()
+			runs[string(log)]++
+			if res.Report != nil {
+				crashed[string(log)] = true
+			}
+			return res, err
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result == nil {
+		t.Fatal("failed to reproduce")
+	}
+	probes := 0
+	for log, n := range runs {
+		if crashed[log] || bytes.Count([]byte(log), []byte("executing program")) < 2 {
+			continue
+		}
+		// The log is a bisection probe that never crashed.
+		probes++
+		if n > maxProbeRuns {
+			t.Errorf("a negative bisection probe was run %v times", n)
+		}
+	}
+	if probes == 0 {
+		t.Fatal("no negative bisection probes")
+	}
+}
+
+func TestMeasureRateErrors(t *testing.T) {
+	runs := 0
+	result, _, err := runTestReproParallel(t, testReproLog, 1, 3, &testExecInterface{
+		run: func(log []byte) (*instance.RunResult, error) {
+			runs++
+			return testExecRunner(log)
+		},
+	})
+	if err != nil || result == nil || result.Runs != 3 {
+		t.Fatalf("failed to reproduce: result=%v err=%v", result, err)
+	}
+	// The last two measurement runs fail, the reproducer and the first run must be kept.
+	limit := runs - 2
+	runs = 0
+	result, _, err = runTestReproParallel(t, testReproLog, 1, 3, &testExecInterface{
+		run: func(log []byte) (*instance.RunResult, error) {
+			runs++
+			if runs > limit {
+				return nil, fmt.Errorf("some random error")
+			}
+			return testExecRunner(log)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result == nil || result.Report == nil {
+		t.Fatal("the reproducer was lost")
+	}
+	if result.Runs != 1 || result.Crashes != 1 {
+		t.Fatalf("unexpected reproduction rate: %v/%v", result.Crashes, result.Runs)
+	}
+}
+
+func TestSPRT(t *testing.T) {
+	runs := func(results ...bool) (int, bool) {
+		var test sprt
+		for i, crashed := range results {
+			if test.add(crashed) {
+				return i + 1, test.crashed()
+			}
+		}
+		return len(results), test.crashed()
+	}
+	n, crashed := runs(true, true)
+	if n != 2 || !crashed {
+		t.Fatalf("reliable crash: runs=%v crashed=%v", n, crashed)
+	}
+	n, crashed = runs(make([]bool, 20)...)
+	if n != 12 || crashed {
+		t.Fatalf("no crash: runs=%v crashed=%v", n, crashed)
+	}
+	// Undecided tests fall back to the more likely hypothesis.
+	n, crashed = runs(true, false)
+	if n != 2 || !crashed {
+		t.Fatalf("undecided: runs=%v crashed=%v", n, crashed)
+	}
+}
+
+func TestSchedRepro(t *testing.T) {
+	const log = `
+2015/12/21 12:18:05 executing program 1:
+getpid()
+pause()
+alarm(0xa)
+`
+	exec := &testExecInterface{
+		runOpts: func(log []byte, opts csource.Options) (*instance.RunResult, error) {
+			// The race only reproduces if threads run on different CPUs.
+			if opts.Sched != csource.SchedSpread {
+				return &instance.RunResult{}, nil
+			}
+			return testExecRunner(log)
+		},
+	}
+	// Scheduling perturbations are not tried by default.
+	result, _, err := runTestRepro(t, log, exec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != nil {
+		t.Fatal("reproduced without scheduling perturbations")
+	}
+	result, _, err = runTestReproEnv(t, log, Environment{Sched: true}, exec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result == nil {
+		t.Fatal("failed to reproduce")
+	}
+	if result.Opts.Sched != csource.SchedSpread {
+		t.Fatalf("wrong sched option: %q", result.Opts.Sched)
+	}
+	if diff := cmp.Diff(`pause()
+alarm(0xa)
+`, string(result.Prog.Serialize())); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestMinimizeCRepro(t *testing.T) {
+	exec := &testExecInterface{
+		runParams: func(params instance.ExecParams) (*instance.RunResult, error) {
+			if params.CProg == nil {
+				return testExecRunner(params.SyzProg)
+			}
+			src := params.CSource
+			if src == nil {
+				var err error
+				src, err = csource.Write(params.CProg, params.Opts)
+				if err != nil {
+					return nil, err
+				}
+			}
+			// The crash needs the temporary dir.
+			if !bytes.Contains(src, []byte("\tuse_temporary_dir();\n")) {
+				return &instance.RunResult{}, nil
+			}
+			return testExecRunner(params.CProg.Serialize())
+		},
+	}
+	// The C reproducer is not minimized by default.
+	result, stats, err := runTestRepro(t, testReproLog, exec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result == nil || !result.CRepro || result.CSource != nil || stats.MinimizeCTime != 0 {
+		t.Fatalf("unexpected default C reproducer: %+v", result)
+	}
+	result, stats, err = runTestReproEnv(t, testReproLog, Environment{MinimizeC: true}, exec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result == nil || !result.CRepro {
+		t.Fatalf("failed to get a C reproducer")
+	}
+	if result.CSource == nil || stats.MinimizeCTime == 0 {
+		t.Fatalf("the C reproducer was not minimized")
+	}
+	if !bytes.Contains(result.CSource, []byte("\tuse_temporary_dir();\n")) {
+		t.Fatalf("the minimized C reproducer does not reproduce:\n%s", result.CSource)
+	}
+	src, err := csource.Write(result.Prog, result.Opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.CSource) >= len(src) {
+		t.Fatalf("the C reproducer was not minimized: %v -> %v bytes", len(src), len(result.CSource))
+	}
+	if res, err := result.WriteC(); err != nil || !bytes.Equal(res, result.CSource) {
+		t.Fatalf("WriteC did not return the minimized source: %v", err)
+	}
+}
+
+func TestSnapshotRequest(t *testing.T) {
+	target, err := prog.GetTarget(targets.Linux, targets.AMD64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sw := newSnapshotWrapper(context.Background(), &poolWrapper{
+		cfg: &mgrconfig.Config{Derived: mgrconfig.Derived{Target: target}},
+	}, flatrpc.AllFeatures, 1)
+	defer sw.stop()
+	single := []byte("executing program 1:\ngetpid()\npause()\n")
+	opts := csource.Options{
+		Threaded: true,
+		Repeat:   true,
+		Sandbox:  "none",
+		Sched:    csource.SchedSpread,
+	}
+	req := sw.request(context.Background(), instance.ExecParams{SyzProg: single, Opts: opts}, nil)
+	if req == nil {
+		t.Fatal("a single program run must be executed from a snapshot")
+	}
+	if want := flatrpc.ExecFlagThreaded | flatrpc.ExecFlagSchedSpread; req.flags != want {
+		t.Errorf("got exec flags %v, want %v", req.flags, want)
+	}
+	if req.env&flatrpc.ExecEnvSandboxNone == 0 {
+		t.Errorf("sandbox flag is not set: %v", req.env)
+	}
+	if len(req.p.Calls) != 2 {
+		t.Errorf("got %v calls, want 2", len(req.p.Calls))
+	}
+
+	fallback := map[string]instance.ExecParams{
+		"several programs": {
+			SyzProg: append(single, "executing program 2:\ngetuid()\n"...),
+			Opts:    opts,
+		},
+		"C program": {CProg: req.p, Opts: opts},
+		"leak":      {SyzProg: single, Opts: csource.Options{Leak: true, Sandbox: "none"}},
+	}
+	for name, params := range fallback {
+		if sw.request(context.Background(), params, nil) != nil {
+			t.Errorf("%v: the run must not be executed from a snapshot", name)
+		}
+	}
+}
+
 // There happen to be transient errors like ssh/scp connection failures.
 // Ensure that the code just retries.
 func TestVMErrorResilience(t *testing.T) {
@@ -0,0 +1,256 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package repro
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/google/syzkaller/pkg/csource"
+	"github.com/google/syzkaller/pkg/flatrpc"
+	"github.com/google/syzkaller/pkg/instance"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/vm"
+	"github.com/google/syzkaller/vm/dispatcher"
+)
+
+// snapshotWrapper executes test runs on VMs that are restored from a snapshot before each run.
+// The snapshot is taken once per VM after boot and executor setup, so all runs start from
+// the same kernel state and don't need a VM reboot.
+// The executor runs a single program per restore, so only single program runs are executed
+// this way, and always in a single executor process regardless of the Procs option.
+// All other runs, and all runs on VM types that don't support snapshots, fall back to poolWrapper.
+// The runs are served by a fixed number of sessions (one per concurrent test run of the reproduction).
+// A session takes a VM only while there are queued requests and releases it once it becomes idle.
+type snapshotWrapper struct {
+	fallback    *poolWrapper
+	features    flatrpc.Feature
+	requests    chan *snapshotRequest
+	unsupported atomic.Bool
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+type snapshotRequest struct {
+	ctx        context.Context
+	params     instance.ExecParams
+	logf       instance.ExecutorLogger
+	p          *prog.Prog
+	env        flatrpc.ExecEnv
+	sandboxArg int64
+	flags      flatrpc.ExecFlag
+	done       chan snapshotResponse
+}
+
+type snapshotResponse struct {
+	res *instance.RunResult
+	err error
+}
+
+func newSnapshotWrapper(ctx context.Context, fallback *poolWrapper, features flatrpc.Feature,
+	sessions int) *snapshotWrapper {
+	ctx, cancel := context.WithCancel(ctx)
+	sw := &snapshotWrapper{
+		fallback: fallback,
+		features: features,
+		requests: make(chan *snapshotRequest),
+		cancel:   cancel,
+	}
+	for i := 0; i < max(sessions, 1); i++ {
+		sw.wg.Add(1)
+		go func() {
+			defer sw.wg.Done()
+			sw.session(ctx)
+		}()
+	}
+	return sw
+}
+
+// stop terminates all sessions, it must be called once there are no more runs.
+func (sw *snapshotWrapper) stop() {
+	sw.cancel()
+	sw.wg.Wait()
+}
+
+func (sw *snapshotWrapper) Run(ctx context.Context, params instance.ExecParams,
+	logf instance.ExecutorLogger) (*instance.RunResult, error) {
+	req := sw.request(ctx, params, logf)
+	if req == nil || sw.unsupported.Load() {
+		return sw.fallback.Run(ctx, params, logf)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Sessions always reply to the requests they have received.
+	select {
+	case sw.requests <- req:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	resp := <-req.done
+	return resp.res, resp.err
+}
+
+// request returns nil if the run cannot be executed from a snapshot.
+func (sw *snapshotWrapper) request(ctx context.Context, params instance.ExecParams,
+	logf instance.ExecutorLogger) *snapshotRequest {
+	opts := params.Opts
+	if params.CProg != nil || opts.Leak || opts.Fault {
+		return nil
+	}
+	entries := sw.fallback.cfg.Target.ParseLog(params.SyzProg, prog.NonStrict)
+	if len(entries) != 1 {
+		return nil
+	}
+	sandbox, err := flatrpc.SandboxToFlags(opts.Sandbox)
+	if err != nil {
+		return nil
+	}
+	flags, err := flatrpc.SchedToFlags(opts.Sched)
+	if err != nil {
+		return nil
+	}
+	if opts.Threaded {
+		flags |= flatrpc.ExecFlagThreaded
+	}
+	return &snapshotRequest{
+		ctx:        ctx,
+		params:     params,
+		logf:       logf,
+		p:          entries[0].P,
+		env:        sandbox | csource.FeaturesToFlags(sw.features, nil),
+		sandboxArg: int64(opts.SandboxArg),
+		flags:      flags,
+		done:       make(chan snapshotResponse, 1),
+	}
+}
+
+func (req *snapshotRequest) reply(res *instance.RunResult, err error) {
+	req.done <- snapshotResponse{res, err}
+}
+
+// session serves requests until the context is cancelled. For each request it takes a VM
+// and serves the following requests on it while they are queued and have the same environment
+// (a VM snapshot captures the executor environment).
+func (sw *snapshotWrapper) session(ctx context.Context) {
+	for {
+		var req *snapshotRequest
+		select {
+		case req = <-sw.requests:
+		case <-ctx.Done():
+			return
+		}
+		for req != nil {
+			if sw.unsupported.Load() {
+				req.reply(sw.fallback.Run(req.ctx, req.params, req.logf))
+				break
+			}
+			var next *snapshotRequest
+			started := false
+			err := sw.fallback.pool.Run(req.ctx, func(ctx context.Context, inst *vm.Instance,
+				updInfo dispatcher.UpdateInfo) {
+				started = true
+				next = sw.serve(ctx, inst, updInfo, req)
+			})
+			if !started {
+				req.reply(nil, err)
+				break
+			}
+			req = next
+		}
+	}
+}
+
+// serve replies to req and to all the following queued requests with the same environment.
+// It returns the first request it did not reply to, or nil once there are no queued requests.
+func (sw *snapshotWrapper) serve(ctx context.Context, inst *vm.Instance, updInfo dispatcher.UpdateInfo,
+	req *snapshotRequest) *snapshotRequest {
+	if !inst.SnapshotSupported() {
+		sw.unsupported.Store(true)
+		req.reply(sw.fallback.runInstance(inst, updInfo, req.params, req.logf))
+		return nil
+	}
+	updInfo(func(info *dispatcher.Info) {
+		info.Status = "reproducing (snapshot)"
+	})
+	builder := flatbuffers.NewBuilder(0)
+	if err := instance.SetupSnapshot(ctx, inst, sw.fallback.cfg, sw.fallback.reporter, builder,
+		sw.features, req.env, req.sandboxArg); err != nil {
+		// Don't try snapshots again, the retried run will take the usual path.
+		log.Logf(0, "repro: VM snapshot setup failed, falling back to reboots: %v", err)
+		sw.unsupported.Store(true)
+		req.reply(nil, err)
+		return nil
+	}
+	env, sandboxArg := req.env, req.sandboxArg
+	for {
+		res, err := sw.run(ctx, inst, builder, req)
+		req.reply(res, err)
+		if err != nil {
+			// The VM is in an unknown state, let it reboot.
+			return nil
+		}
+		select {
+		case req = <-sw.requests:
+		default:
+			// Don't hold the VM while there is nothing to run.
+			return nil
+		}
+		if req.env != env || req.sandboxArg != sandboxArg {
+			return req
+		}
+	}
+}
+
+// run executes the request program from the snapshot. With the Repeat option the program is
+// executed from the snapshot again and again until it crashes or the run duration expires.
+func (sw *snapshotWrapper) run(ctx context.Context, inst *vm.Instance, builder *flatbuffers.Builder,
+	req *snapshotRequest) (*instance.RunResult, error) {
+	progData, err := req.p.SerializeForExec()
+	if err != nil {
+		return nil, fmt.Errorf("program serialization failed: %w", err)
+	}
+	msg := flatrpc.SnapshotRequestT{
+		ExecFlags: req.flags,
+		NumCalls:  int32(len(req.p.Calls)),
+		ProgData:  progData,
+	}
+	builder.Reset()
+	builder.Finish(msg.Pack(builder))
+	input := builder.FinishedBytes()
+	reporter := sw.fallback.reporter
+	start := time.Now()
+	var output []byte
+	for {
+		_, out, err := inst.RunSnapshot(input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to run snapshot: %w", err)
+		}
+		output = out
+		if rep := reporter.Parse(output); rep != nil {
+			if err := reporter.Symbolize(rep); err != nil {
+				req.logf(0, "failed to symbolize report: %v", err)
+			}
+			req.logf(2, "program crashed: %v", rep.Title)
+			return &instance.RunResult{
+				Output:   output,
+				Report:   rep,
+				Duration: time.Since(start),
+			}, nil
+		}
+		if !req.params.Opts.Repeat || time.Since(start) >= req.params.Duration || ctx.Err() != nil {
+			break
+		}
+	}
+	req.logf(2, "program did not crash")
+	return &instance.RunResult{
+		Output:   output,
+		Duration: time.Since(start),
+	}, nil
+}
@@ -51,6 +51,7 @@ func RunStrace(result *Result, cfg *mgrconfig.Config, reporter *report.Reporter,
 		if result.CRepro {
 			log.Logf(1, "running C repro under strace")
 			params.CProg = result.Prog
+			params.CSource = result.CSource
 			runRes, err = ret.RunCProg(params)
 		} else {
 			log.Logf(1, "running syz repro under strace")
@@ -0,0 +1,50 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package repro
+
+import (
+	"math"
+)
+
+// Parameters of the sequential probability ratio test used to decide whether a candidate
+// reproduces the crash. The test chooses between two hypotheses: the candidate crashes the kernel
+// with probability sprtRateNone (i.e. it does not reproduce the crash, but there are unrelated
+// spurious crashes), or with probability sprtRateRepro (it reproduces the crash, possibly flaky).
+const (
+	sprtRateNone  = 0.02
+	sprtRateRepro = 0.25
+	// Probabilities of the false positive and false negative verdicts.
+	sprtAlpha = 0.05
+	sprtBeta  = 0.05
+)
+
+var (
+	sprtCrashed    = math.Log(sprtRateRepro / sprtRateNone)
+	sprtNotCrashed = math.Log((1 - sprtRateRepro) / (1 - sprtRateNone))
+	sprtUpper      = math.Log((1 - sprtBeta) / sprtAlpha)
+	sprtLower      = math.Log(sprtBeta / (1 - sprtAlpha))
+)
+
+// sprt accumulates the log-likelihood ratio of the two hypotheses over test runs.
+// With the default parameters, a reliable reproducer is accepted after 2 runs,
+// and a candidate that never crashes is rejected after 12 runs.
+type sprt struct {
+	llr float64
+}
+
+// add records the result of a test run and returns whether the test has reached a decision.
+func (s *sprt) add(crashed bool) bool {
+	if crashed {
+		s.llr += sprtCrashed
+	} else {
+		s.llr += sprtNotCrashed
+	}
+	return s.llr >= sprtUpper || s.llr <= sprtLower
+}
+
+// crashed returns the current verdict. If the test has not reached a decision
+// (the number of runs is limited), it returns the more likely hypothesis.
+func (s *sprt) crashed() bool {
+	return s.llr > 0
+}
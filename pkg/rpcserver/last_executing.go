@@ -9,6 +9,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/google/syzkaller/pkg/flatrpc"
 	"github.com/google/syzkaller/pkg/report"
 	"github.com/google/syzkaller/prog"
 )
@@ -27,6 +28,12 @@ type ExecRecord struct {
 	Proc int
 	Prog []byte
 	Time time.Duration
+	// Options the program was executed with (only set for records parsed by ParseSession).
+	Opts *flatrpc.ExecOpts
+	// Signal of the program if it has finished executing and signal was collected.
+	Signal []uint64
+
+	signal [][]uint64
 }
 
 func MakeLastExecuting(procs, count int) *LastExecuting {
@@ -52,6 +59,29 @@ func (last *LastExecuting) Note(id, proc int, progData []byte, now time.Duration
 	}
 }
 
+// Executed notes the signal of the program if it's still among the last executing programs.
+func (last *LastExecuting) Executed(id, proc int, info *flatrpc.ProgInfo) {
+	if proc < 0 || proc >= len(last.positions) {
+		return
+	}
+	for i := proc * last.count; i < (proc+1)*last.count; i++ {
+		rec := &last.procs[i]
+		if rec.ID != id || rec.Prog == nil {
+			continue
+		}
+		// The signal is not copied since it's not modified after execution.
+		for _, call := range info.Calls {
+			if len(call.Signal) != 0 {
+				rec.signal = append(rec.signal, call.Signal)
+			}
+		}
+		if info.Extra != nil && len(info.Extra.Signal) != 0 {
+			rec.signal = append(rec.signal, info.Extra.Signal)
+		}
+		return
+	}
+}
+
 // Note a hanged program.
 func (last *LastExecuting) Hanged(id, proc int, progData []byte, now time.Duration) {
 	last.hanged = append(last.hanged, ExecRecord{
@@ -82,6 +112,10 @@ func (last *LastExecuting) Collect() []ExecRecord {
 			break
 		}
 		procs[i].Time = max - procs[i].Time
+		for _, signal := range procs[i].signal {
+			procs[i].Signal = append(procs[i].Signal, signal...)
+		}
+		procs[i].signal = nil
 	}
 	return procs
 }
@@ -6,6 +6,8 @@ package rpcserver
 import (
 	"testing"
 
+	"github.com/google/syzkaller/pkg/flatrpc"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -76,3 +78,24 @@ func TestLastExecutingHanged(t *testing.T) {
 		{ID: 9, Proc: 0, Prog: []byte("prog9"), Time: 0},
 	})
 }
+
+func TestLastExecutingSignal(t *testing.T) {
+	last := MakeLastExecuting(2, 2)
+	last.Note(1, 0, []byte("prog1"), 1)
+	last.Note(2, 1, []byte("prog2"), 2)
+	last.Note(3, 0, []byte("prog3"), 3)
+	last.Executed(3, 0, &flatrpc.ProgInfo{
+		Calls: []*flatrpc.CallInfo{{Signal: []uint64{1, 2}}, {}, {Signal: []uint64{3}}},
+		Extra: &flatrpc.CallInfo{Signal: []uint64{4}},
+	})
+	// Wrong proc.
+	last.Executed(3, 1, &flatrpc.ProgInfo{Calls: []*flatrpc.CallInfo{{Signal: []uint64{5}}}})
+	last.Note(4, 0, []byte("prog4"), 4)
+	// The record was already evicted.
+	last.Executed(1, 0, &flatrpc.ProgInfo{Calls: []*flatrpc.CallInfo{{Signal: []uint64{6}}}})
+	assert.Equal(t, last.Collect(), []ExecRecord{
+		{ID: 2, Proc: 1, Prog: []byte("prog2"), Time: 2},
+		{ID: 3, Proc: 0, Prog: []byte("prog3"), Time: 1, Signal: []uint64{1, 2, 3, 4}},
+		{ID: 4, Proc: 0, Prog: []byte("prog4"), Time: 0},
+	})
+}
@@ -30,6 +30,9 @@ type LocalConfig struct {
 	HandleInterrupts bool
 	// Run executor under gdb.
 	GDB bool
+	// Communicate with the executor via shared memory instead of a TCP socket.
+	// Falls back to the socket if shared memory is not supported on the host.
+	SharedMemory bool
 	// Can be used to intercept stdout/stderr output.
 	OutputWriter   io.Writer
 	MaxSignal      []uint64
@@ -99,7 +102,7 @@ func cancelOnInterrupts(ctx context.Context) context.Context {
 
 type local struct {
 	cfg       *LocalConfig
-	serv      Server
+	serv      *server
 	setupDone chan bool
 }
 
@@ -132,6 +135,18 @@ func (ctx *local) RunInstance(baseCtx context.Context, id int) error {
 	cfg := ctx.cfg
 	bin := cfg.Executor
 	args := []string{"runner", fmt.Sprint(id), "localhost", fmt.Sprint(ctx.serv.Port())}
+	var shmemConn *flatrpc.Conn
+	var shmemFiles []*os.File
+	if cfg.SharedMemory {
+		var err error
+		shmemConn, shmemFiles, err = flatrpc.NewShmemConn()
+		if err != nil {
+			log.Logf(0, "failed to setup shared memory transport, using TCP: %v", err)
+		} else {
+			// The files are passed to the executor starting from fd 3.
+			args = []string{"runner", fmt.Sprint(id), "shmem", "3"}
+		}
+	}
 	if cfg.GDB {
 		bin = "gdb"
 		args = append([]string{
@@ -153,9 +168,29 @@ func (ctx *local) RunInstance(baseCtx context.Context, id int) error {
 	if cfg.GDB {
 		cmd.Stdin = os.Stdin
 	}
-	if err := cmd.Start(); err != nil {
+	cmd.ExtraFiles = shmemFiles
+	err := cmd.Start()
+	for _, file := range shmemFiles {
+		file.Close()
+	}
+	if err != nil {
+		if shmemConn != nil {
+			shmemConn.Close()
+		}
 		return fmt.Errorf("failed to start executor: %w", err)
 	}
+	// For TCP connections handleConn is called by the server when the executor connects.
+	// It reports errors after the handshake via connErr, but early errors are only returned.
+	handshakeErr := make(chan error, 1)
+	if shmemConn != nil {
+		go func() {
+			err := ctx.serv.handleConn(baseCtx, shmemConn)
+			shmemConn.Close()
+			if err != nil {
+				handshakeErr <- err
+			}
+		}()
+	}
 	var retErr error
 	select {
 	case <-baseCtx.Done():
@@ -164,8 +199,11 @@ func (ctx *local) RunInstance(baseCtx context.Context, id int) error {
 			retErr = fmt.Errorf("connection error: %w", err)
 		}
 		cmd.Process.Kill()
+	case err := <-handshakeErr:
+		retErr = fmt.Errorf("connection error: %w", err)
+		cmd.Process.Kill()
 	}
-	err := cmd.Wait()
+	err = cmd.Wait()
 	if retErr == nil {
 		retErr = fmt.Errorf("executor process exited: %w", err)
 	}
@@ -10,11 +10,13 @@ import (
 	"fmt"
 	"math/rand"
 	"net/url"
+	"path/filepath"
 	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/google/syzkaller/pkg/cover"
 	"github.com/google/syzkaller/pkg/cover/backend"
@@ -50,8 +52,15 @@ type Config struct {
 	DebugTimeouts bool
 	Procs         int
 	Slowdown      int
-	pcBase        uint64
-	localModules  []*vminfo.KernelModule
+	// Max number of requests sent to the executor in a single message (0 disables batching).
+	ExecBatch int
+	// Ask executors to send signal/cover in the compact delta encoding.
+	CompactCover bool
+	// If set, all programs executed in each VM are recorded to a file in this dir.
+	// The files are kept only for crashed VMs.
+	SessionDir   string
+	pcBase       uint64
+	localModules []*vminfo.KernelModule
 
 	// RPCServer closes the channel once the machine check has begun. Used for fault injection during testing.
 	machineCheckStarted chan struct{}
@@ -81,6 +90,7 @@ type Server interface {
 	ShutdownInstance(id int, crashed bool, extraExecs ...report.ExecutorInfo) ([]ExecRecord, []byte)
 	StopFuzzing(id int)
 	DistributeSignalDelta(plus signal.Signal)
+	ExecutorHealth() []queue.ExecutorHealth
 }
 
 type server struct {
@@ -159,6 +169,10 @@ func New(cfg *RemoteConfig) (Server, error) {
 	if !cfg.Experimental.RemoteCover {
 		features &= ^flatrpc.FeatureExtraCoverage
 	}
+	sessionDir := ""
+	if cfg.Experimental.RecordSessions {
+		sessionDir = filepath.Join(cfg.Workdir, "sessions")
+	}
 	return newImpl(&Config{
 		Config: vminfo.Config{
 			Target:     cfg.Target,
@@ -181,6 +195,9 @@ func New(cfg *RemoteConfig) (Server, error) {
 		PrintMachineCheck: true,
 		Procs:             cfg.Procs,
 		Slowdown:          cfg.Timeouts.Slowdown,
+		ExecBatch:         cfg.Experimental.ExecBatch,
+		CompactCover:      cfg.Experimental.CompactCover,
+		SessionDir:        sessionDir,
 		pcBase:            pcBase,
 		localModules:      cfg.LocalModules,
 	}, cfg.Manager), nil
@@ -211,6 +228,9 @@ func newImpl(cfg *Config, mgr Manager) *server {
 				stat.Rate{}, stat.Graph("executor")),
 			statExecutorRestarts: stat.New("executor restarts",
 				"Number of times executor process was restarted", stat.Rate{}, stat.Graph("executor")),
+			statCoverBytes: stat.New("cover bytes/exec",
+				"Size of signal and coverage received from executors per test program execution",
+				stat.Distribution{}),
 			statExecBufferTooSmall: queue.StatExecBufferTooSmall,
 			statExecs:              cfg.Stats.StatExecs,
 			statNoExecRequests:     queue.StatNoExecRequests,
@@ -319,19 +339,22 @@ func (serv *server) handleConn(ctx context.Context, conn *flatrpc.Conn) error {
 		return fmt.Errorf("unknown VM %v tries to connect", id)
 	}
 
-	err = serv.handleRunnerConn(ctx, runner, conn)
+	err = serv.handleRunnerConn(ctx, runner, conn, connectReq)
 	log.Logf(2, "runner %v: %v", id, err)
 
 	runner.resultCh <- err
 	return nil
 }
 
-func (serv *server) handleRunnerConn(ctx context.Context, runner *Runner, conn *flatrpc.Conn) error {
+func (serv *server) handleRunnerConn(ctx context.Context, runner *Runner, conn *flatrpc.Conn,
+	connectReq *flatrpc.ConnectRequest) error {
 	opts := &handshakeConfig{
-		VMLess:   serv.cfg.VMLess,
-		Files:    serv.checker.RequiredFiles(),
-		Timeouts: serv.timeouts,
-		Callback: serv.handleMachineInfo,
+		VMLess:       serv.cfg.VMLess,
+		Files:        serv.checker.RequiredFiles(),
+		Timeouts:     serv.timeouts,
+		BatchSize:    max(0, min(serv.cfg.ExecBatch, int(connectReq.MaxBatch))),
+		CompactCover: serv.cfg.CompactCover && connectReq.CompactCover,
+		Callback:     serv.handleMachineInfo,
 	}
 	opts.LeakFrames, opts.RaceFrames = serv.mgr.BugFrames()
 	if serv.checkDone.Load() {
@@ -554,6 +577,15 @@ func (serv *server) CreateInstance(id int, injectExec chan<- bool, updInfo dispa
 		updInfo:  updInfo,
 		resultCh: make(chan error, 1),
 	}
+	if serv.cfg.SessionDir != "" {
+		file := filepath.Join(serv.cfg.SessionDir, fmt.Sprintf("vm%v-%v.log.gz", id, time.Now().UnixMilli()))
+		session, err := NewSessionRecorder(file)
+		if err != nil {
+			log.Logf(0, "VM %v: failed to create session recorder: %v", id, err)
+		} else {
+			runner.session = session
+		}
+	}
 	serv.mu.Lock()
 	defer serv.mu.Unlock()
 	if serv.runners[id] != nil {
@@ -592,6 +624,10 @@ func (serv *server) DistributeSignalDelta(plus signal.Signal) {
 	})
 }
 
+func (serv *server) ExecutorHealth() []queue.ExecutorHealth {
+	return serv.execSource.Health()
+}
+
 func (serv *server) TriagedCorpus() {
 	serv.triagedCorpus.Store(true)
 	serv.foreachRunnerAsync(func(runner *Runner) {
@@ -40,10 +40,12 @@ type Runner struct {
 	infoc         chan chan []byte
 	canonicalizer *cover.CanonicalizerInstance
 	nextRequestID int64
+	batchSize     int
 	requests      map[int64]*queue.Request
 	executing     map[int64]bool
 	hanged        map[int64]bool
 	lastExec      *LastExecuting
+	session       *SessionRecorder
 	updInfo       dispatcher.UpdateInfo
 	resultCh      chan error
 
@@ -58,6 +60,7 @@ type runnerStats struct {
 	statExecs              *stat.Val
 	statExecRetries        *stat.Val
 	statExecutorRestarts   *stat.Val
+	statCoverBytes         *stat.Val
 	statExecBufferTooSmall *stat.Val
 	statNoExecRequests     *stat.Val
 	statNoExecDuration     *stat.Val
@@ -70,6 +73,10 @@ type handshakeConfig struct {
 	RaceFrames []string
 	Files      []string
 	Features   flatrpc.Feature
+	// Max number of requests in a single ExecBatch message (0 disables batching).
+	BatchSize int
+	// Ask the executor to send signal/cover in CallInfo.SignalPacked/CoverPacked.
+	CompactCover bool
 
 	// Callback() is called in the middle of the handshake process.
 	// The return arguments are the coverage filter and the (possible) error.
@@ -104,6 +111,8 @@ func (runner *Runner) Handshake(conn *flatrpc.Conn, cfg *handshakeConfig) (hands
 		RaceFrames:       cfg.RaceFrames,
 		Files:            cfg.Files,
 		Features:         cfg.Features,
+		BatchSize:        int32(cfg.BatchSize),
+		CompactCover:     cfg.CompactCover,
 	}
 	if err := flatrpc.Send(conn, connectReply); err != nil {
 		return handshakeResult{}, err
@@ -124,6 +133,7 @@ func (runner *Runner) Handshake(conn *flatrpc.Conn, cfg *handshakeConfig) (hands
 	}
 	runner.mu.Lock()
 	runner.conn = conn
+	runner.batchSize = cfg.BatchSize
 	runner.machineInfo = ret.MachineInfo
 	runner.canonicalizer = ret.Canonicalizer
 	runner.mu.Unlock()
@@ -174,14 +184,8 @@ func (runner *Runner) ConnectionLoop() error {
 			default:
 			}
 		}
-		for len(runner.requests)-len(runner.executing) < 2*runner.procs {
-			req := runner.source.Next(runner.id)
-			if req == nil {
-				break
-			}
-			if err := runner.sendRequest(req); err != nil {
-				return err
-			}
+		if err := runner.sendRequests(); err != nil {
+			return err
 		}
 		if len(runner.requests) == 0 {
 			if !runner.Alive() {
@@ -203,6 +207,12 @@ func (runner *Runner) ConnectionLoop() error {
 			err = runner.handleExecutingMessage(msg)
 		case *flatrpc.ExecResult:
 			err = runner.handleExecResult(msg)
+		case *flatrpc.ExecBatchResult:
+			for _, res := range msg.Results {
+				if err = runner.handleExecResult(res); err != nil {
+					break
+				}
+			}
 		case *flatrpc.StateResult:
 			buf := new(bytes.Buffer)
 			fmt.Fprintf(buf, "pending requests on the VM:")
@@ -270,7 +280,52 @@ func (runner *Runner) sendStateRequest() error {
 	return flatrpc.Send(runner.conn, msg)
 }
 
-func (runner *Runner) sendRequest(req *queue.Request) error {
+// sendRequests sends new requests to the executor until it has enough pending requests.
+// If batching was negotiated during the handshake, requests are sent in ExecBatch messages.
+func (runner *Runner) sendRequests() error {
+	var batch []*flatrpc.ExecRequest
+	for len(runner.requests)-len(runner.executing) < 2*runner.procs {
+		req := runner.source.Next(runner.id)
+		if req == nil {
+			break
+		}
+		msg := runner.convertRequest(req)
+		if msg == nil {
+			continue
+		}
+		batch = append(batch, msg)
+		if len(batch) >= max(runner.batchSize, 1) {
+			if err := runner.sendBatch(batch); err != nil {
+				return err
+			}
+			batch = nil
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return runner.sendBatch(batch)
+}
+
+func (runner *Runner) sendBatch(batch []*flatrpc.ExecRequest) error {
+	msg := &flatrpc.HostMessage{
+		Msg: &flatrpc.HostMessages{
+			Type:  flatrpc.HostMessagesRawExecRequest,
+			Value: batch[0],
+		},
+	}
+	if len(batch) > 1 {
+		msg.Msg = &flatrpc.HostMessages{
+			Type:  flatrpc.HostMessagesRawExecBatch,
+			Value: &flatrpc.ExecBatch{Requests: batch},
+		}
+	}
+	return flatrpc.Send(runner.conn, msg)
+}
+
+// convertRequest registers the request as pending and converts it to the RPC message.
+// Returns nil if the request has already failed.
+func (runner *Runner) convertRequest(req *queue.Request) *flatrpc.ExecRequest {
 	if err := req.Validate(); err != nil {
 		panic(err)
 	}
@@ -320,6 +375,10 @@ func (runner *Runner) sendRequest(req *queue.Request) error {
 	case flatrpc.RequestTypeGlob:
 		data = append([]byte(req.GlobPattern), 0)
 		flags |= flatrpc.RequestFlagReturnOutput
+	case flatrpc.RequestTypeReadFile:
+		for _, file := range req.Files {
+			data = append(append(data, file...), 0)
+		}
 	default:
 		panic("unhandled request type")
 	}
@@ -329,22 +388,16 @@ func (runner *Runner) sendRequest(req *queue.Request) error {
 			avoid |= uint64(1 << id.Proc)
 		}
 	}
-	msg := &flatrpc.HostMessage{
-		Msg: &flatrpc.HostMessages{
-			Type: flatrpc.HostMessagesRawExecRequest,
-			Value: &flatrpc.ExecRequest{
-				Id:        id,
-				Type:      req.Type,
-				Avoid:     avoid,
-				Data:      data,
-				Flags:     flags,
-				ExecOpts:  &opts,
-				AllSignal: allSignal,
-			},
-		},
-	}
 	runner.requests[id] = req
-	return flatrpc.Send(runner.conn, msg)
+	return &flatrpc.ExecRequest{
+		Id:        id,
+		Type:      req.Type,
+		Avoid:     avoid,
+		Data:      data,
+		Flags:     flags,
+		ExecOpts:  &opts,
+		AllSignal: allSignal,
+	}
 }
 
 func (runner *Runner) handleExecutingMessage(msg *flatrpc.ExecutingMessage) error {
@@ -378,10 +431,16 @@ func (runner *Runner) handleExecutingMessage(msg *flatrpc.ExecutingMessage) erro
 		data = []byte(fmt.Sprintf("executing binary %v\n", req.BinaryFile))
 	case flatrpc.RequestTypeGlob:
 		data = []byte(fmt.Sprintf("expanding glob: %v\n", req.GlobPattern))
+	case flatrpc.RequestTypeReadFile:
+		data = []byte(fmt.Sprintf("reading files: %v\n", req.Files))
 	default:
 		panic(fmt.Sprintf("unhandled request type %v", req.Type))
 	}
-	runner.lastExec.Note(int(msg.Id), proc, data, osutil.MonotonicNano())
+	now := osutil.MonotonicNano()
+	runner.lastExec.Note(int(msg.Id), proc, data, now)
+	if runner.session != nil && req.Type == flatrpc.RequestTypeProgram {
+		runner.session.Note(int(msg.Id), proc, req.ExecOpts, data, now)
+	}
 	select {
 	case runner.injectExec <- true:
 	default:
@@ -403,6 +462,15 @@ func (runner *Runner) handleExecResult(msg *flatrpc.ExecResult) error {
 		}
 		return fmt.Errorf("can't find executed request %v", msg.Id)
 	}
+	if req.Type == flatrpc.RequestTypeProgram && msg.Info != nil {
+		// Unpack before the request is removed: on error the runner is shut down,
+		// and shutdown completes all requests that are still in runner.requests.
+		coverBytes, err := unpackCover(msg.Info)
+		if err != nil {
+			return fmt.Errorf("failed to unpack result of request %v: %w", msg.Id, err)
+		}
+		runner.stats.statCoverBytes.Add(coverBytes)
+	}
 	delete(runner.requests, msg.Id)
 	delete(runner.executing, msg.Id)
 	if req.Type == flatrpc.RequestTypeProgram && msg.Info != nil {
@@ -435,6 +503,11 @@ func (runner *Runner) handleExecResult(msg *flatrpc.ExecResult) error {
 			// filtered out.
 			addFallbackSignal(req.Prog, msg.Info)
 		}
+		runner.lastExec.Executed(int(msg.Id), int(msg.Proc), msg.Info)
+	}
+	for _, file := range msg.Files {
+		// The data references the receive buffer, which is reused for the next message.
+		file.Data = slices.Clone(file.Data)
 	}
 	status := queue.Success
 	var resErr error
@@ -449,6 +522,7 @@ func (runner *Runner) handleExecResult(msg *flatrpc.ExecResult) error {
 		}
 		runner.hanged[msg.Id] = true
 	}
+	runner.source.RecordExec(runner.id, status == queue.Hanged)
 	req.Done(&queue.Result{
 		Executor: queue.ExecutorID{
 			VM:   runner.id,
@@ -457,11 +531,28 @@ func (runner *Runner) handleExecResult(msg *flatrpc.ExecResult) error {
 		Status: status,
 		Info:   msg.Info,
 		Output: slices.Clone(msg.Output),
+		Files:  msg.Files,
 		Err:    resErr,
 	})
 	return nil
 }
 
+// unpackCover decodes signal/cover sent in the compact encoding (see handshakeConfig.CompactCover),
+// and returns the number of signal/cover bytes received from the executor.
+func unpackCover(info *flatrpc.ProgInfo) (int, error) {
+	size := 0
+	for _, call := range append(slices.Clip(info.Calls), info.ExtraRaw...) {
+		if call == nil {
+			continue
+		}
+		size += len(call.SignalPacked) + len(call.CoverPacked) + 8*(len(call.Signal)+len(call.Cover))
+		if err := call.UnpackCover(); err != nil {
+			return 0, err
+		}
+	}
+	return size, nil
+}
+
 func (runner *Runner) convertCallInfo(call *flatrpc.CallInfo) {
 	call.Cover = runner.canonicalizer.Canonicalize(call.Cover)
 	call.Signal = runner.canonicalizer.Canonicalize(call.Signal)
@@ -556,6 +647,18 @@ func (runner *Runner) Shutdown(crashed bool, extraExecs ...report.ExecutorInfo)
 		// Wait for the connection goroutine to finish and stop touching data.
 		<-finished
 	}
+	if crashed {
+		runner.source.RecordCrash(runner.id)
+	}
+	if runner.session != nil {
+		// Sessions of VMs that did not crash are not interesting.
+		file, err := runner.session.Close(crashed)
+		if err != nil {
+			log.Logf(0, "VM %v: failed to save execution session: %v", runner.id, err)
+		} else if file != "" {
+			log.Logf(0, "VM %v: saved execution session to %v", runner.id, file)
+		}
+	}
 	records := runner.lastExec.Collect()
 	for _, info := range extraExecs {
 		req := runner.requests[int64(info.ExecID)]
@@ -0,0 +1,37 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package rpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/syzkaller/pkg/flatrpc"
+	"github.com/google/syzkaller/pkg/fuzzer/queue"
+	"github.com/google/syzkaller/prog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecResultCorruptedCover(t *testing.T) {
+	runner := &Runner{
+		requests:  make(map[int64]*queue.Request),
+		executing: make(map[int64]bool),
+		hanged:    make(map[int64]bool),
+		lastExec:  MakeLastExecuting(prog.MaxPids, 6),
+	}
+	req := &queue.Request{}
+	runner.requests[1] = req
+	runner.executing[1] = true
+	err := runner.handleExecResult(&flatrpc.ExecResult{
+		Id: 1,
+		Info: &flatrpc.ProgInfo{
+			Calls: []*flatrpc.CallInfo{{SignalPacked: []byte{0x02, 0x80}}},
+		},
+	})
+	assert.Error(t, err)
+	// The error shuts down the runner, which must complete the request.
+	runner.Shutdown(false)
+	res := req.Wait(context.Background())
+	assert.Equal(t, queue.Restarted, res.Status)
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package rpcserver
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/syzkaller/pkg/flatrpc"
+	"github.com/google/syzkaller/pkg/osutil"
+)
+
+// SessionRecorder writes all programs executed in a VM during its whole lifetime
+// to a gzip-compressed log. Unlike LastExecuting, the log allows to reproduce crashes
+// that depend on long execution histories (see syz-execprog -replay).
+// The log format is compatible with prog.ParseLog, each entry looks as:
+//
+//	<time since session start>: executing program <proc> (id=<id>, env=<flags>, exec=<flags>, sandbox_arg=<arg>):
+//	<program>
+type SessionRecorder struct {
+	file  string
+	f     *os.File
+	gz    *gzip.Writer
+	w     *bufio.Writer
+	start time.Duration
+}
+
+// maxSessions is the number of the most recent session logs kept in the sessions dir.
+const maxSessions = 100
+
+func NewSessionRecorder(file string) (*SessionRecorder, error) {
+	if err := osutil.MkdirAll(filepath.Dir(file)); err != nil {
+		return nil, err
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, err
+	}
+	gz := gzip.NewWriter(f)
+	return &SessionRecorder{
+		file:  file,
+		f:     f,
+		gz:    gz,
+		w:     bufio.NewWriter(gz),
+		start: osutil.MonotonicNano(),
+	}, nil
+}
+
+// Note execution of the 'prog' on 'proc' with 'opts' at time 'now'.
+func (rec *SessionRecorder) Note(id, proc int, opts flatrpc.ExecOpts, progData []byte, now time.Duration) {
+	fmt.Fprintf(rec.w, "%v: executing program %v (id=%v, env=0x%x, exec=0x%x, sandbox_arg=%v):\n%s\n",
+		now-rec.start, proc, id, uint64(opts.EnvFlags), uint64(opts.ExecFlags), opts.SandboxArg, progData)
+}
+
+// Close finishes the log. If keep is false, the log is removed.
+// Otherwise the oldest logs are removed so that at most maxSessions logs remain.
+// Returns the log file name (if it's kept).
+func (rec *SessionRecorder) Close(keep bool) (string, error) {
+	err := rec.w.Flush()
+	if err1 := rec.gz.Close(); err == nil {
+		err = err1
+	}
+	if err1 := rec.f.Close(); err == nil {
+		err = err1
+	}
+	if !keep || err != nil {
+		os.Remove(rec.file)
+		return "", err
+	}
+	removeOldSessions(filepath.Dir(rec.file), maxSessions)
+	return rec.file, nil
+}
+
+func removeOldSessions(dir string, keep int) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.log.gz"))
+	if err != nil || len(files) <= keep {
+		return
+	}
+	modTime := make(map[string]time.Time)
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			modTime[file] = info.ModTime()
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return modTime[files[i]].Before(modTime[files[j]])
+	})
+	for _, file := range files[:len(files)-keep] {
+		os.Remove(file)
+	}
+}
+
+// Options are optional for compatibility with logs recorded before they were added.
+var sessionEntryRe = regexp.MustCompile(`^([0-9.a-zµ]+): executing program ([0-9]+) \(id=([0-9]+)` +
+	`(?:, env=0x([0-9a-f]+), exec=0x([0-9a-f]+), sandbox_arg=(-?[0-9]+))?\):$`)
+
+// ParseSession parses a log produced by SessionRecorder (either compressed or not).
+// Returned ExecRecord.Time is the time since the session start.
+func ParseSession(data []byte) ([]ExecRecord, error) {
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		// The tail may be corrupted if the manager was killed in the middle,
+		// use whatever we managed to decompress.
+		data, _ = io.ReadAll(gz)
+	}
+	var records []ExecRecord
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		match := sessionEntryRe.FindSubmatch(line)
+		if match == nil {
+			if len(line) == 0 {
+				continue
+			}
+			if len(records) == 0 {
+				return nil, fmt.Errorf("unexpected line in session log: %q", line)
+			}
+			rec := &records[len(records)-1]
+			rec.Prog = append(append(rec.Prog, line...), '\n')
+			continue
+		}
+		when, err := time.ParseDuration(string(match[1]))
+		if err != nil {
+			return nil, fmt.Errorf("bad time in session log: %q", line)
+		}
+		proc, _ := strconv.Atoi(string(match[2]))
+		id, _ := strconv.Atoi(string(match[3]))
+		var opts *flatrpc.ExecOpts
+		if len(match[4]) != 0 {
+			env, _ := strconv.ParseUint(string(match[4]), 16, 64)
+			exec, _ := strconv.ParseUint(string(match[5]), 16, 64)
+			sandboxArg, _ := strconv.ParseInt(string(match[6]), 10, 64)
+			opts = &flatrpc.ExecOpts{
+				EnvFlags:   flatrpc.ExecEnv(env),
+				ExecFlags:  flatrpc.ExecFlag(exec),
+				SandboxArg: sandboxArg,
+			}
+		}
+		records = append(records, ExecRecord{
+			ID:   id,
+			Proc: proc,
+			Time: when,
+			Opts: opts,
+		})
+	}
+	return records, nil
+}
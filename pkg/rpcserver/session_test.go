@@ -0,0 +1,103 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package rpcserver
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/syzkaller/pkg/flatrpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionRecorder(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "sessions", "vm0.log.gz")
+	rec, err := NewSessionRecorder(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := flatrpc.ExecOpts{
+		EnvFlags:   flatrpc.ExecEnvSandboxNamespace | flatrpc.ExecEnvSignal,
+		ExecFlags:  flatrpc.ExecFlagCollectSignal | flatrpc.ExecFlagThreaded,
+		SandboxArg: -1,
+	}
+	rec.Note(1, 0, opts, []byte("foo()\nbar()\n"), rec.start+time.Millisecond)
+	rec.Note(2, 5, flatrpc.ExecOpts{}, []byte("r0 = baz()\n"), rec.start+1500*time.Microsecond)
+	rec.Note(5, 0, opts, []byte("qux(0x1)\n"), rec.start+3*time.Second)
+	saved, err := rec.Close(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, file, saved)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := ParseSession(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []ExecRecord{
+		{ID: 1, Proc: 0, Prog: []byte("foo()\nbar()\n"), Time: time.Millisecond, Opts: &opts},
+		{ID: 2, Proc: 5, Prog: []byte("r0 = baz()\n"), Time: 1500 * time.Microsecond, Opts: &flatrpc.ExecOpts{}},
+		{ID: 5, Proc: 0, Prog: []byte("qux(0x1)\n"), Time: 3 * time.Second, Opts: &opts},
+	}, records)
+}
+
+func TestParseSessionWithoutOpts(t *testing.T) {
+	records, err := ParseSession([]byte("1ms: executing program 0 (id=1):\nfoo()\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []ExecRecord{
+		{ID: 1, Proc: 0, Prog: []byte("foo()\n"), Time: time.Millisecond},
+	}, records)
+}
+
+func TestSessionRecorderDiscard(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "vm0.log.gz")
+	rec, err := NewSessionRecorder(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.Note(1, 0, flatrpc.ExecOpts{}, []byte("foo()\n"), rec.start)
+	saved, err := rec.Close(false)
+	assert.NoError(t, err)
+	assert.Empty(t, saved)
+	assert.NoFileExists(t, file)
+}
+
+func TestParseSessionCorrupted(t *testing.T) {
+	_, err := ParseSession([]byte("foo()\n1ms: executing program 0 (id=1):\nbar()\n"))
+	assert.Error(t, err)
+}
+
+func TestSessionRecorderRetention(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < maxSessions+5; i++ {
+		file := filepath.Join(dir, fmt.Sprintf("vm0-%v.log.gz", i))
+		rec, err := NewSessionRecorder(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := rec.Close(true); err != nil {
+			t.Fatal(err)
+		}
+		// Make the order of modification times deterministic.
+		mtime := time.Now().Add(time.Duration(i-maxSessions) * time.Minute)
+		if err := os.Chtimes(file, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.log.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, files, maxSessions)
+	assert.NoFileExists(t, filepath.Join(dir, "vm0-3.log.gz"))
+	assert.FileExists(t, filepath.Join(dir, fmt.Sprintf("vm0-%v.log.gz", maxSessions+4)))
+}
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/google/syzkaller/prog"
 	_ "github.com/google/syzkaller/sys"
 	"github.com/google/syzkaller/sys/targets"
+	"github.com/stretchr/testify/assert"
 )
 
 // Find the corresponding QEMU binary for the target arch, if needed.
@@ -141,6 +143,76 @@ func TestZlib(t *testing.T) {
 	}
 }
 
+func TestReadFile(t *testing.T) {
+	t.Parallel()
+	target, err := prog.GetTarget(targets.TestOS, targets.TestArch64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sysTarget := targets.Get(target.OS, target.Arch)
+	if sysTarget.BrokenCompiler != "" {
+		t.Skipf("skipping, broken cross-compiler: %v", sysTarget.BrokenCompiler)
+	}
+	dir := t.TempDir()
+	for _, file := range []string{"foo", "bar1", "bar2"} {
+		if err := osutil.WriteFile(filepath.Join(dir, file), []byte("data of "+file)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A FIFO with an open writer, but without data: blocking read would hang forever.
+	fifo := filepath.Join(dir, "fifo")
+	if err := syscall.Mkfifo(fifo, 0600); err != nil {
+		t.Fatal(err)
+	}
+	writer, err := os.OpenFile(fifo, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+	executor := csource.BuildExecutor(t, target, "../..")
+	source := queue.Plain()
+	startRPCServer(t, target, executor, source, rpcParams{})
+	req := &queue.Request{
+		Type: flatrpc.RequestTypeReadFile,
+		Files: []string{
+			filepath.Join(dir, "foo"),
+			filepath.Join(dir, "bar*"),
+			filepath.Join(dir, "baz"),
+			fifo,
+			// Endless files are truncated.
+			"/dev/zero",
+		},
+	}
+	source.Submit(req)
+	res := req.Wait(context.Background())
+	if res.Status != queue.Success {
+		t.Fatalf("request failed: %v %v", res.Status, res.Err)
+	}
+	got := make(map[string]string)
+	for _, file := range res.Files {
+		if file.Name == "/dev/zero" {
+			assert.NotEmpty(t, file.Data)
+			assert.Contains(t, file.Error, "truncated")
+			continue
+		}
+		if file.Name == fifo {
+			assert.Contains(t, file.Error, "no more data")
+		}
+		if file.Exists {
+			got[filepath.Base(file.Name)] = string(file.Data)
+		} else {
+			got[filepath.Base(file.Name)] = "missing"
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"foo":  "data of foo",
+		"bar1": "data of bar1",
+		"bar2": "data of bar2",
+		"baz":  "missing",
+		"fifo": "",
+	}, got)
+}
+
 func TestExecutorCommonExt(t *testing.T) {
 	t.Parallel()
 	target, err := prog.GetTarget("test", "64_fork")
@@ -52,12 +52,29 @@ func TestUnit(t *testing.T) {
 		sysTarget1 := targets.Get(sysTarget.OS, sysTarget.Arch)
 		t.Run(sysTarget1.Arch, func(t *testing.T) {
 			t.Parallel()
-			test(t, sysTarget1)
+			test(t, sysTarget1, rpcParams{})
 		})
 	}
 }
 
-func test(t *testing.T, sysTarget *targets.Target) {
+// TestUnitBatched runs the same tests with requests and results batched in RPC messages.
+func TestUnitBatched(t *testing.T) {
+	switch runtime.GOOS {
+	case targets.OpenBSD:
+		t.Skipf("broken on %v", runtime.GOOS)
+	}
+	test(t, targets.Get(targets.TestOS, targets.TestArch64Fork), rpcParams{execBatch: 8})
+}
+
+// TestUnitSharedMemory runs the same tests with the shared memory transport between the host and the executor.
+func TestUnitSharedMemory(t *testing.T) {
+	if runtime.GOOS != targets.Linux {
+		t.Skipf("shared memory transport is not supported on %v", runtime.GOOS)
+	}
+	test(t, targets.Get(targets.TestOS, targets.TestArch64Fork), rpcParams{sharedMemory: true})
+}
+
+func test(t *testing.T, sysTarget *targets.Target, params rpcParams) {
 	target, err := prog.GetTarget(sysTarget.OS, sysTarget.Arch)
 	if err != nil {
 		t.Fatal(err)
@@ -86,34 +103,33 @@ func test(t *testing.T, sysTarget *targets.Target) {
 		Debug:   *flagDebug,
 	}
 	ctx.Init()
-	waitCtx := startRPCServer(t, target, executor, ctx, rpcParams{
-		manyProcs: true,
-		machineChecked: func(features flatrpc.Feature) {
-			// Features we expect to be enabled on the test OS.
-			// All sandboxes except for none are not implemented, coverage is not returned,
-			// and setup for few features is failing specifically to test feature detection.
-			want := flatrpc.FeatureCoverage |
-				flatrpc.FeatureExtraCoverage |
-				flatrpc.FeatureDelayKcovMmap |
-				flatrpc.FeatureSandboxNone |
-				flatrpc.FeatureFault |
-				flatrpc.FeatureNetDevices |
-				flatrpc.FeatureKCSAN |
-				flatrpc.FeatureNicVF |
-				flatrpc.FeatureUSBEmulation |
-				flatrpc.FeatureVhciInjection |
-				flatrpc.FeatureWifiEmulation |
-				flatrpc.FeatureLRWPANEmulation |
-				flatrpc.FeatureBinFmtMisc |
-				flatrpc.FeatureSwap
-			for feat, name := range flatrpc.EnumNamesFeature {
-				if features&feat != want&feat {
-					t.Errorf("expect featue %v to be %v, but it is %v",
-						name, want&feat != 0, features&feat != 0)
-				}
+	params.manyProcs = true
+	params.machineChecked = func(features flatrpc.Feature) {
+		// Features we expect to be enabled on the test OS.
+		// All sandboxes except for none are not implemented, coverage is not returned,
+		// and setup for few features is failing specifically to test feature detection.
+		want := flatrpc.FeatureCoverage |
+			flatrpc.FeatureExtraCoverage |
+			flatrpc.FeatureDelayKcovMmap |
+			flatrpc.FeatureSandboxNone |
+			flatrpc.FeatureFault |
+			flatrpc.FeatureNetDevices |
+			flatrpc.FeatureKCSAN |
+			flatrpc.FeatureNicVF |
+			flatrpc.FeatureUSBEmulation |
+			flatrpc.FeatureVhciInjection |
+			flatrpc.FeatureWifiEmulation |
+			flatrpc.FeatureLRWPANEmulation |
+			flatrpc.FeatureBinFmtMisc |
+			flatrpc.FeatureSwap
+		for feat, name := range flatrpc.EnumNamesFeature {
+			if features&feat != want&feat {
+				t.Errorf("expect featue %v to be %v, but it is %v",
+					name, want&feat != 0, features&feat != 0)
 			}
-		},
-	})
+		}
+	}
+	waitCtx := startRPCServer(t, target, executor, ctx, params)
 	if t.Failed() {
 		return
 	}
@@ -370,28 +386,36 @@ func testCover(t *testing.T, target *prog.Target) {
 	}
 	executor := csource.BuildExecutor(t, target, "../../")
 	for i, test := range tests {
-		t.Run(fmt.Sprint(i), func(t *testing.T) {
-			t.Parallel()
-			source := queue.Plain()
-			vmArch := targets.TestArch32
-			if test.Is64Bit {
-				vmArch = targets.TestArch64
-			}
-			sysTarget := targets.Get(targets.TestOS, vmArch)
-			if sysTarget.BrokenCompiler != "" {
-				t.Skipf("skipping due to broken compiler:\n%v", sysTarget.BrokenCompiler)
+		for _, compactCover := range []bool{false, true} {
+			name := fmt.Sprint(i)
+			if compactCover {
+				name += "-compact"
 			}
-			ctx := startRPCServer(t, target, executor, source, rpcParams{
-				vmArch:      vmArch,
-				maxSignal:   test.MaxSignal,
-				coverFilter: test.CoverFilter,
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+				source := queue.Plain()
+				vmArch := targets.TestArch32
+				if test.Is64Bit {
+					vmArch = targets.TestArch64
+				}
+				sysTarget := targets.Get(targets.TestOS, vmArch)
+				if sysTarget.BrokenCompiler != "" {
+					t.Skipf("skipping due to broken compiler:\n%v", sysTarget.BrokenCompiler)
+				}
+				ctx := startRPCServer(t, target, executor, source, rpcParams{
+					vmArch:       vmArch,
+					compactCover: compactCover,
+					maxSignal:    test.MaxSignal,
+					coverFilter:  test.CoverFilter,
+				})
+				testCover1(t, ctx, target, test, source, compactCover)
 			})
-			testCover1(t, ctx, target, test, source)
-		})
+		}
 	}
 }
 
-func testCover1(t *testing.T, ctx context.Context, target *prog.Target, test CoverTest, source *queue.PlainQueue) {
+func testCover1(t *testing.T, ctx context.Context, target *prog.Target, test CoverTest, source *queue.PlainQueue,
+	compactCover bool) {
 	callName := "syz_inject_cover"
 	if test.ExtraCoverage {
 		callName = "syz_inject_remote_cover"
@@ -430,7 +454,12 @@ func testCover1(t *testing.T, ctx context.Context, target *prog.Target, test Cov
 		test.Signal = []uint64{}
 	}
 	assert.Equal(t, test.Cover, call.Cover)
-	assert.Equal(t, test.Signal, call.Signal)
+	if compactCover {
+		// The compact encoding does not preserve order of signal elements (which does not matter).
+		assert.ElementsMatch(t, test.Signal, call.Signal)
+	} else {
+		assert.Equal(t, test.Signal, call.Signal)
+	}
 	// Comparisons are reordered and order does not matter, so compare without order.
 	assert.ElementsMatch(t, test.Comps, call.Comps)
 }
@@ -464,6 +493,9 @@ func makeComps(comps ...Comparison) []byte {
 
 type rpcParams struct {
 	manyProcs      bool
+	execBatch      int
+	compactCover   bool
+	sharedMemory   bool
 	vmArch         string
 	vmType         string
 	maxSignal      []uint64
@@ -496,14 +528,17 @@ func startRPCServer(t *testing.T, target *prog.Target, executor string,
 			},
 			VMArch:        extra.vmArch,
 			Procs:         procs,
+			ExecBatch:     extra.execBatch,
+			CompactCover:  extra.compactCover,
 			Slowdown:      10, // to deflake slower tests
 			DebugTimeouts: true,
 		},
-		Executor:    executor,
-		Dir:         dir,
-		GDB:         *flagGDB,
-		MaxSignal:   extra.maxSignal,
-		CoverFilter: extra.coverFilter,
+		Executor:     executor,
+		Dir:          dir,
+		GDB:          *flagGDB,
+		SharedMemory: extra.sharedMemory,
+		MaxSignal:    extra.maxSignal,
+		CoverFilter:  extra.coverFilter,
 	}
 	cfg.MachineChecked = func(features flatrpc.Feature, syscalls map[*prog.Syscall]bool) queue.Source {
 		if extra.machineChecked != nil {
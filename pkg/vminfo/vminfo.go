@@ -103,6 +103,35 @@ func (checker *Checker) MachineInfo(fileInfos []*flatrpc.FileInfo) ([]*KernelMod
 
 var ErrAborted = errors.New("aborted through the context")
 
+// ReadFiles reads the files (or all files matching glob patterns) in the VM that executes
+// requests submitted to exec. In contrast to Checker.RequiredFiles, this can be used at any time
+// during fuzzing to gather the current kernel state (e.g. /proc/slabinfo).
+// The result contains only existing files, the size of all files is limited by the executor,
+// files that don't fit are truncated.
+func ReadFiles(ctx context.Context, exec queue.Executor, files ...string) (map[string][]byte, error) {
+	req := &queue.Request{
+		Type:      flatrpc.RequestTypeReadFile,
+		Files:     files,
+		Important: true,
+	}
+	exec.Submit(req)
+	res := req.Wait(ctx)
+	if res.Err == queue.ErrRequestAborted {
+		return nil, ErrAborted
+	}
+	if res.Status != queue.Success {
+		return nil, fmt.Errorf("failed to read files: %w (%v)", res.Err, res.Status)
+	}
+	ret := make(map[string][]byte)
+	for name, file := range createVirtualFilesystem(res.Files) {
+		if file.Error != "" && len(file.Data) == 0 {
+			continue
+		}
+		ret[name] = file.Data
+	}
+	return ret, nil
+}
+
 func (checker *Checker) Run(ctx context.Context, files []*flatrpc.FileInfo, featureInfos []*flatrpc.FeatureInfo) (
 	map[*prog.Syscall]bool, map[*prog.Syscall]string, Features, error) {
 	cc := newCheckContext(ctx, checker.cfg, checker.checker, checker.executor)
@@ -45,6 +45,38 @@ func TestHostMachineInfo(t *testing.T) {
 	}
 }
 
+func TestReadFiles(t *testing.T) {
+	exec := queue.Plain()
+	go func() {
+		for {
+			req := exec.Next()
+			if req == nil {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			if req.Type != flatrpc.RequestTypeReadFile {
+				t.Errorf("unexpected request type %v", req.Type)
+			}
+			req.Done(&queue.Result{
+				Status: queue.Success,
+				Files: []*flatrpc.FileInfo{
+					{Name: "/proc/slabinfo", Exists: true, Data: []byte("slabinfo")},
+					{Name: "/proc/missing", Error: "no such file"},
+					{Name: "/proc/kmsg", Exists: true, Error: "permission denied"},
+				},
+			})
+			return
+		}
+	}()
+	files, err := ReadFiles(context.Background(), exec, "/proc/slabinfo", "/proc/missing", "/proc/kmsg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || string(files["/proc/slabinfo"]) != "slabinfo" {
+		t.Fatalf("unexpected files: %q", files)
+	}
+}
+
 func TestSyscalls(t *testing.T) {
 	t.Parallel()
 	for _, arches := range targets.List {
@@ -0,0 +1,157 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package prog
+
+import (
+	"fmt"
+)
+
+// crossover replaces a complex argument (struct, union, array or buffer) of a random call
+// with an argument of the same type of the same syscall from another corpus program.
+// Unlike splice, it allows to propagate complex argument shapes (e.g. ioctl arguments)
+// learned in one program into other programs.
+// Resource references inside of the transplanted argument are rewired to compatible resources
+// of the mutated program, and pointers are re-allocated in its address space.
+func (ctx *mutator) crossover() bool {
+	p, r := ctx.p, ctx.r
+	if len(ctx.corpus) == 0 || len(p.Calls) == 0 {
+		return false
+	}
+	donor := ctx.corpus[r.Intn(len(ctx.corpus))]
+	donorCalls := make(map[*Syscall][]int)
+	for i, c := range donor.Calls {
+		donorCalls[c.Meta] = append(donorCalls[c.Meta], i)
+	}
+	type callPair struct {
+		idx      int
+		donorIdx int
+	}
+	var pairs []callPair
+	for i, c := range p.Calls {
+		if ctx.noMutate[c.Meta.ID] {
+			continue
+		}
+		for _, j := range donorCalls[c.Meta] {
+			pairs = append(pairs, callPair{i, j})
+		}
+	}
+	if len(pairs) == 0 {
+		return false
+	}
+	pair := pairs[r.Intn(len(pairs))]
+	idx, c := pair.idx, p.Calls[pair.idx]
+	// The transplanted argument is modified, so we take it from a copy of the donor.
+	donorCall := donor.Clone().Calls[pair.donorIdx]
+	srcArgs := make(map[crossoverKey][]Arg)
+	ForeachArg(donorCall, func(arg Arg, _ *ArgCtx) {
+		if key, ok := crossoverArgKey(arg); ok {
+			srcArgs[key] = append(srcArgs[key], arg)
+		}
+	})
+	type dstArg struct {
+		arg Arg
+		ctx ArgCtx
+	}
+	var dstArgs []dstArg
+	ForeachArg(c, func(arg Arg, argCtx *ArgCtx) {
+		if key, ok := crossoverArgKey(arg); ok && len(srcArgs[key]) != 0 {
+			dstArgs = append(dstArgs, dstArg{arg, *argCtx})
+		}
+	})
+	if len(dstArgs) == 0 {
+		return false
+	}
+	dst := dstArgs[r.Intn(len(dstArgs))]
+	key, _ := crossoverArgKey(dst.arg)
+	src := srcArgs[key][r.Intn(len(srcArgs[key]))]
+
+	s := analyze(ctx.ct, ctx.corpus, p, c)
+	r.adoptArg(s, src)
+	var baseSize uint64
+	if dst.ctx.Base != nil {
+		baseSize = dst.ctx.Base.Res.Size()
+	}
+	// Note: replaceArg can't be used since it keeps the old pointees,
+	// so we remove the old argument from the program and then overwrite it.
+	// Calls that used resources created by the old argument get the default values.
+	removeArg(dst.arg)
+	switch a := dst.arg.(type) {
+	case *GroupArg:
+		*a = *src.(*GroupArg)
+	case *UnionArg:
+		*a = *src.(*UnionArg)
+	case *DataArg:
+		*a = *src.(*DataArg)
+	default:
+		panic(fmt.Sprintf("unexpected crossover arg %#v", dst.arg))
+	}
+	// Update base pointer if size has increased.
+	if base := dst.ctx.Base; base != nil && baseSize < base.Res.Size() {
+		newArg := r.allocAddr(s, base.Type(), base.Dir(), base.Res.Size(), base.Res)
+		replaceArg(base, newArg)
+	}
+	calls, _ := r.patchConditionalFields(c, s)
+	p.insertBefore(c, calls)
+	idx += len(calls)
+	for len(p.Calls) > ctx.ncalls {
+		idx--
+		p.RemoveCall(idx)
+	}
+	if idx < 0 || idx >= len(p.Calls) || p.Calls[idx] != c {
+		panic(fmt.Sprintf("wrong call index: idx=%v calls=%v p.Calls=%v ncalls=%v",
+			idx, len(calls), len(p.Calls), ctx.ncalls))
+	}
+	p.Target.assignSizesCall(c)
+	return true
+}
+
+type crossoverKey struct {
+	typ Type
+	dir Dir
+}
+
+func crossoverArgKey(arg Arg) (crossoverKey, bool) {
+	switch typ := arg.Type().(type) {
+	case *StructType, *UnionType, *ArrayType:
+	case *BufferType:
+		if arg.Dir() == DirOut || typ.Kind == BufferCompressed {
+			return crossoverKey{}, false
+		}
+	default:
+		return crossoverKey{}, false
+	}
+	return crossoverKey{arg.Type(), arg.Dir()}, true
+}
+
+// adoptArg prepares an argument of another program to be inserted into the program analyzed in s.
+// Resource references are switched to compatible resources of the program (or to the default values),
+// and pointers are allocated anew.
+func (r *randGen) adoptArg(s *state, arg Arg) {
+	ForeachSubArg(arg, func(arg Arg, _ *ArgCtx) {
+		switch a := arg.(type) {
+		case *ResultArg:
+			// The calls that use the resource stay in the other program.
+			a.uses = nil
+			if a.Res == nil {
+				return
+			}
+			var res Arg
+			if typ, ok := a.Type().(*ResourceType); ok {
+				res = r.existingResource(s, typ, a.Dir())
+			}
+			if res == nil {
+				res = a.Type().DefaultArg(a.Dir())
+			}
+			replaceResultArg(a, res.(*ResultArg))
+		case *PointerArg:
+			switch {
+			case a.IsSpecial():
+			case a.VmaSize != 0:
+				*a = *r.allocVMA(s, a.Type(), a.Dir(), a.VmaSize/r.target.PageSize)
+			case a.Res != nil:
+				*a = *r.allocAddr(s, a.Type(), a.Dir(), a.Res.Size(), a.Res)
+			}
+		}
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package prog
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestCrossoverResources(t *testing.T) {
+	target, rs, _ := initRandomTargetTest(t, "test", "64")
+	recipient, err := target.Deserialize([]byte(`
+test$r102_producer(&(0x7f0000000000)=<r0=>0x0)
+test$r102_consumer_recur(&(0x7f0000000100)={&(0x7f0000000200)})
+`), Strict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	donor, err := target.Deserialize([]byte(`
+test$r102_producer(&(0x7f0000000000)=<r0=>0x0)
+test$r102_producer(&(0x7f0000000000)=<r1=>0x0)
+test$r102_consumer_recur(&(0x7f0000000300)={&(0x7f0000000400)={r1}})
+`), Strict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := newRand(target, rs)
+	var p *Prog
+	for i := 0; ; i++ {
+		if i == 100 {
+			t.Fatalf("crossover failed")
+		}
+		p = recipient.Clone()
+		ctx := &mutator{
+			p:      p,
+			r:      r,
+			ncalls: 10,
+			ct:     target.DefaultChoiceTable(),
+			corpus: []*Prog{donor},
+		}
+		// Not all pairs of calls have arguments to exchange.
+		if ctx.crossover() {
+			break
+		}
+	}
+	if err := p.validate(); err != nil {
+		t.Fatalf("invalid program after crossover: %v\n%s", err, p.Serialize())
+	}
+	var producer *ResultArg
+	ForeachArg(p.Calls[0], func(arg Arg, _ *ArgCtx) {
+		if a, ok := arg.(*ResultArg); ok {
+			producer = a
+		}
+	})
+	var consumer *ResultArg
+	ForeachArg(p.Calls[1], func(arg Arg, _ *ArgCtx) {
+		if a, ok := arg.(*ResultArg); ok {
+			consumer = a
+		}
+	})
+	if consumer == nil || consumer.Res != producer {
+		t.Fatalf("the resource was not rewired:\n%s", p.Serialize())
+	}
+	// The donor must not be changed.
+	if err := donor.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if len(donor.Calls) != 3 {
+		t.Fatalf("the donor was changed:\n%s", donor.Serialize())
+	}
+}
+
+func TestCrossoverRandom(t *testing.T) {
+	testEachTargetRandom(t, func(t *testing.T, target *Target, rs rand.Source, iters int) {
+		ct := target.DefaultChoiceTable()
+		var corpus []*Prog
+		for i := 0; i < 10; i++ {
+			corpus = append(corpus, target.Generate(rs, 10, ct))
+		}
+		r := newRand(target, rs)
+		for i := 0; i < iters; i++ {
+			p := corpus[r.Intn(len(corpus))].Clone()
+			data := p.Serialize()
+			ctx := &mutator{
+				p:      p,
+				r:      r,
+				ncalls: 20,
+				ct:     ct,
+				corpus: corpus,
+			}
+			ctx.crossover()
+			if err := p.validate(); err != nil {
+				t.Fatalf("invalid program after crossover: %v\norig:\n%s\nmutated:\n%s", err, data, p.Serialize())
+			}
+			for _, p1 := range corpus {
+				if err := p1.validate(); err != nil {
+					t.Fatalf("corpus program was changed: %v", err)
+				}
+			}
+		}
+	})
+}
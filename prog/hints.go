@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math/rand"
 	"sort"
 	"sync"
 
@@ -99,6 +100,14 @@ func (m CompMap) InplaceIntersect(other CompMap) {
 // The callback must return whether we should continue substitution (true)
 // or abort the process (false).
 func (p *Prog) MutateWithHints(callIndex int, comps CompMap, exec func(p *Prog) bool) {
+	p.MutateWithTaintedHints(callIndex, comps, nil, exec)
+}
+
+// MutateWithTaintedHints is like MutateWithHints, but substitutes comparison operands
+// only into the arguments that influence the comparisons according to taint.
+// If taint is nil, all comparisons are matched against all arguments.
+func (p *Prog) MutateWithTaintedHints(callIndex int, comps CompMap, taint *HintsTaint,
+	exec func(p *Prog) bool) {
 	p = p.Clone()
 	c := p.Calls[callIndex]
 	doMore := true
@@ -120,66 +129,255 @@ func (p *Prog) MutateWithHints(callIndex int, comps CompMap, exec func(p *Prog)
 		doMore = exec(p)
 		return doMore
 	}
+	idx := 0
 	ForeachArg(c, func(arg Arg, ctx *ArgCtx) {
 		if !doMore {
 			ctx.Stop = true
 			return
 		}
-		generateHints(comps, arg, ctx.Field, execValidate)
+		argComps := comps
+		if taint != nil {
+			argComps = taint.filter(idx, comps)
+		}
+		idx++
+		generateHints(argComps, arg, ctx.Field, execValidate)
 	})
 }
 
 func generateHints(compMap CompMap, arg Arg, field *Field, exec func() bool) {
+	if len(compMap) == 0 || !hintable(arg) {
+		return
+	}
+	switch a := arg.(type) {
+	case *ConstArg:
+		checkConstArg(a, field, compMap, exec)
+	case *DataArg:
+		if a.Type().(*BufferType).Kind == BufferCompressed {
+			checkCompressedArg(a, compMap, exec)
+		} else {
+			checkDataArg(a, compMap, exec)
+		}
+	}
+}
+
+// hintable returns whether comparison operands can be substituted into the argument.
+func hintable(arg Arg) bool {
 	typ := arg.Type()
 	if typ == nil || arg.Dir() == DirOut {
-		return
+		return false
 	}
 	switch t := typ.(type) {
 	case *ProcType:
 		// Random proc will not pass validation.
 		// We can mutate it, but only if the resulting value is within the legal range.
-		return
+		return false
 	case *ConstType:
 		if IsPad(typ) {
-			return
+			return false
 		}
 	case *CsumType:
 		// Csum will not pass validation and is always computed.
-		return
+		return false
 	case *BufferType:
 		switch t.Kind {
 		case BufferFilename:
 			// This can generate escaping paths and is probably not too useful anyway.
-			return
+			return false
 		case BufferString, BufferGlob:
 			if len(t.Values) != 0 {
 				// These are frequently file names or complete enumerations.
 				// Mutating these may be useful iff we intercept strcmp
 				// (and filter out file names).
-				return
+				return false
 			}
 		}
 	}
 
-	switch a := arg.(type) {
+	switch arg.(type) {
 	case *ConstArg:
-		if arg.Type().TypeBitSize() <= 8 {
-			// Very small arg, hopefully we can guess it w/o hints help.
+		// Very small arg, hopefully we can guess it w/o hints help.
+		return typ.TypeBitSize() > 8
+	case *DataArg:
+		// Let's assume it either does not contain anything interesting,
+		// or we can guess everything eventually by brute force.
+		return arg.Size() > 3
+	}
+	return false
+}
+
+// HintsTaint attributes comparisons of a call to the call arguments that influence them.
+// Taint is obtained by executing a copy of the program where the hintable arguments
+// are marked with random values (see MarkHints): a comparison is attributed to an argument
+// if one of the comparison operands matches the marker of the argument.
+// This is an approximation of the real taint: comparisons of values derived from an argument
+// (e.g. hashed or masked) are not attributed to it, and comparisons that are only reached
+// with the original argument values are not filtered (see filter).
+type HintsTaint struct {
+	// PCs of all comparisons of the marked execution.
+	reached map[uint64]bool
+	// PCs of comparisons attributed to each of the marked arguments.
+	// Arguments are identified by their index in ForeachArg order.
+	args map[int]map[uint64]bool
+}
+
+// MarkHints returns a copy of the program where all hintable arguments of the call
+// are replaced with random marker values. Comparisons collected during execution
+// of the marked program can be attributed to the arguments with HintsTaint.
+func (p *Prog) MarkHints(rs rand.Source, callIndex int) *Prog {
+	p = p.Clone()
+	r := newRand(p.Target, rs)
+	c := p.Calls[callIndex]
+	markable := markableArgs(p.Target, c)
+	ForeachArg(c, func(arg Arg, _ *ArgCtx) {
+		if !markable(arg) {
 			return
 		}
-		checkConstArg(a, field, compMap, exec)
-	case *DataArg:
-		if arg.Size() <= 3 {
-			// Let's assume it either does not contain anything interesting,
-			// or we can guess everything eventually by brute force.
+		switch a := arg.(type) {
+		case *ConstArg:
+			a.Val = truncateToBitSize(r.Uint64(), a.Type().TypeBitSize())
+		case *DataArg:
+			r.Read(a.Data())
+		}
+	})
+	// Random markers can turn the call into a dangerous one.
+	p.Target.sanitize(c, true)
+	return p
+}
+
+// markableArgs returns a predicate for the arguments of the call that MarkHints replaces
+// with markers. Arguments that determine the layout of the call are not marked:
+// random lengths would be wrong, and random values of arguments referenced by conditions
+// would select different conditional fields, while the marked program must have the same
+// arguments as the original one. The predicate gives the same answers for the original
+// and for the marked program.
+func markableArgs(target *Target, c *Call) func(Arg) bool {
+	conditions := make(map[Arg]bool)
+	for _, callArg := range c.Args {
+		foreachSubArgWithStack(callArg, func(arg Arg, argCtx *ArgCtx) {
+			if target.isAnyPtr(arg.Type()) {
+				argCtx.Stop = true
+				return
+			}
+			unionArg, ok := arg.(*UnionArg)
+			if !ok {
+				return
+			}
+			unionType, ok := arg.Type().(*UnionType)
+			if !ok || !unionType.isConditional() {
+				return
+			}
+			argFinder := makeArgFinder(target, c, unionArg, argCtx.parentStack)
+			for _, field := range unionType.Fields {
+				if field.Condition == nil {
+					continue
+				}
+				field.Condition.ForEachValue(func(v *Value) {
+					if len(v.Path) == 0 {
+						return
+					}
+					if found := argFinder(v.Path); found != nil && found != SquashedArgFound {
+						conditions[found] = true
+					}
+				})
+			}
+		})
+	}
+	return func(arg Arg) bool {
+		if !hintable(arg) || conditions[arg] {
+			return false
+		}
+		switch typ := arg.Type().(type) {
+		case *LenType:
+			return false
+		case *BufferType:
+			// Images are large and are not worth an additional execution.
+			return typ.Kind != BufferCompressed
+		}
+		return true
+	}
+}
+
+// HintsTaint attributes comparisons collected during execution of the marked program p
+// (the result of MarkHints) to the arguments of the call.
+func (p *Prog) HintsTaint(callIndex int, comps CompMap) *HintsTaint {
+	taint := &HintsTaint{
+		reached: make(map[uint64]bool),
+		args:    make(map[int]map[uint64]bool),
+	}
+	for _, ops2 := range comps {
+		for _, pcs := range ops2 {
+			for pc := range pcs {
+				taint.reached[pc] = true
+			}
+		}
+	}
+	idx := 0
+	c := p.Calls[callIndex]
+	markable := markableArgs(p.Target, c)
+	ForeachArg(c, func(arg Arg, _ *ArgCtx) {
+		argIdx := idx
+		idx++
+		if !markable(arg) {
 			return
 		}
-		if typ.(*BufferType).Kind == BufferCompressed {
-			checkCompressedArg(a, compMap, exec)
-		} else {
-			checkDataArg(a, compMap, exec)
+		pcs := make(map[uint64]bool)
+		match := func(v, bitsize uint64) {
+			forEachShrinkExpand(truncateToBitSize(v, bitsize), bitsize, false,
+				func(mutant, _ uint64, _ int, _ bool) {
+					for _, pcs1 := range comps[mutant] {
+						for pc := range pcs1 {
+							pcs[pc] = true
+						}
+					}
+				})
+		}
+		switch a := arg.(type) {
+		case *ConstArg:
+			match(a.Val, a.Type().TypeBitSize())
+		case *DataArg:
+			data := a.Data()
+			val := make([]byte, 8)
+			for i := 0; i < min(len(data), maxDataLength); i++ {
+				clear(val)
+				copy(val, data[i:])
+				match(binary.LittleEndian.Uint64(val), 64)
+			}
+		}
+		taint.args[argIdx] = pcs
+	})
+	return taint
+}
+
+// Len returns the number of (argument, PC) pairs attributed by the taint.
+func (taint *HintsTaint) Len() int {
+	count := 0
+	for _, pcs := range taint.args {
+		count += len(pcs)
+	}
+	return count
+}
+
+// filter returns the comparisons that may be influenced by the argument with the given index.
+// Comparisons that were not reached by the marked execution are kept since the markers
+// could have changed control flow, so we have no evidence for them.
+func (taint *HintsTaint) filter(idx int, comps CompMap) CompMap {
+	pcs, ok := taint.args[idx]
+	if !ok {
+		// The argument was not marked.
+		return comps
+	}
+	res := make(CompMap)
+	for op1, ops2 := range comps {
+		for op2, pcs1 := range ops2 {
+			for pc := range pcs1 {
+				if !taint.reached[pc] || pcs[pc] {
+					// Comps already contain both operand orders.
+					res.Add(pc, op1, op2, true)
+				}
+			}
 		}
 	}
+	return res
 }
 
 func checkConstArg(arg *ConstArg, field *Field, compMap CompMap, exec func() bool) {
@@ -295,6 +493,59 @@ func shrinkExpand(v uint64, compMap CompMap, bitsize uint64, image bool) []uint6
 	v = truncateToBitSize(v, bitsize)
 	limit := uint64(1<<bitsize - 1)
 	var replacers map[uint64]bool
+	forEachShrinkExpand(v, bitsize, image, func(mutant, size uint64, width int, bigendian bool) {
+		for newV := range compMap[mutant] {
+			// Check the limit for negative numbers.
+			if newV > limit && ((^(limit >> 1) & newV) != ^(limit >> 1)) {
+				continue
+			}
+			mask := uint64(1<<size - 1)
+			newHi := newV & ^mask
+			newV = newV & mask
+			if newHi != 0 && newHi^^mask != 0 {
+				continue
+			}
+			if bigendian {
+				newV = swapInt(newV, width)
+			}
+			// We insert special ints (like 0) with high probability,
+			// so we don't try to replace to special ints them here.
+			// Images are large so it's hard to guess even special
+			// ints with random mutations.
+			if !image && specialIntsSet[newV] {
+				continue
+			}
+			// Replace size least significant bits of v with
+			// corresponding bits of newV. Leave the rest of v as it was.
+			replacer := (v &^ mask) | newV
+			if replacer == v {
+				continue
+			}
+			replacer = truncateToBitSize(replacer, bitsize)
+			// TODO(dvyukov): should we try replacing with arg+/-1?
+			// This could trigger some off-by-ones.
+			if replacers == nil {
+				replacers = make(map[uint64]bool)
+			}
+			replacers[replacer] = true
+		}
+	})
+	if replacers == nil {
+		return nil
+	}
+	res := make([]uint64, 0, len(replacers))
+	for v := range replacers {
+		res = append(res, v)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i] < res[j]
+	})
+	return res
+}
+
+// forEachShrinkExpand calls fn for every shrank and expanded version of v
+// that can be matched against comparison operands (see shrinkExpand).
+func forEachShrinkExpand(v, bitsize uint64, image bool, fn func(mutant, size uint64, width int, bigendian bool)) {
 	for _, iwidth := range []int{8, 4, 2, 1, -4, -2, -1} {
 		var width int
 		var size, mutant uint64
@@ -334,54 +585,9 @@ func shrinkExpand(v uint64, compMap CompMap, bitsize uint64, image bool) []uint6
 				}
 				mutant = swapInt(mutant, width)
 			}
-			for newV := range compMap[mutant] {
-				// Check the limit for negative numbers.
-				if newV > limit && ((^(limit >> 1) & newV) != ^(limit >> 1)) {
-					continue
-				}
-				mask := uint64(1<<size - 1)
-				newHi := newV & ^mask
-				newV = newV & mask
-				if newHi != 0 && newHi^^mask != 0 {
-					continue
-				}
-				if bigendian {
-					newV = swapInt(newV, width)
-				}
-				// We insert special ints (like 0) with high probability,
-				// so we don't try to replace to special ints them here.
-				// Images are large so it's hard to guess even special
-				// ints with random mutations.
-				if !image && specialIntsSet[newV] {
-					continue
-				}
-				// Replace size least significant bits of v with
-				// corresponding bits of newV. Leave the rest of v as it was.
-				replacer := (v &^ mask) | newV
-				if replacer == v {
-					continue
-				}
-				replacer = truncateToBitSize(replacer, bitsize)
-				// TODO(dvyukov): should we try replacing with arg+/-1?
-				// This could trigger some off-by-ones.
-				if replacers == nil {
-					replacers = make(map[uint64]bool)
-				}
-				replacers[replacer] = true
-			}
+			fn(mutant, size, width, bigendian)
 		}
 	}
-	if replacers == nil {
-		return nil
-	}
-	res := make([]uint64, 0, len(replacers))
-	for v := range replacers {
-		res = append(res, v)
-	}
-	sort.Slice(res, func(i, j int) bool {
-		return res[i] < res[j]
-	})
-	return res
 }
 
 type HintsLimiter struct {
@@ -648,6 +648,59 @@ func TestHintsCall(t *testing.T) {
 	}
 }
 
+func TestHintsTaint(t *testing.T) {
+	target, rs, _ := initRandomTargetTest(t, "test", "64")
+	p, err := target.Deserialize([]byte(`test$int(0x0, 0x0, 0x0, 0x111, 0x222)`), Strict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	marked := p.MarkHints(rs, 0)
+	marker := marked.Calls[0].Args[3].(*ConstArg).Val
+	if marker == 0x111 {
+		t.Fatalf("the argument was not marked")
+	}
+	markedComps := make(CompMap)
+	markedComps.Add(1, marker, 0xaaaa, true)
+	markedComps.Add(2, 0x5555, 0x6666, true)
+	taint := marked.HintsTaint(0, markedComps)
+	comps := make(CompMap)
+	// Arg 3 reaches PC 1.
+	comps.Add(1, 0x111, 0x1234, true)
+	// PC 1 does not depend on arg 4.
+	comps.Add(1, 0x222, 0x4321, true)
+	// PC 2 does not depend on arg 3.
+	comps.Add(2, 0x111, 0x6666, true)
+	// PC 3 was not reached by the marked execution, so we don't know.
+	comps.Add(3, 0x222, 0x7777, true)
+	var got []string
+	p.MutateWithTaintedHints(0, comps, taint, func(newP *Prog) bool {
+		got = append(got, strings.TrimSpace(string(newP.Serialize())))
+		return true
+	})
+	assert.ElementsMatch(t, []string{
+		`test$int(0x0, 0x0, 0x0, 0x1234, 0x222)`,
+		`test$int(0x0, 0x0, 0x0, 0x111, 0x7777)`,
+	}, got)
+}
+
+func TestMarkHintsLayout(t *testing.T) {
+	target, rs, _ := initRandomTargetTest(t, "test", "64")
+	p, err := target.Deserialize([]byte(
+		`test$conditional_struct(&(0x7f0000000000)={0x6, @value={AUTO}, @value=0x123, @void})`), Strict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	marked := p.MarkHints(rs, 0)
+	fields := marked.Calls[0].Args[0].(*PointerArg).Res.(*GroupArg).Inner
+	// The mask selects the conditional fields, so it must not be marked.
+	assert.Equal(t, uint64(0x6), fields[0].(*ConstArg).Val)
+	assert.NotEqual(t, uint64(0x123), fields[2].(*UnionArg).Option.(*ConstArg).Val)
+	for i, field := range fields[1:] {
+		assert.Equal(t, p.Calls[0].Args[0].(*PointerArg).Res.(*GroupArg).Inner[i+1].(*UnionArg).Index,
+			field.(*UnionArg).Index)
+	}
+}
+
 func TestHintsRandom(t *testing.T) {
 	target, rs, iters := initTest(t)
 	ct := target.DefaultChoiceTable()
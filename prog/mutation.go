@@ -37,8 +37,13 @@ var DefaultMutateOpts = MutateOpts{
 	InsertWeight:     100,
 	MutateArgWeight:  100,
 	RemoveCallWeight: 10,
+	// Crossover is disabled by default, see DefaultCrossoverWeight.
+	CrossoverWeight: 0,
 }
 
+// DefaultCrossoverWeight is the recommended CrossoverWeight if crossover is enabled.
+const DefaultCrossoverWeight = 50
+
 type MutateOpts struct {
 	ExpectedIterations int
 	MutateArgCount     int
@@ -47,14 +52,161 @@ type MutateOpts struct {
 	InsertWeight       int
 	MutateArgWeight    int
 	RemoveCallWeight   int
+	CrossoverWeight    int
+	// Weights of argument kinds when choosing an argument to mutate (relative to DefaultArgWeight).
+	// Zero weight means DefaultArgWeight.
+	ArgWeights [ArgMutationCount]int
+}
+
+const DefaultArgWeight = 100
+
+// MutationOp identifies a top-level mutation operator.
+type MutationOp int
+
+const (
+	MutationSquash MutationOp = iota
+	MutationSplice
+	MutationInsert
+	MutationMutateArg
+	MutationRemoveCall
+	MutationCrossover
+	MutationOpCount
+)
+
+var mutationOpNames = [MutationOpCount]string{"squash", "splice", "insert", "mutate arg", "remove call",
+	"crossover"}
+
+func (op MutationOp) String() string {
+	return mutationOpNames[op]
+}
+
+// ArgMutation identifies the type-specific method used to mutate a single argument.
+type ArgMutation int
+
+const (
+	ArgMutationInt ArgMutation = iota
+	ArgMutationFlags
+	ArgMutationLen
+	ArgMutationResource
+	ArgMutationVma
+	ArgMutationProc
+	ArgMutationBuffer
+	ArgMutationArray
+	ArgMutationPtr
+	ArgMutationStruct
+	ArgMutationUnion
+	ArgMutationCount
+)
+
+var argMutationNames = [ArgMutationCount]string{"int", "flags", "len", "resource", "vma", "proc",
+	"buffer", "array", "ptr", "struct", "union"}
+
+func (m ArgMutation) String() string {
+	return argMutationNames[m]
+}
+
+func argMutationOf(typ Type) ArgMutation {
+	switch typ.(type) {
+	case *IntType:
+		return ArgMutationInt
+	case *FlagsType:
+		return ArgMutationFlags
+	case *LenType:
+		return ArgMutationLen
+	case *ResourceType:
+		return ArgMutationResource
+	case *VmaType:
+		return ArgMutationVma
+	case *ProcType:
+		return ArgMutationProc
+	case *BufferType:
+		return ArgMutationBuffer
+	case *ArrayType:
+		return ArgMutationArray
+	case *PtrType:
+		return ArgMutationPtr
+	case *StructType:
+		return ArgMutationStruct
+	case *UnionType:
+		return ArgMutationUnion
+	default:
+		panic(fmt.Sprintf("unexpected mutated type %T", typ))
+	}
+}
+
+// MutationStats counts the mutations that were successfully applied to a program.
+type MutationStats struct {
+	Ops  [MutationOpCount]int
+	Args [ArgMutationCount]int
+}
+
+func (o MutateOpts) Weight(op MutationOp) int {
+	switch op {
+	case MutationSquash:
+		return o.SquashWeight
+	case MutationSplice:
+		return o.SpliceWeight
+	case MutationInsert:
+		return o.InsertWeight
+	case MutationMutateArg:
+		return o.MutateArgWeight
+	case MutationRemoveCall:
+		return o.RemoveCallWeight
+	case MutationCrossover:
+		return o.CrossoverWeight
+	default:
+		panic(fmt.Sprintf("unknown mutation op %v", int(op)))
+	}
+}
+
+func (o *MutateOpts) SetWeight(op MutationOp, weight int) {
+	switch op {
+	case MutationSquash:
+		o.SquashWeight = weight
+	case MutationSplice:
+		o.SpliceWeight = weight
+	case MutationInsert:
+		o.InsertWeight = weight
+	case MutationMutateArg:
+		o.MutateArgWeight = weight
+	case MutationRemoveCall:
+		o.RemoveCallWeight = weight
+	case MutationCrossover:
+		o.CrossoverWeight = weight
+	default:
+		panic(fmt.Sprintf("unknown mutation op %v", int(op)))
+	}
+}
+
+func (o MutateOpts) ArgWeight(kind ArgMutation) int {
+	if weight := o.ArgWeights[kind]; weight != 0 {
+		return weight
+	}
+	return DefaultArgWeight
 }
 
 func (o MutateOpts) weight() int {
-	return o.SquashWeight + o.SpliceWeight + o.InsertWeight + o.MutateArgWeight + o.RemoveCallWeight
+	total := 0
+	for op := MutationOp(0); op < MutationOpCount; op++ {
+		total += o.Weight(op)
+	}
+	return total
+}
+
+func (o MutateOpts) chooseOp(val int) MutationOp {
+	for op := MutationOp(0); op < MutationOpCount-1; op++ {
+		val -= o.Weight(op)
+		if val < 0 {
+			return op
+		}
+	}
+	return MutationOpCount - 1
 }
 
+// MutateWithOpts is like Mutate, but allows to override the mutation parameters.
+// It returns the statistics of the applied mutations.
 func (p *Prog) MutateWithOpts(rs rand.Source, ncalls int, ct *ChoiceTable, noMutate map[int]bool,
-	corpus []*Prog, opts MutateOpts) {
+	corpus []*Prog, opts MutateOpts) MutationStats {
 	if p.isUnsafe {
 		panic("mutation of unsafe programs is not supposed to be done")
 	}
@@ -71,36 +223,33 @@ func (p *Prog) MutateWithOpts(rs rand.Source, ncalls int, ct *ChoiceTable, noMut
 		opts:     opts,
 	}
 	for stop, ok := false, false; !stop; stop = ok && len(p.Calls) != 0 && r.oneOf(opts.ExpectedIterations) {
-		val := r.Intn(totalWeight)
-		val -= opts.SquashWeight
-		if val < 0 {
+		op := opts.chooseOp(r.Intn(totalWeight))
+		switch op {
+		case MutationSquash:
 			// Not all calls have anything squashable,
 			// so this has lower priority in reality.
 			ok = ctx.squashAny()
-			continue
-		}
-		val -= opts.SpliceWeight
-		if val < 0 {
+		case MutationSplice:
 			ok = ctx.splice()
-			continue
-		}
-		val -= opts.InsertWeight
-		if val < 0 {
+		case MutationInsert:
 			ok = ctx.insertCall()
-			continue
-		}
-		val -= opts.MutateArgWeight
-		if val < 0 {
+		case MutationMutateArg:
 			ok = ctx.mutateArg()
-			continue
+		case MutationCrossover:
+			ok = ctx.crossover()
+		default:
+			ok = ctx.removeCall()
+		}
+		if ok {
+			ctx.stats.Ops[op]++
 		}
-		ok = ctx.removeCall()
 	}
 	p.sanitizeFix()
 	p.debugValidate()
 	if got := len(p.Calls); got < 1 || got > ncalls {
 		panic(fmt.Sprintf("bad number of calls after mutation: %v, want [1, %v]", got, ncalls))
 	}
+	return ctx.stats
 }
 
 // Internal state required for performing mutations -- currently this matches
@@ -113,6 +262,7 @@ type mutator struct {
 	noMutate map[int]bool // Set of IDs of syscalls which should not be mutated.
 	corpus   []*Prog      // The entire corpus, including original program p.
 	opts     MutateOpts
+	stats    MutationStats
 }
 
 // This function selects a random other program p0 out of the corpus, and
@@ -230,18 +380,20 @@ func (ctx *mutator) mutateArg() bool {
 	updateSizes := true
 	for stop, ok := false, false; !stop; stop = ok && r.oneOf(ctx.opts.MutateArgCount) {
 		ok = true
-		ma := &mutationArgs{target: p.Target}
+		ma := &mutationArgs{target: p.Target, opts: &ctx.opts}
 		ForeachArg(c, ma.collectArg)
 		if len(ma.args) == 0 {
 			return false
 		}
 		s := analyze(ctx.ct, ctx.corpus, p, c)
 		arg, argCtx := ma.chooseArg(r.Rand)
+		kind := argMutationOf(arg.Type())
 		calls, ok1 := p.Target.mutateArg(r, s, arg, argCtx, &updateSizes)
 		if !ok1 {
 			ok = false
 			continue
 		}
+		ctx.stats.Args[kind]++
 		moreCalls, fieldsPatched := r.patchConditionalFields(c, s)
 		calls = append(calls, moreCalls...)
 		p.insertBefore(c, calls)
@@ -561,6 +713,7 @@ func (t *ConstType) mutate(r *randGen, s *state, arg Arg, ctx ArgCtx) (calls []*
 
 type mutationArgs struct {
 	target        *Target
+	opts          *MutateOpts // scales priorities with ArgWeights, if set
 	ignoreSpecial bool
 	prioSum       float64
 	args          []mutationArg
@@ -597,6 +750,9 @@ func (ma *mutationArgs) collectArg(arg Arg, ctx *ArgCtx) {
 		return
 	}
 
+	if ma.opts != nil {
+		prio *= float64(ma.opts.ArgWeight(argMutationOf(typ))) / DefaultArgWeight
+	}
 	if len(ma.args) == 0 {
 		ma.args = ma.argsBuffer[:0]
 	}
@@ -256,6 +256,71 @@ func TestMutateCorpus(t *testing.T) {
 	}
 }
 
+func TestMutateStats(t *testing.T) {
+	target, rs, iters := initTest(t)
+	ct := target.DefaultChoiceTable()
+	for i := 0; i < iters; i++ {
+		p := target.Generate(rs, 10, ct)
+		opts := DefaultMutateOpts
+		opts.SetWeight(MutationSplice, 0)
+		opts.SetWeight(MutationMutateArg, 0)
+		stats := p.MutateWithOpts(rs, 10, ct, nil, nil, opts)
+		total := 0
+		for _, n := range stats.Ops {
+			total += n
+		}
+		if total == 0 {
+			t.Fatalf("no mutations were recorded")
+		}
+		if stats.Ops[MutationSplice] != 0 || stats.Ops[MutationMutateArg] != 0 {
+			t.Fatalf("disabled mutations were applied: %+v", stats.Ops)
+		}
+		for _, n := range stats.Args {
+			if n != 0 {
+				t.Fatalf("argument mutations without mutate arg op: %+v", stats.Args)
+			}
+		}
+	}
+}
+
+func TestMutateArgWeights(t *testing.T) {
+	target, rs, iters := initTest(t)
+	ct := target.DefaultChoiceTable()
+	opts := DefaultMutateOpts
+	for op := MutationOp(0); op < MutationOpCount; op++ {
+		opts.SetWeight(op, 0)
+	}
+	opts.SetWeight(MutationMutateArg, 1)
+	bufferOpts := opts
+	for kind := range bufferOpts.ArgWeights {
+		bufferOpts.ArgWeights[kind] = 1
+	}
+	bufferOpts.ArgWeights[ArgMutationBuffer] = 100 * DefaultArgWeight
+	var def, buffer MutationStats
+	for i := 0; i < iters; i++ {
+		p := target.Generate(rs, 10, ct)
+		stats := p.Clone().MutateWithOpts(rs, 10, ct, nil, nil, opts)
+		for kind, n := range stats.Args {
+			def.Args[kind] += n
+		}
+		stats = p.Clone().MutateWithOpts(rs, 10, ct, nil, nil, bufferOpts)
+		for kind, n := range stats.Args {
+			buffer.Args[kind] += n
+		}
+	}
+	share := func(stats MutationStats) float64 {
+		total := 0
+		for _, n := range stats.Args {
+			total += n
+		}
+		return float64(stats.Args[ArgMutationBuffer]) / float64(max(total, 1))
+	}
+	if share(buffer) <= share(def) {
+		t.Fatalf("buffer weight is not respected: default share %.2f, weighted share %.2f",
+			share(def), share(buffer))
+	}
+}
+
 func TestMutateTable(t *testing.T) {
 	tests := [][2]string{
 		// Insert a call.
@@ -5,6 +5,7 @@ package prog
 
 import (
 	"fmt"
+	"maps"
 	"math"
 	"math/rand"
 	"slices"
@@ -23,24 +24,39 @@ import (
 // pair of syscalls in a single program in corpus. For example, if socket and
 // connect frequently occur in programs together, we give higher priority to
 // this pair of syscalls.
+// Optionally, there is also a learned component that is based on the pairs
+// of consecutive calls in executed programs that actually gave new coverage
+// (see CallTransitions).
 // Note: the current implementation is very basic, there is no theory behind any
 // constants.
 
 func (target *Target) CalculatePriorities(corpus []*Prog) [][]int32 {
+	return target.CalculateLearnedPriorities(corpus, nil)
+}
+
+// CalculateLearnedPriorities is like CalculatePriorities, but also takes into account
+// the learned call transitions.
+func (target *Target) CalculateLearnedPriorities(corpus []*Prog, transitions CallTransitions) [][]int32 {
 	static := target.calcStaticPriorities()
 	if len(corpus) != 0 {
 		// Let's just sum the static and dynamic distributions.
 		dynamic := target.calcDynamicPrio(corpus)
-		for i, prios := range dynamic {
-			dst := static[i]
-			for j, p := range prios {
-				dst[j] += p
-			}
-		}
+		addPrios(static, dynamic)
+	}
+	if len(transitions) != 0 {
+		addPrios(static, target.calcLearnedPrio(transitions))
 	}
 	return static
 }
 
+func addPrios(dst, src [][]int32) {
+	for i, prios := range src {
+		for j, p := range prios {
+			dst[i][j] += p
+		}
+	}
+}
+
 func (target *Target) calcStaticPriorities() [][]int32 {
 	uses := target.calcResourceUsage()
 	prios := make([][]int32, len(target.Syscalls))
@@ -179,6 +195,60 @@ func (target *Target) calcDynamicPrio(corpus []*Prog) [][]int32 {
 	return prios
 }
 
+// CallTransitions maps syscall ID of the previous call in a program and syscall ID
+// of the next call to the number of times the next call has given new coverage.
+type CallTransitions map[int]map[int]int32
+
+// Add records that the call with ID next has given new coverage after the call with ID prev.
+func (ct CallTransitions) Add(prev, next int) {
+	if ct[prev] == nil {
+		ct[prev] = make(map[int]int32)
+	}
+	ct[prev][next]++
+}
+
+// Clone returns a deep copy of the transitions.
+func (ct CallTransitions) Clone() CallTransitions {
+	res := make(CallTransitions, len(ct))
+	for prev, nexts := range ct {
+		res[prev] = maps.Clone(nexts)
+	}
+	return res
+}
+
+// The number of observed transitions from a call after which the learned distribution
+// for the call gets the same weight as the static and dynamic ones.
+// Before that, the learned distribution is scaled down, so that a few lucky
+// transitions don't dominate the choice.
+const learnedPrioConfidence = 100
+
+func (target *Target) calcLearnedPrio(transitions CallTransitions) [][]int32 {
+	prios := make([][]int32, len(target.Syscalls))
+	for i := range prios {
+		prios[i] = make([]int32, len(target.Syscalls))
+	}
+	for prev, nexts := range transitions {
+		for next, val := range nexts {
+			// Same as for the dynamic priorities, lessen the effect of large counts.
+			prios[prev][next] = int32(2.0 * math.Sqrt(float64(val)))
+		}
+	}
+	normalizePrios(prios)
+	for prev, nexts := range transitions {
+		total := int32(0)
+		for _, val := range nexts {
+			total += val
+		}
+		if total >= learnedPrioConfidence {
+			continue
+		}
+		for next := range nexts {
+			prios[prev][next] = prios[prev][next] * total / learnedPrioConfidence
+		}
+	}
+	return prios
+}
+
 // normalizePrio distributes |N| * 10 points proportional to the values in the matrix.
 func normalizePrios(prios [][]int32) {
 	total := 10 * int32(len(prios))
@@ -205,6 +275,14 @@ type ChoiceTable struct {
 }
 
 func (target *Target) BuildChoiceTable(corpus []*Prog, enabled map[*Syscall]bool) *ChoiceTable {
+	return target.BuildWeightedChoiceTable(corpus, enabled, nil, nil)
+}
+
+// BuildWeightedChoiceTable is like BuildChoiceTable, but additionally multiplies
+// the priorities of choosing syscalls by the given weights (syscalls not present in weights
+// have weight 1) and takes into account the learned call transitions (can be nil).
+func (target *Target) BuildWeightedChoiceTable(corpus []*Prog, enabled map[*Syscall]bool,
+	weights map[*Syscall]int32, transitions CallTransitions) *ChoiceTable {
 	if enabled == nil {
 		enabled = make(map[*Syscall]bool)
 		for _, c := range target.Syscalls {
@@ -241,7 +319,12 @@ func (target *Target) BuildChoiceTable(corpus []*Prog, enabled map[*Syscall]bool
 			}
 		}
 	}
-	prios := target.CalculatePriorities(corpus)
+	prios := target.CalculateLearnedPriorities(corpus, transitions)
+	for call, weight := range weights {
+		for i := range prios {
+			prios[i][call.ID] *= weight
+		}
+	}
 	run := make([][]int32, len(target.Syscalls))
 	// ChoiceTable.runs[][] contains cumulated sum of weighted priority numbers.
 	// This helps in quick binary search with biases when generating programs.
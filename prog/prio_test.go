@@ -94,3 +94,45 @@ func TestPrioDeterminism(t *testing.T) {
 		}
 	}
 }
+
+func TestWeightedChoiceTable(t *testing.T) {
+	target := initTargetTest(t, "linux", "amd64")
+	open, write := target.SyscallMap["open"], target.SyscallMap["write"]
+	ct := target.BuildChoiceTable(nil, nil)
+	weighted := target.BuildWeightedChoiceTable(nil, nil, map[*Syscall]int32{write: 10}, nil)
+	prob := func(ct *ChoiceTable, from, to *Syscall) float64 {
+		run := ct.runs[from.ID]
+		return float64(run[to.ID]-run[to.ID-1]) / float64(run[len(run)-1])
+	}
+	before, after := prob(ct, open, write), prob(weighted, open, write)
+	if before == 0 || after < 5*before {
+		t.Fatalf("the weight was not applied: probability before %v, after %v", before, after)
+	}
+}
+
+func TestLearnedPriorities(t *testing.T) {
+	target := initTargetTest(t, "linux", "amd64")
+	open, read, write := target.SyscallMap["open"], target.SyscallMap["read"], target.SyscallMap["write"]
+	static := target.CalculatePriorities(nil)
+	transitions := make(CallTransitions)
+	for i := 0; i < learnedPrioConfidence; i++ {
+		transitions.Add(open.ID, write.ID)
+	}
+	learned := target.CalculateLearnedPriorities(nil, transitions)
+	if learned[open.ID][write.ID] <= static[open.ID][write.ID] {
+		t.Fatalf("open->write priority was not increased: %v -> %v",
+			static[open.ID][write.ID], learned[open.ID][write.ID])
+	}
+	if learned[open.ID][read.ID] != static[open.ID][read.ID] {
+		t.Fatalf("open->read priority has changed: %v -> %v",
+			static[open.ID][read.ID], learned[open.ID][read.ID])
+	}
+	// A single observation must have smaller effect.
+	transitions = make(CallTransitions)
+	transitions.Add(open.ID, write.ID)
+	single := target.CalculateLearnedPriorities(nil, transitions)
+	if single[open.ID][write.ID] >= learned[open.ID][write.ID] {
+		t.Fatalf("single transition has too large effect: %v vs %v",
+			single[open.ID][write.ID], learned[open.ID][write.ID])
+	}
+}
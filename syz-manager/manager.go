@@ -24,12 +24,14 @@ import (
 	"github.com/google/syzkaller/dashboard/dashapi"
 	"github.com/google/syzkaller/pkg/asset"
 	"github.com/google/syzkaller/pkg/corpus"
+	"github.com/google/syzkaller/pkg/corpus/schedule"
 	"github.com/google/syzkaller/pkg/csource"
 	"github.com/google/syzkaller/pkg/db"
 	"github.com/google/syzkaller/pkg/flatrpc"
 	"github.com/google/syzkaller/pkg/fuzzer"
 	"github.com/google/syzkaller/pkg/fuzzer/queue"
 	"github.com/google/syzkaller/pkg/gce"
+	"github.com/google/syzkaller/pkg/hash"
 	"github.com/google/syzkaller/pkg/ifaceprobe"
 	"github.com/google/syzkaller/pkg/image"
 	"github.com/google/syzkaller/pkg/log"
@@ -80,7 +82,9 @@ type Manager struct {
 	checkDone       atomic.Bool
 	reportGenerator *manager.ReportGeneratorWrapper
 	fresh           bool
+	checkpoint      *fuzzer.State // fuzzer state saved by the previous run, if any
 	coverFilters    manager.CoverageFilters
+	distances       map[uint64]uint32 // PC -> call graph distance to the directed fuzzing targets
 
 	dash *dashapi.Dashboard
 	// This is specifically separated from dash, so that we can keep dash = nil when
@@ -362,6 +366,7 @@ func RunManager(mode *Mode, cfg *mgrconfig.Config) {
 	}
 	mgr.pool = vm.NewDispatcher(mgr.vmPool, mgr.fuzzerInstance)
 	mgr.http.Pool = mgr.pool
+	mgr.http.ExecutorHealth = mgr.serv.ExecutorHealth
 	reproVMs := max(0, mgr.vmPool.Count()-mgr.cfg.FuzzingVMs)
 	mgr.reproLoop = manager.NewReproLoop(mgr, reproVMs, mgr.cfg.DashboardOnlyRepro)
 	mgr.http.ReproLoop = mgr.reproLoop
@@ -511,6 +516,12 @@ func (mgr *Manager) RunRepro(ctx context.Context, crash *manager.Crash) *manager
 		Features: mgr.enabledFeatures,
 		Reporter: mgr.reporter,
 		Pool:     mgr.pool,
+		Parallel: mgr.cfg.Experimental.ReproParallel,
+		Attempts: mgr.cfg.Experimental.ReproAttempts,
+		Snapshot: mgr.cfg.Experimental.SnapshotRepro,
+		Sched:    mgr.cfg.Experimental.ReproSched,
+
+		MinimizeC: mgr.cfg.Experimental.ReproMinimizeC,
 	})
 	ret := &manager.ReproResult{
 		Crash: crash,
@@ -563,6 +574,12 @@ func (mgr *Manager) preloadCorpus() {
 	}
 	mgr.fresh = info.Fresh
 	mgr.corpusDB = info.CorpusDB
+	if mgr.mode == ModeFuzzing && mgr.cfg.Experimental.CheckpointPeriod != 0 {
+		mgr.checkpoint, err = manager.LoadCheckpoint(mgr.cfg)
+		if err != nil {
+			log.Errorf("failed to load the fuzzer state: %v", err)
+		}
+	}
 	mgr.corpusPreload <- info.Candidates
 }
 
@@ -619,6 +636,7 @@ func (mgr *Manager) fuzzerInstance(ctx context.Context, inst *vm.Instance, updIn
 	if err == nil && rep != nil {
 		mgr.crashes <- &manager.Crash{
 			InstanceIndex: inst.Index(),
+			Signature:     manager.NewCrashSignature(mgr.target, rep, lastExec),
 			Report:        rep,
 		}
 	}
@@ -864,7 +882,7 @@ func (mgr *Manager) saveRepro(res *manager.ReproResult) {
 
 	var cprogText []byte
 	if repro.CRepro {
-		cprog, err := csource.Write(repro.Prog, repro.Opts)
+		cprog, err := repro.WriteC()
 		if err == nil {
 			formatted, err := csource.Format(cprog)
 			if err == nil {
@@ -908,6 +926,8 @@ func (mgr *Manager) saveRepro(res *manager.ReproResult) {
 			ReproSyz:      progText,
 			ReproC:        cprogText,
 			ReproLog:      truncateReproLog(res.Stats.FullLog()),
+			ReproRuns:     repro.Runs,
+			ReproCrashes:  repro.Crashes,
 			Assets:        mgr.uploadReproAssets(repro),
 			OriginalTitle: res.Crash.Title,
 		}
@@ -1133,19 +1153,52 @@ func (mgr *Manager) MachineChecked(features flatrpc.Feature,
 		corpusUpdates := make(chan corpus.NewItemEvent, 128)
 		mgr.corpus = corpus.NewFocusedCorpus(context.Background(),
 			corpusUpdates, mgr.coverFilters.Areas)
+		sched, err := schedule.Parse(mgr.cfg.Experimental.CorpusSchedule)
+		if err != nil {
+			return nil, err
+		}
+		mgr.corpus.SetSchedule(sched)
+		if mgr.distances != nil {
+			mgr.corpus.SetDistances(mgr.distances)
+		}
 		mgr.http.Corpus.Store(mgr.corpus)
 
+		var budget *fuzzer.ExecBudget
+		if mgr.cfg.Experimental.ExecBudget {
+			budget, err = fuzzer.ParseExecBudget(false, mgr.cfg.Experimental.ExecShares,
+				mgr.cfg.Experimental.ExecDeadlines)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := fuzzer.ValidateStrategies(mgr.cfg.Experimental.Strategies); err != nil {
+			return nil, err
+		}
+		var jobLog *fuzzer.JobLog
+		if mgr.cfg.Experimental.JobLogSize != 0 {
+			jobLog, err = fuzzer.NewJobLog(filepath.Join(mgr.cfg.Workdir, "jobs.jsonl"),
+				int64(mgr.cfg.Experimental.JobLogSize)<<20)
+			if err != nil {
+				return nil, err
+			}
+		}
 		rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
 		fuzzerObj := fuzzer.NewFuzzer(context.Background(), &fuzzer.Config{
-			Corpus:         mgr.corpus,
-			Snapshot:       mgr.cfg.Snapshot,
-			Coverage:       mgr.cfg.Cover,
-			FaultInjection: features&flatrpc.FeatureFault != 0,
-			Comparisons:    features&flatrpc.FeatureComparisons != 0,
-			Collide:        true,
-			EnabledCalls:   enabledSyscalls,
-			NoMutateCalls:  mgr.cfg.NoMutateCalls,
-			FetchRawCover:  mgr.cfg.RawCover,
+			Corpus:            mgr.corpus,
+			Snapshot:          mgr.cfg.Snapshot,
+			Coverage:          mgr.cfg.Cover,
+			FaultInjection:    features&flatrpc.FeatureFault != 0,
+			Comparisons:       features&flatrpc.FeatureComparisons != 0,
+			Collide:           true,
+			EnabledCalls:      enabledSyscalls,
+			NoMutateCalls:     mgr.cfg.NoMutateCalls,
+			FetchRawCover:     mgr.cfg.RawCover,
+			AdaptiveMutations: mgr.cfg.Experimental.AdaptiveMutations,
+			Crossover:         mgr.cfg.Experimental.Crossover,
+			HintsTaint:        mgr.cfg.Experimental.HintsTaint,
+			JobLog:            jobLog,
+			ExecBudget:        budget,
+			Strategies:        mgr.cfg.Experimental.Strategies,
 			Logf: func(level int, msg string, args ...interface{}) {
 				if level != 0 {
 					return
@@ -1158,6 +1211,9 @@ func (mgr *Manager) MachineChecked(features flatrpc.Feature,
 				return !mgr.saturatedCalls[call]
 			},
 		}, rnd, mgr.target)
+		if mgr.checkpoint != nil {
+			candidates = mgr.restoreCheckpoint(fuzzerObj, candidates)
+		}
 		fuzzerObj.AddCandidates(candidates)
 		mgr.fuzzer.Store(fuzzerObj)
 		mgr.http.Fuzzer.Store(fuzzerObj)
@@ -1165,6 +1221,9 @@ func (mgr *Manager) MachineChecked(features flatrpc.Feature,
 		go mgr.corpusInputHandler(corpusUpdates)
 		go mgr.corpusMinimization()
 		go mgr.fuzzerLoop(fuzzerObj)
+		if mgr.mode == ModeFuzzing && mgr.cfg.Experimental.CheckpointPeriod != 0 {
+			go mgr.checkpointLoop(vm.ShutdownCtx(), fuzzerObj)
+		}
 		if mgr.dash != nil {
 			go mgr.dashboardReporter()
 			if mgr.cfg.Reproduce {
@@ -1263,6 +1322,49 @@ func (mgr *Manager) corpusMinimization() {
 	}
 }
 
+// restoreCheckpoint restores the fuzzer state saved by the previous run
+// and returns the candidates that still need to be triaged.
+func (mgr *Manager) restoreCheckpoint(fuzzerObj *fuzzer.Fuzzer, candidates []fuzzer.Candidate) []fuzzer.Candidate {
+	sigs := make([]string, len(candidates))
+	corpusSigs := make(map[string]bool)
+	for i, candidate := range candidates {
+		// Seeds are not in the corpus database, they are triaged on every start.
+		if candidate.Flags&fuzzer.ProgFromCorpus != 0 {
+			sigs[i] = hash.String(candidate.Prog.Serialize())
+			corpusSigs[sigs[i]] = true
+		}
+	}
+	restored, triage := fuzzerObj.RestoreState(mgr.checkpoint, func(sig string) bool {
+		return corpusSigs[sig]
+	})
+	mgr.checkpoint = nil
+	var rest []fuzzer.Candidate
+	for i, candidate := range candidates {
+		if sigs[i] == "" || !restored[sigs[i]] {
+			rest = append(rest, candidate)
+		}
+	}
+	rest = append(rest, triage...)
+	log.Logf(0, "restored the fuzzer state: %v corpus programs, %v left to triage (%v were in triage)",
+		len(restored), len(rest), len(triage))
+	return rest
+}
+
+func (mgr *Manager) checkpointLoop(ctx context.Context, fuzzerObj *fuzzer.Fuzzer) {
+	ticker := time.NewTicker(time.Duration(mgr.cfg.Experimental.CheckpointPeriod) * time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		if err := manager.SaveCheckpoint(mgr.cfg, fuzzerObj.State()); err != nil {
+			log.Errorf("failed to save the fuzzer state: %v", err)
+		}
+	}
+}
+
 func (mgr *Manager) MaxSignal() signal.Signal {
 	if fuzzer := mgr.fuzzer.Load(); fuzzer != nil {
 		return fuzzer.Cover.CopyMaxSignal()
@@ -1454,6 +1556,10 @@ func (mgr *Manager) CoverageFilter(modules []*vminfo.KernelModule) ([]uint64, er
 		return nil, fmt.Errorf("failed to init coverage filter: %w", err)
 	}
 	mgr.coverFilters = filters
+	mgr.distances, err = manager.DirectedDistances(mgr.reportGenerator, mgr.cfg, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate directed fuzzing distances: %w", err)
+	}
 	mgr.http.Cover.Store(&manager.CoverageInfo{
 		Modules:         modules,
 		ReportGenerator: mgr.reportGenerator,
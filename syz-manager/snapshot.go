@@ -13,6 +13,7 @@ import (
 	flatbuffers "github.com/google/flatbuffers/go"
 	"github.com/google/syzkaller/pkg/flatrpc"
 	"github.com/google/syzkaller/pkg/fuzzer/queue"
+	"github.com/google/syzkaller/pkg/instance"
 	"github.com/google/syzkaller/pkg/log"
 	"github.com/google/syzkaller/pkg/manager"
 	"github.com/google/syzkaller/vm"
@@ -34,19 +35,6 @@ func (mgr *Manager) snapshotInstance(ctx context.Context, inst *vm.Instance, upd
 }
 
 func (mgr *Manager) snapshotLoop(ctx context.Context, inst *vm.Instance) error {
-	executor, err := inst.Copy(mgr.cfg.ExecutorBin)
-	if err != nil {
-		return err
-	}
-	// All network connections (including ssh) will break once we start restoring snapshots.
-	// So we start a background process and log to /dev/kmsg.
-	cmd := fmt.Sprintf("nohup %v exec snapshot 1>/dev/null 2>/dev/kmsg </dev/null &", executor)
-	ctxTimeout, cancel := context.WithTimeout(ctx, time.Hour)
-	defer cancel()
-	if _, _, err := inst.Run(ctxTimeout, mgr.reporter, cmd); err != nil {
-		return err
-	}
-
 	builder := flatbuffers.NewBuilder(0)
 	var envFlags flatrpc.ExecEnv
 	for first := true; ctx.Err() == nil; first = false {
@@ -54,7 +42,8 @@ func (mgr *Manager) snapshotLoop(ctx context.Context, inst *vm.Instance) error {
 		req := mgr.snapshotSource.Next(inst.Index())
 		if first {
 			envFlags = req.ExecOpts.EnvFlags
-			if err := mgr.snapshotSetup(inst, builder, envFlags); err != nil {
+			if err := instance.SetupSnapshot(ctx, inst, mgr.cfg, mgr.reporter, builder,
+				mgr.enabledFeatures, envFlags, mgr.cfg.SandboxArg); err != nil {
 				req.Done(&queue.Result{Status: queue.Crashed})
 				return err
 			}
@@ -85,22 +74,6 @@ func (mgr *Manager) snapshotLoop(ctx context.Context, inst *vm.Instance) error {
 	return nil
 }
 
-func (mgr *Manager) snapshotSetup(inst *vm.Instance, builder *flatbuffers.Builder, env flatrpc.ExecEnv) error {
-	msg := flatrpc.SnapshotHandshakeT{
-		CoverEdges:       mgr.cfg.Experimental.CoverEdges,
-		Kernel64Bit:      mgr.cfg.SysTarget.PtrSize == 8,
-		Slowdown:         int32(mgr.cfg.Timeouts.Slowdown),
-		SyscallTimeoutMs: int32(mgr.cfg.Timeouts.Syscall / time.Millisecond),
-		ProgramTimeoutMs: int32(mgr.cfg.Timeouts.Program / time.Millisecond),
-		Features:         mgr.enabledFeatures,
-		EnvFlags:         env,
-		SandboxArg:       mgr.cfg.SandboxArg,
-	}
-	builder.Reset()
-	builder.Finish(msg.Pack(builder))
-	return inst.SetupSnapshot(builder.FinishedBytes())
-}
-
 func (mgr *Manager) snapshotRun(inst *vm.Instance, builder *flatbuffers.Builder, req *queue.Request) (
 	*queue.Result, []byte, error) {
 	progData, err := req.Prog.SerializeForExec()
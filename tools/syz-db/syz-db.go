@@ -14,12 +14,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/syzkaller/pkg/corpus"
 	"github.com/google/syzkaller/pkg/db"
 	"github.com/google/syzkaller/pkg/hash"
+	"github.com/google/syzkaller/pkg/manager"
+	"github.com/google/syzkaller/pkg/mgrconfig"
 	"github.com/google/syzkaller/pkg/osutil"
 	"github.com/google/syzkaller/pkg/tool"
 	"github.com/google/syzkaller/prog"
 	_ "github.com/google/syzkaller/sys"
+	"github.com/google/syzkaller/vm"
 	"golang.org/x/exp/maps"
 )
 
@@ -28,6 +32,8 @@ func main() {
 		flagVersion = flag.Uint64("version", 0, "database version")
 		flagOS      = flag.String("os", runtime.GOOS, "target OS")
 		flagArch    = flag.String("arch", runtime.GOARCH, "target arch")
+		flagConfig  = flag.String("config", "", "manager config (for distill)")
+		flagDebug   = flag.Bool("debug", false, "dump VM output (for distill)")
 	)
 	flag.Parse()
 	args := flag.Args()
@@ -45,6 +51,17 @@ func main() {
 		bench(target, args[1])
 		return
 	}
+	if args[0] == "distill" {
+		if len(args) != 3 || *flagConfig == "" {
+			usage()
+		}
+		cfg, err := mgrconfig.LoadFile(*flagConfig)
+		if err != nil {
+			tool.Fail(err)
+		}
+		distill(args[1], args[2], cfg, *flagDebug)
+		return
+	}
 	var target *prog.Target
 	if *flagOS != "" || *flagArch != "" {
 		var err error
@@ -89,6 +106,8 @@ func usage() {
 databases that are used by syz-managers. The following generic arguments are
 offered:
   -arch string
+  -config string
+  -debug
   -os string
   -version uint
   -vv int
@@ -106,6 +125,9 @@ offered:
     syz-db print corpus.db
   remove a syscall from db
     syz-db rm corpus.db syscall_name
+  distill a database: replay all programs in VMs and keep a minimal subset
+  of programs that preserves the total coverage:
+    syz-db -config=manager.cfg distill corpus.db distilled.db
 `)
 	os.Exit(1)
 }
@@ -276,3 +298,86 @@ func rm(file, syscall string, target *prog.Target) {
 		tool.Fail(err)
 	}
 }
+
+func distill(file, outFile string, cfg *mgrconfig.Config, debug bool) {
+	srcDB, err := db.Open(file, false)
+	if err != nil {
+		tool.Failf("failed to open database: %v", err)
+	}
+	keys := maps.Keys(srcDB.Records)
+	sort.Strings(keys)
+	var progs []*prog.Prog
+	var progKeys []string
+	keep := make(map[string]string)
+	for _, key := range keys {
+		p, err := cfg.Target.Deserialize(srcDB.Records[key].Val, prog.NonStrict)
+		if err != nil {
+			keep[key] = fmt.Sprintf("failed to deserialize: %v", err)
+			continue
+		}
+		progs = append(progs, p)
+		progKeys = append(progKeys, key)
+	}
+	osutil.HandleInterrupts(vm.Shutdown)
+	results, err := manager.ReplayPrograms(vm.ShutdownCtx(), cfg, progs, debug)
+	if err != nil {
+		tool.Fail(err)
+	}
+	var inputs []corpus.DistillInput
+	var inputKeys []string
+	totalCover := make(map[uint64]bool)
+	for i, res := range results {
+		if res.Err != nil {
+			// We don't know what these programs cover, so don't drop them.
+			keep[progKeys[i]] = res.Err.Error()
+			continue
+		}
+		for _, pc := range res.Cover {
+			totalCover[pc] = true
+		}
+		inputs = append(inputs, corpus.DistillInput{Prog: progs[i], Cover: res.Cover})
+		inputKeys = append(inputKeys, progKeys[i])
+	}
+	selected := make(map[int]bool)
+	for _, idx := range corpus.Distill(inputs) {
+		selected[idx] = true
+		keep[inputKeys[idx]] = ""
+	}
+	retainedCover := make(map[uint64]bool)
+	for idx := range selected {
+		for _, pc := range inputs[idx].Cover {
+			retainedCover[pc] = true
+		}
+	}
+	var records []db.Record
+	for _, key := range keys {
+		if _, ok := keep[key]; ok {
+			records = append(records, srcDB.Records[key])
+		}
+	}
+	// Programs that returned no coverage on replay are dropped as well (e.g. they crashed the VM
+	// or the kernel does not support them), count them separately since they are not redundant.
+	emptyCover := 0
+	for idx, inp := range inputs {
+		if selected[idx] {
+			continue
+		}
+		if len(inp.Cover) == 0 {
+			emptyCover++
+			fmt.Printf("dropped %v: %v calls, no coverage\n", inputKeys[idx], len(inp.Prog.Calls))
+			continue
+		}
+		fmt.Printf("dropped %v: %v calls, %v PCs\n", inputKeys[idx], len(inp.Prog.Calls), len(inp.Cover))
+	}
+	for _, key := range keys {
+		if reason := keep[key]; reason != "" {
+			fmt.Printf("kept %v without replay: %v\n", key, reason)
+		}
+	}
+	if err := db.Create(outFile, srcDB.Version, records); err != nil {
+		tool.Fail(err)
+	}
+	fmt.Printf("programs: %v, kept: %v, kept without replay: %v, dropped: %v (with no coverage: %v)\n",
+		len(keys), len(records), len(keys)-len(inputs), len(keys)-len(records), emptyCover)
+	fmt.Printf("coverage: retained %v out of %v PCs\n", len(retainedCover), len(totalCover))
+}
@@ -47,6 +47,7 @@ var (
 	flagDisable    = flag.String("disable", "none", "enable all additional features except listed")
 	flagExecutor   = flag.String("executor", "./syz-executor", "path to executor binary")
 	flagThreaded   = flag.Bool("threaded", true, "use threaded mode in executor")
+	flagSched      = flag.String("sched", "", "scheduling perturbation in threaded mode (yield/pin/spread/delay)")
 	flagSignal     = flag.Bool("cover", false, "collect feedback signals (coverage)")
 	flagSandbox    = flag.String("sandbox", "none", "sandbox for fuzzing (none/setuid/namespace/android)")
 	flagSandboxArg = flag.Int("sandbox_arg", 0, "argument for sandbox runner to adjust it via config")
@@ -54,6 +55,12 @@ var (
 	flagSlowdown   = flag.Int("slowdown", 1, "execution slowdown caused by emulation/instrumentation")
 	flagUnsafe     = flag.Bool("unsafe", false, "use unsafe program deserialization mode")
 	flagGlob       = flag.String("glob", "", "run glob expansion request")
+	flagRead       = flag.String("read", "", "read files/globs (colon-separated) and print their contents")
+	flagReplay     = flag.String("replay", "", "replay execution session recorded by syz-manager "+
+		"(programs are executed in the original order on the original procs with the original options "+
+		"and approximately the original delays: while programs are executing, the next one is only started "+
+		"once one of them finishes, so delays shorter than an execution are not reproduced; "+
+		"-procs/-repeat and execution options are ignored)")
 
 	// The in the stress mode resembles simple unguided fuzzer.
 	// This mode can be used as an intermediate step when porting syzkaller to a new OS,
@@ -67,6 +74,8 @@ var (
 
 	flagGDB = flag.Bool("gdb", false, "start executor under gdb")
 
+	flagShmem = flag.Bool("shmem", false, "communicate with executor via shared memory instead of TCP (linux only)")
+
 	// The following flag is only kept to let syzkaller remain compatible with older execprog versions.
 	// In order to test incoming patches or perform bug bisection, syz-ci must use the exact syzkaller
 	// version that detected the bug (as descriptions and syntax could've already been changed), and
@@ -134,12 +143,25 @@ func main() {
 	if *flagThreaded {
 		exec |= flatrpc.ExecFlagThreaded
 	}
+	sched, err := flatrpc.SchedToFlags(*flagSched)
+	if err != nil {
+		tool.Failf("failed to parse sched: %v", err)
+	}
+	exec |= sched
 	if *flagCoverFile == "" {
 		exec |= flatrpc.ExecFlagDedupCover
 	}
 
-	progs := loadPrograms(target, flag.Args())
-	if *flagGlob == "" && !*flagStress && len(progs) == 0 {
+	var progs []*prog.Prog
+	var replay *replayer
+	procs, repeat := *flagProcs, *flagRepeat
+	if *flagReplay != "" {
+		replay = loadReplay(target, *flagReplay, deserializeMode())
+		progs, procs, repeat = replay.progs, replay.numProcs, 1
+	} else {
+		progs = loadPrograms(target, flag.Args())
+	}
+	if *flagGlob == "" && *flagRead == "" && !*flagStress && len(progs) == 0 {
 		flag.Usage()
 		os.Exit(1)
 	}
@@ -155,7 +177,8 @@ func main() {
 		signal:    *flagSignal,
 		hints:     *flagHints,
 		stress:    *flagStress,
-		repeat:    *flagRepeat,
+		replay:    replay,
+		repeat:    repeat,
 		defaultOpts: flatrpc.ExecOpts{
 			EnvFlags:   env,
 			ExecFlags:  exec,
@@ -175,12 +198,13 @@ func main() {
 				Sandbox:    sandbox,
 				SandboxArg: int64(*flagSandboxArg),
 			},
-			Procs:    *flagProcs,
+			Procs:    procs,
 			Slowdown: *flagSlowdown,
 		},
 		Executor:         *flagExecutor,
 		HandleInterrupts: true,
 		GDB:              *flagGDB,
+		SharedMemory:     *flagShmem,
 		MachineChecked:   ctx.machineChecked,
 	}
 	if err := rpcserver.RunLocal(rpcCtx, cfg); err != nil {
@@ -203,6 +227,7 @@ type Context struct {
 	signal      bool
 	hints       bool
 	stress      bool
+	replay      *replayer
 	repeat      int
 	pos         int
 	completed   atomic.Uint64
@@ -215,10 +240,25 @@ func (ctx *Context) machineChecked(features flatrpc.Feature, syscalls map[*prog.
 		ctx.choiceTable = ctx.target.BuildChoiceTable(ctx.progs, syscalls)
 	}
 	ctx.defaultOpts.EnvFlags |= csource.FeaturesToFlags(features, nil)
+	if ctx.replay != nil {
+		// Replayed programs use the recorded options, see Next.
+		return ctx
+	}
 	return queue.DefaultOpts(ctx, ctx.defaultOpts)
 }
 
 func (ctx *Context) Next() *queue.Request {
+	if *flagRead != "" {
+		if ctx.resultIndex.Add(1) != 1 {
+			return nil
+		}
+		req := &queue.Request{
+			Type:  flatrpc.RequestTypeReadFile,
+			Files: strings.Split(*flagRead, ":"),
+		}
+		req.OnDone(ctx.doneRead)
+		return req
+	}
 	if *flagGlob != "" {
 		idx := int(ctx.resultIndex.Add(1) - 1)
 		if idx >= len(ctx.globs) {
@@ -232,8 +272,18 @@ func (ctx *Context) Next() *queue.Request {
 		return req
 	}
 	var p *prog.Prog
+	var opts *flatrpc.ExecOpts
+	proc := -1
 	if ctx.stress {
 		p = ctx.createStressProg()
+	} else if ctx.replay != nil {
+		if p, proc, opts = ctx.replay.next(); p == nil {
+			return nil
+		}
+		if opts == nil {
+			// The session was recorded without options.
+			opts = &ctx.defaultOpts
+		}
 	} else {
 		idx := ctx.getProgramIndex()
 		if idx < 0 {
@@ -251,11 +301,21 @@ func (ctx *Context) Next() *queue.Request {
 	req := &queue.Request{
 		Prog: p,
 	}
+	if opts != nil {
+		req.ExecOpts = *opts
+	}
 	if ctx.hints {
 		req.ExecOpts.ExecFlags |= flatrpc.ExecFlagCollectComps
 	} else if ctx.signal || ctx.coverFile != "" {
 		req.ExecOpts.ExecFlags |= flatrpc.ExecFlagCollectSignal | flatrpc.ExecFlagCollectCover
 	}
+	if proc >= 0 {
+		req.Avoid = ctx.replay.avoid(proc)
+		req.OnDone(func(*queue.Request, *queue.Result) bool {
+			ctx.replay.finished(proc)
+			return true
+		})
+	}
 	req.OnDone(ctx.Done)
 	return req
 }
@@ -279,6 +339,22 @@ func (ctx *Context) doneGlob(req *queue.Request, res *queue.Result) bool {
 	return true
 }
 
+func (ctx *Context) doneRead(req *queue.Request, res *queue.Result) bool {
+	if res.Status == queue.Success {
+		for _, file := range res.Files {
+			if file.Error != "" {
+				fmt.Printf("%v: %v\n", file.Name, file.Error)
+				continue
+			}
+			fmt.Printf("%v (%v bytes):\n%s\n", file.Name, len(file.Data), file.Data)
+		}
+	} else {
+		fmt.Printf("request failed: %v (%v)\n%s\n", res.Status, res.Err, res.Output)
+	}
+	ctx.done()
+	return true
+}
+
 func (ctx *Context) Done(req *queue.Request, res *queue.Result) bool {
 	if res.Info != nil {
 		ctx.printCallResults(res.Info)
@@ -398,10 +474,7 @@ func (ctx *Context) createStressProg() *prog.Prog {
 
 func loadPrograms(target *prog.Target, files []string) []*prog.Prog {
 	var progs []*prog.Prog
-	mode := prog.NonStrict
-	if *flagUnsafe {
-		mode = prog.NonStrictUnsafe
-	}
+	mode := deserializeMode()
 	for _, fn := range files {
 		if corpus, err := db.Open(fn, false); err == nil {
 			for _, rec := range corpus.Records {
@@ -424,3 +497,10 @@ func loadPrograms(target *prog.Target, files []string) []*prog.Prog {
 	log.Logf(0, "parsed %v programs", len(progs))
 	return progs
 }
+
+func deserializeMode() prog.DeserializeMode {
+	if *flagUnsafe {
+		return prog.NonStrictUnsafe
+	}
+	return prog.NonStrict
+}
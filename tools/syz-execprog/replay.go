@@ -0,0 +1,114 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/syzkaller/pkg/flatrpc"
+	"github.com/google/syzkaller/pkg/fuzzer/queue"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/rpcserver"
+	"github.com/google/syzkaller/prog"
+)
+
+// replayer executes programs from a session recorded by syz-manager (see record_sessions config option)
+// in the original order, and each program on the same proc it was originally executed on.
+// A program is not started until the previous program on the same proc has finished,
+// and not earlier than it was started in the original session relative to the first program,
+// so concurrency between procs and pauses between programs resemble the original execution.
+// The timing is only approximate: while some programs are executing, the next program is requested
+// only once one of them finishes, so delays shorter than a program execution are not reproduced.
+// Programs are executed with the recorded options (if the session has them).
+type replayer struct {
+	progs []*prog.Prog
+	procs []int
+	times []time.Duration
+	opts  []*flatrpc.ExecOpts
+	// Number of procs used by the session.
+	numProcs int
+
+	mu    sync.Mutex
+	pos   int
+	busy  []bool
+	start time.Time
+}
+
+func loadReplay(target *prog.Target, file string, mode prog.DeserializeMode) *replayer {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		log.Fatalf("failed to read session file: %v", err)
+	}
+	records, err := rpcserver.ParseSession(data)
+	if err != nil {
+		log.Fatalf("failed to parse session file: %v", err)
+	}
+	r := &replayer{}
+	for _, rec := range records {
+		p, err := target.Deserialize(rec.Prog, mode)
+		if err != nil {
+			log.Logf(1, "failed to parse program %v: %v", rec.ID, err)
+			continue
+		}
+		r.progs = append(r.progs, p)
+		r.procs = append(r.procs, rec.Proc)
+		r.times = append(r.times, rec.Time)
+		r.opts = append(r.opts, rec.Opts)
+		r.numProcs = max(r.numProcs, rec.Proc+1)
+	}
+	r.busy = make([]bool, r.numProcs)
+	var duration time.Duration
+	if len(records) != 0 {
+		duration = records[len(records)-1].Time
+	}
+	log.Logf(0, "replaying %v programs (%v failed to parse) on %v procs, session duration %v",
+		len(r.progs), len(records)-len(r.progs), r.numProcs, duration)
+	return r
+}
+
+// next returns the next program to execute, the proc to execute it on, and the recorded options
+// (nil if the session does not have them). Returns nil if all programs were already started,
+// the proc for the next program is still busy, or it's too early to start the next program.
+func (r *replayer) next() (*prog.Prog, int, *flatrpc.ExecOpts) {
+	return r.nextAt(time.Now())
+}
+
+func (r *replayer) nextAt(now time.Time) (*prog.Prog, int, *flatrpc.ExecOpts) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pos == len(r.progs) || r.busy[r.procs[r.pos]] {
+		return nil, 0, nil
+	}
+	if r.start.IsZero() {
+		// The first program is executed right away.
+		r.start = now.Add(-r.times[r.pos])
+	}
+	if now.Sub(r.start) < r.times[r.pos] {
+		return nil, 0, nil
+	}
+	p, proc, opts := r.progs[r.pos], r.procs[r.pos], r.opts[r.pos]
+	r.pos++
+	r.busy[proc] = true
+	return p, proc, opts
+}
+
+func (r *replayer) finished(proc int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.busy[proc] = false
+}
+
+// avoid returns executors that must not execute a program for the given proc.
+func (r *replayer) avoid(proc int) []queue.ExecutorID {
+	var ids []queue.ExecutorID
+	for i := 0; i < r.numProcs; i++ {
+		if i != proc {
+			// RunLocal runs the single instance with id 0.
+			ids = append(ids, queue.ExecutorID{VM: 0, Proc: i})
+		}
+	}
+	return ids
+}
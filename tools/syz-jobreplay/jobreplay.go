@@ -0,0 +1,176 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// syz-jobreplay inspects and replays fuzzer jobs recorded in workdir/jobs.jsonl
+// (see job_log_size manager config option). Usage:
+//
+//	syz-jobreplay jobs.jsonl
+//
+// prints a summary of the recorded jobs, and
+//
+//	syz-jobreplay -config=manager.cfg -job=N jobs.jsonl
+//
+// executes the input and all mutants of the N-th job (or the job with the given input hash)
+// in VMs and prints the coverage of each of the mutants that is not covered by the input.
+// Intended for debugging of mutation strategies that don't give new signal.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/syzkaller/pkg/fuzzer"
+	"github.com/google/syzkaller/pkg/manager"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/tool"
+	"github.com/google/syzkaller/prog"
+	_ "github.com/google/syzkaller/sys"
+	"github.com/google/syzkaller/vm"
+)
+
+var (
+	flagConfig = flag.String("config", "", "manager configuration file")
+	flagJob    = flag.String("job", "", "index or input hash of the job to replay")
+	flagDebug  = flag.Bool("debug", false, "dump all VM output to console")
+)
+
+func main() {
+	flag.Parse()
+	if len(flag.Args()) != 1 || *flagJob != "" && *flagConfig == "" {
+		fmt.Fprintf(os.Stderr, "usage: syz-jobreplay [-config=manager.cfg -job=N] jobs.jsonl\n")
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+	recs, err := fuzzer.ReadJobLog(flag.Args()[0])
+	if err != nil {
+		tool.Fail(err)
+	}
+	if *flagJob == "" {
+		summary(recs)
+		return
+	}
+	rec := findJob(recs, *flagJob)
+	if rec == nil {
+		tool.Failf("job %v is not found", *flagJob)
+	}
+	cfg, err := mgrconfig.LoadFile(*flagConfig)
+	if err != nil {
+		tool.Fail(err)
+	}
+	replay(cfg, rec)
+}
+
+func summary(recs []*fuzzer.JobRecord) {
+	type typeStats struct {
+		jobs, execs, newSignal, unproductive int
+		duration                             time.Duration
+	}
+	stats := make(map[string]*typeStats)
+	for i, rec := range recs {
+		fmt.Printf("#%v %v %v %v: %v execs, %v mutants, %v new signal, %v\n",
+			i, rec.Input, rec.Type, strings.Join(rec.Calls, ","), rec.Execs, len(rec.Mutants),
+			rec.NewSignal, time.Duration(rec.Duration)*time.Millisecond)
+		st := stats[rec.Type]
+		if st == nil {
+			st = new(typeStats)
+			stats[rec.Type] = st
+		}
+		st.jobs++
+		st.execs += rec.Execs
+		st.newSignal += rec.NewSignal
+		st.duration += time.Duration(rec.Duration) * time.Millisecond
+		if rec.NewSignal == 0 {
+			st.unproductive++
+		}
+	}
+	var types []string
+	for typ := range stats {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	for _, typ := range types {
+		st := stats[typ]
+		fmt.Printf("%v: %v jobs (%v without new signal), %v execs, %v new signal, %v\n",
+			typ, st.jobs, st.unproductive, st.execs, st.newSignal, st.duration)
+	}
+}
+
+func findJob(recs []*fuzzer.JobRecord, job string) *fuzzer.JobRecord {
+	if idx, err := strconv.Atoi(job); err == nil {
+		if idx < 0 || idx >= len(recs) {
+			return nil
+		}
+		return recs[idx]
+	}
+	for _, rec := range recs {
+		if rec.Input == job {
+			return rec
+		}
+	}
+	return nil
+}
+
+func replay(cfg *mgrconfig.Config, rec *fuzzer.JobRecord) {
+	progs := []*prog.Prog{deserialize(cfg.Target, rec.Prog)}
+	for _, mutant := range rec.Mutants {
+		progs = append(progs, deserialize(cfg.Target, mutant.Prog))
+	}
+	fmt.Printf("replaying %v job for %v with %v mutants\n", rec.Type, rec.Input, len(rec.Mutants))
+	osutil.HandleInterrupts(vm.Shutdown)
+	results, err := manager.ReplayPrograms(vm.ShutdownCtx(), cfg, progs, *flagDebug)
+	if err != nil {
+		tool.Fail(err)
+	}
+	if results[0].Err != nil {
+		tool.Failf("failed to replay the input: %v", results[0].Err)
+	}
+	inputCover := make(map[uint64]bool)
+	for _, pc := range results[0].Cover {
+		inputCover[pc] = true
+	}
+	fmt.Printf("input: %v PCs\n", len(inputCover))
+	totalCover := make(map[uint64]bool)
+	for i, mutant := range rec.Mutants {
+		res := results[i+1]
+		if res.Err != nil {
+			fmt.Printf("mutant #%v: %v\n", i, res.Err)
+			continue
+		}
+		newPCs := 0
+		for _, pc := range res.Cover {
+			if !inputCover[pc] {
+				newPCs++
+				totalCover[pc] = true
+			}
+		}
+		fmt.Printf("mutant #%v: %v PCs, %v not covered by the input, recorded new signal %v%v\n",
+			i, len(res.Cover), newPCs, mutant.NewSignal, formatOps(mutant.Ops))
+	}
+	fmt.Printf("mutants cover %v PCs not covered by the input\n", len(totalCover))
+}
+
+func deserialize(target *prog.Target, data string) *prog.Prog {
+	p, err := target.Deserialize([]byte(data), prog.NonStrict)
+	if err != nil {
+		tool.Failf("failed to deserialize the program: %v\n%s", err, data)
+	}
+	return p
+}
+
+func formatOps(ops map[string]int) string {
+	if len(ops) == 0 {
+		return ""
+	}
+	var res []string
+	for op, count := range ops {
+		res = append(res, fmt.Sprintf("%v=%v", op, count))
+	}
+	sort.Strings(res)
+	return ", ops: " + strings.Join(res, " ")
+}
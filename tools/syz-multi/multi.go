@@ -0,0 +1,68 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// syz-multi fuzzes several kernels from a single process.
+// All kernels share one corpus stored in the workdir of the first kernel, and programs that bring
+// new coverage on one kernel are cross-executed on the others. The -vms flag limits the total
+// number of VMs running at once across all kernels. Crashes are reproduced on the crashed kernel
+// and are saved in its workdir; the HTTP server of the first kernel shows crashes of all kernels.
+//
+// Usage:
+//
+//	syz-multi name1=manager1.cfg name2=manager2.cfg ...
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/manager"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/vm"
+)
+
+var (
+	flagDebug = flag.Bool("debug", false, "dump all VM output to console")
+	flagVMs   = flag.Int("vms", 0, "total number of VMs running at once across all kernels (0 - no limit)")
+)
+
+func main() {
+	if !prog.GitRevisionKnown() {
+		log.Fatalf("bad syz-multi build: build with make, run bin/syz-multi")
+	}
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: syz-multi [flags] name1=manager1.cfg name2=manager2.cfg...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(1)
+	}
+	log.EnableLogCaching(1000, 1<<20)
+
+	var kernels []manager.KernelConfig
+	for _, arg := range flag.Args() {
+		name, file, ok := strings.Cut(arg, "=")
+		if !ok {
+			log.Fatalf("bad argument %q, want name=manager.cfg", arg)
+		}
+		cfg, err := mgrconfig.LoadFile(file)
+		if err != nil {
+			log.Fatalf("%v: %v", name, err)
+		}
+		kernels = append(kernels, manager.KernelConfig{Name: name, Config: cfg})
+	}
+	ctx := vm.ShutdownCtx()
+	err := manager.RunMultiFuzzer(ctx, kernels, manager.MultiFuzzerConfig{
+		Debug: *flagDebug,
+		VMs:   *flagVMs,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
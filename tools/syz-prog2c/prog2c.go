@@ -20,6 +20,7 @@ var (
 	flagArch       = flag.String("arch", runtime.GOARCH, "target arch")
 	flagBuild      = flag.Bool("build", false, "also build the generated program")
 	flagThreaded   = flag.Bool("threaded", false, "create threaded program")
+	flagSched      = flag.String("sched", "", "scheduling perturbation in threaded mode (yield/pin/spread/delay)")
 	flagRepeat     = flag.Int("repeat", 1, "repeat program that many times (<=0 - infinitely)")
 	flagProcs      = flag.Int("procs", 1, "number of parallel processes")
 	flagSlowdown   = flag.Int("slowdown", 1, "execution slowdown caused by emulation/instrumentation")
@@ -70,6 +71,7 @@ func main() {
 	}
 	opts := csource.Options{
 		Threaded:      *flagThreaded,
+		Sched:         *flagSched,
 		Repeat:        *flagRepeat != 1,
 		RepeatTimes:   *flagRepeat,
 		Procs:         *flagProcs,
@@ -28,8 +28,15 @@ var (
 	flagCRepro = flag.String("crepro", filepath.Join(".", "repro.c"), "output c file (repro.c)")
 	flagTitle  = flag.String("title", "", "where to save the title of the reproduced bug")
 	flagStrace = flag.String("strace", "", "output strace log (strace_bin must be set)")
+	flagPar    = flag.Int("parallel", 1, "number of concurrent test runs during bisection (1: sequential)")
+	flagTries  = flag.Int("attempts", 1, attemptsHelp)
+	flagMinC   = flag.Bool("minimize_c", false, "remove unneeded setup code from the C reproducer (up to 15m)")
+	flagSched  = flag.Bool("sched", false, "also try scheduling perturbations to reproduce races (up to 8 long runs)")
 )
 
+const attemptsHelp = "max number of test runs per candidate (>1 also measures reproduction rate);" +
+	" every rejected candidate costs up to min(attempts, 12) runs, a rejected bisection probe up to 3 runs"
+
 func main() {
 	os.Args = append(append([]string{}, os.Args[0], "-vv=10"), os.Args[1:]...)
 	flag.Parse()
@@ -72,6 +79,12 @@ func main() {
 			Features: flatrpc.AllFeatures,
 			Reporter: reporter,
 			Pool:     pool,
+			Parallel: *flagPar,
+			Attempts: *flagTries,
+			Snapshot: cfg.Experimental.SnapshotRepro,
+			Sched:    *flagSched,
+
+			MinimizeC: *flagMinC,
 		})
 		if err != nil {
 			log.Logf(0, "reproduction failed: %v", err)
@@ -82,12 +95,18 @@ func main() {
 			fmt.Printf("simplifying prog options: %v\n", stats.SimplifyProgTime)
 			fmt.Printf("extracting C: %v\n", stats.ExtractCTime)
 			fmt.Printf("simplifying C: %v\n", stats.SimplifyCTime)
+			fmt.Printf("minimizing C: %v\n", stats.MinimizeCTime)
+			fmt.Printf("test runs: %v (up to %v in parallel)\n", stats.TestRuns, stats.Parallel)
 		}
 		if res == nil {
 			return
 		}
 
-		fmt.Printf("opts: %+v crepro: %v\n\n", res.Opts, res.CRepro)
+		fmt.Printf("opts: %+v crepro: %v\n", res.Opts, res.CRepro)
+		if res.Runs != 0 {
+			fmt.Printf("reproduces %v/%v\n", res.Crashes, res.Runs)
+		}
+		fmt.Printf("\n")
 		progSerialized := res.Prog.Serialize()
 		fmt.Printf("%s\n", progSerialized)
 		if err = osutil.WriteFile(*flagOutput, progSerialized); err == nil {
@@ -119,7 +138,7 @@ func recordTitle(res *repro.Result, fileName string) {
 }
 
 func recordCRepro(res *repro.Result, fileName string) {
-	src, err := csource.Write(res.Prog, res.Opts)
+	src, err := res.WriteC()
 	if err != nil {
 		log.Fatalf("failed to generate C repro: %v", err)
 	}
@@ -29,9 +29,11 @@ type Pool[T Instance] struct {
 	BootErrors chan error
 	BootTime   stat.AverageValue[time.Duration]
 
-	creator    CreateInstance[T]
-	defaultJob Runner[T]
-	jobs       chan Runner[T]
+	creator         CreateInstance[T]
+	reservedCreator CreateInstance[T]
+	budget          *Budget
+	defaultJob      Runner[T]
+	jobs            chan Runner[T]
 
 	// The mutex serializes ReserveForRun() and SetDefault() calls.
 	mu        *sync.Mutex
@@ -62,6 +64,19 @@ func NewPool[T Instance](count int, creator CreateInstance[T], def Runner[T]) *P
 	}
 }
 
+// SetReservedCreator sets a different function to create instances reserved for custom runners
+// (see ReserveForRun). It must be called before Loop.
+func (p *Pool[T]) SetReservedCreator(creator CreateInstance[T]) {
+	p.reservedCreator = creator
+}
+
+// SetBudget makes the pool share the budget with other pools: an instance is booted only
+// once it takes a slot of the budget, and the slot is returned when the instance is shut down.
+// It must be called before Loop.
+func (p *Pool[T]) SetBudget(budget *Budget) {
+	p.budget = budget
+}
+
 // UpdateDefault forces all VMs to restart.
 func (p *Pool[T]) SetDefault(def Runner[T]) {
 	p.mu.Lock()
@@ -113,6 +128,12 @@ func (p *Pool[T]) Loop(ctx context.Context) {
 
 func (p *Pool[T]) runInstance(ctx context.Context, inst *poolInstance[T]) {
 	p.waitUnpaused()
+	if p.budget != nil {
+		if !p.budget.acquire(ctx) {
+			return
+		}
+		defer p.budget.release()
+	}
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	log.Logf(2, "pool: booting instance %d", inst.idx)
@@ -123,7 +144,13 @@ func (p *Pool[T]) runInstance(ctx context.Context, inst *poolInstance[T]) {
 	inst.status(StateBooting)
 	defer inst.status(StateOffline)
 
-	obj, err := p.creator(inst.idx)
+	creator := p.creator
+	inst.mu.Lock()
+	if inst.reserved() && p.reservedCreator != nil {
+		creator = p.reservedCreator
+	}
+	inst.mu.Unlock()
+	obj, err := creator(inst.idx)
 	if err != nil {
 		p.BootErrors <- err
 		return
@@ -334,3 +361,27 @@ func mergeContextCancel(main, monitor context.Context) (context.Context, func())
 	}()
 	return withCancel, cancel
 }
+
+// Budget limits the total number of instances that may run at once across several pools.
+// Pools waiting for a slot get it in the FIFO order, so as instances are re-created,
+// the slots are passed around between the pools.
+type Budget struct {
+	slots chan struct{}
+}
+
+func NewBudget(count int) *Budget {
+	return &Budget{slots: make(chan struct{}, count)}
+}
+
+func (b *Budget) acquire(ctx context.Context) bool {
+	select {
+	case b.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (b *Budget) release() {
+	<-b.slots
+}
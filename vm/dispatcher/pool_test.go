@@ -237,6 +237,40 @@ func TestPoolPause(t *testing.T) {
 	<-done
 }
 
+func TestPoolReservedCreator(t *testing.T) {
+	mgr := NewPool[*testInstance](
+		3,
+		func(idx int) (*testInstance, error) {
+			return &testInstance{index: idx}, nil
+		},
+		func(ctx context.Context, _ *testInstance, _ UpdateInfo) {
+			<-ctx.Done()
+		},
+	)
+	// Reserved instances are marked with a negative index.
+	mgr.SetReservedCreator(func(idx int) (*testInstance, error) {
+		return &testInstance{index: -1 - idx}, nil
+	})
+	done := make(chan bool)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		mgr.Loop(ctx)
+		close(done)
+	}()
+
+	mgr.ReserveForRun(1)
+	for i := 0; i < 3; i++ {
+		var index int
+		mgr.Run(ctx, func(_ context.Context, inst *testInstance, _ UpdateInfo) {
+			index = inst.Index()
+		})
+		assert.Less(t, index, 0)
+	}
+
+	cancel()
+	<-done
+}
+
 func TestPoolCancelRun(t *testing.T) {
 	// The test to aid the race detector.
 	mgr := NewPool[*nilInstance](
@@ -286,6 +320,44 @@ func TestPoolCancelRun(t *testing.T) {
 	wg.Wait()
 }
 
+func TestPoolBudget(t *testing.T) {
+	const budget = 2
+	var running, maxRunning atomic.Int64
+	ran := make([]atomic.Int64, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	shared := NewBudget(budget)
+	for i := range ran {
+		mgr := NewPool[*countedInstance](
+			3,
+			func(idx int) (*countedInstance, error) {
+				cur := running.Add(1)
+				for prev := maxRunning.Load(); cur > prev; prev = maxRunning.Load() {
+					maxRunning.CompareAndSwap(prev, cur)
+				}
+				return &countedInstance{running: &running}, nil
+			},
+			func(ctx context.Context, _ *countedInstance, _ UpdateInfo) {
+				ran[i].Add(1)
+				time.Sleep(time.Millisecond)
+			},
+		)
+		mgr.SetBudget(shared)
+		wg.Add(1)
+		go func() {
+			mgr.Loop(ctx)
+			wg.Done()
+		}()
+	}
+	// Both pools eventually get their share of the budget.
+	for ran[0].Load() < 10 || ran[1].Load() < 10 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	wg.Wait()
+	assert.LessOrEqual(t, maxRunning.Load(), int64(budget))
+}
+
 func makePool(count int) []testInstance {
 	var ret []testInstance
 	for i := 0; i < count; i++ {
@@ -338,3 +410,12 @@ type nilInstance struct {
 func (ni *nilInstance) Close() error {
 	return nil
 }
+
+type countedInstance struct {
+	running *atomic.Int64
+}
+
+func (ci *countedInstance) Close() error {
+	ci.running.Add(-1)
+	return nil
+}
@@ -325,6 +325,14 @@ func (pool *Pool) Count() int {
 }
 
 func (pool *Pool) Create(workdir string, index int) (vmimpl.Instance, error) {
+	return pool.create(workdir, index, pool.env.Snapshot)
+}
+
+func (pool *Pool) CreateSnapshot(workdir string, index int) (vmimpl.Instance, error) {
+	return pool.create(workdir, index, true)
+}
+
+func (pool *Pool) create(workdir string, index int, withSnapshot bool) (vmimpl.Instance, error) {
 	sshkey := pool.env.SSHKey
 	sshuser := pool.env.SSHUser
 	if pool.env.Image == "9p" {
@@ -341,7 +349,7 @@ func (pool *Pool) Create(workdir string, index int) (vmimpl.Instance, error) {
 	}
 
 	for i := 0; ; i++ {
-		inst, err := pool.ctor(workdir, sshkey, sshuser, index)
+		inst, err := pool.ctor(workdir, sshkey, sshuser, index, withSnapshot)
 		if err == nil {
 			return inst, nil
 		}
@@ -359,7 +367,7 @@ func (pool *Pool) Create(workdir string, index int) (vmimpl.Instance, error) {
 	}
 }
 
-func (pool *Pool) ctor(workdir, sshkey, sshuser string, index int) (*instance, error) {
+func (pool *Pool) ctor(workdir, sshkey, sshuser string, index int, withSnapshot bool) (*instance, error) {
 	inst := &instance{
 		index:      index,
 		cfg:        pool.cfg,
@@ -378,7 +386,7 @@ func (pool *Pool) ctor(workdir, sshkey, sshuser string, index int) (*instance, e
 			User: sshuser,
 		},
 	}
-	if pool.env.Snapshot {
+	if withSnapshot {
 		inst.snapshot = new(snapshot)
 	}
 	if st, err := os.Stat(inst.image); err == nil && st.Size() == 0 {
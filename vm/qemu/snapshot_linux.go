@@ -8,12 +8,14 @@ import (
 	"fmt"
 	"net"
 	"path/filepath"
+	"strings"
 	"sync/atomic"
 	"syscall"
 	"time"
 	"unsafe"
 
 	"github.com/google/syzkaller/pkg/flatrpc"
+	"github.com/google/syzkaller/sys/targets"
 	"golang.org/x/sys/unix"
 )
 
@@ -28,6 +30,12 @@ type snapshot struct {
 	header      *flatrpc.SnapshotHeaderT
 }
 
+func (pool *Pool) SnapshotSupported() bool {
+	// Snapshots need KVM (see the -cpu argument in snapshotEnable) and are supported by executor only on linux.
+	return pool.env.OS == targets.Linux && pool.env.Arch == targets.AMD64 &&
+		strings.Contains(pool.cfg.QemuArgs, "-enable-kvm")
+}
+
 func (inst *instance) snapshotClose() {
 	if inst.ivsListener != nil {
 		inst.ivsListener.Close()
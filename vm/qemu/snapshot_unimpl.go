@@ -13,6 +13,10 @@ type snapshot struct{}
 
 var errNotImplemented = fmt.Errorf("snapshots are not implemeneted")
 
+func (pool *Pool) SnapshotSupported() bool {
+	return false
+}
+
 func (inst *instance) snapshotClose() {
 }
 
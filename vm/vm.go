@@ -53,6 +53,7 @@ type Pool struct {
 	count              int
 	activeCount        int32
 	snapshot           bool
+	snapshotRepro      bool
 	hostFuzzer         bool
 	statOutputReceived *stat.Val
 }
@@ -62,6 +63,7 @@ type Instance struct {
 	impl          vmimpl.Instance
 	workdir       string
 	index         int
+	snapshot      bool
 	snapshotSetup bool
 	onClose       func()
 }
@@ -140,14 +142,15 @@ func Create(cfg *mgrconfig.Config, debug bool) (*Pool, error) {
 		count = 1
 	}
 	return &Pool{
-		impl:       impl,
-		typ:        typ,
-		workdir:    env.Workdir,
-		template:   cfg.WorkdirTemplate,
-		timeouts:   cfg.Timeouts,
-		count:      count,
-		snapshot:   cfg.Snapshot,
-		hostFuzzer: cfg.SysTarget.HostFuzzer,
+		impl:          impl,
+		typ:           typ,
+		workdir:       env.Workdir,
+		template:      cfg.WorkdirTemplate,
+		timeouts:      cfg.Timeouts,
+		count:         count,
+		snapshot:      cfg.Snapshot,
+		snapshotRepro: cfg.Experimental.SnapshotRepro,
+		hostFuzzer:    cfg.SysTarget.HostFuzzer,
 		statOutputReceived: stat.New("vm output", "Bytes of VM console output received",
 			stat.Graph("traffic"), stat.Rate{}, stat.FormatMB),
 	}, nil
@@ -158,6 +161,17 @@ func (pool *Pool) Count() int {
 }
 
 func (pool *Pool) Create(index int) (*Instance, error) {
+	return pool.create(index, pool.snapshot)
+}
+
+// CreateSnapshot is like Create, but boots the instance with snapshot support (see SetupSnapshot),
+// if the VM type supports it. Otherwise it boots a usual instance.
+func (pool *Pool) CreateSnapshot(index int) (*Instance, error) {
+	impl, ok := pool.impl.(vmimpl.SnapshotPool)
+	return pool.create(index, pool.snapshot || ok && impl.SnapshotSupported())
+}
+
+func (pool *Pool) create(index int, snapshot bool) (*Instance, error) {
 	if index < 0 || index >= pool.count {
 		return nil, fmt.Errorf("invalid VM index %v (count %v)", index, pool.count)
 	}
@@ -170,18 +184,24 @@ func (pool *Pool) Create(index int) (*Instance, error) {
 			return nil, err
 		}
 	}
-	impl, err := pool.impl.Create(workdir, index)
+	var impl vmimpl.Instance
+	if snapshot && !pool.snapshot {
+		impl, err = pool.impl.(vmimpl.SnapshotPool).CreateSnapshot(workdir, index)
+	} else {
+		impl, err = pool.impl.Create(workdir, index)
+	}
 	if err != nil {
 		os.RemoveAll(workdir)
 		return nil, err
 	}
 	atomic.AddInt32(&pool.activeCount, 1)
 	return &Instance{
-		pool:    pool,
-		impl:    impl,
-		workdir: workdir,
-		index:   index,
-		onClose: func() { atomic.AddInt32(&pool.activeCount, -1) },
+		pool:     pool,
+		impl:     impl,
+		workdir:  workdir,
+		index:    index,
+		snapshot: snapshot,
+		onClose:  func() { atomic.AddInt32(&pool.activeCount, -1) },
 	}, nil
 }
 
@@ -229,6 +249,14 @@ func (inst *Instance) RunSnapshot(input []byte) (result, output []byte, err erro
 	return impl.RunSnapshot(timeout, input)
 }
 
+// SnapshotSupported returns whether SetupSnapshot/RunSnapshot can be used with the instance.
+// Besides support in the VM type this requires the instance to be booted in a special way
+// (in the snapshot mode in the manager config, or with CreateSnapshot).
+func (inst *Instance) SnapshotSupported() bool {
+	_, ok := inst.impl.(snapshotter)
+	return ok && inst.snapshot
+}
+
 type snapshotter interface {
 	SetupSnapshot([]byte) error
 	RunSnapshot(time.Duration, []byte) ([]byte, []byte, error)
@@ -333,8 +361,15 @@ func (inst *Instance) Close() error {
 
 type Dispatcher = dispatcher.Pool[*Instance]
 
+// NewDispatcher creates a dispatcher for the pool. If snapshot_repro is enabled in the config,
+// instances reserved for custom runners (e.g. bug reproduction) are booted with snapshot support,
+// if the VM type supports it.
 func NewDispatcher(pool *Pool, def dispatcher.Runner[*Instance]) *Dispatcher {
-	return dispatcher.NewPool(pool.count, pool.Create, def)
+	ret := dispatcher.NewPool(pool.count, pool.Create, def)
+	if pool.snapshotRepro {
+		ret.SetReservedCreator(pool.CreateSnapshot)
+	}
+	return ret
 }
 
 type monitor struct {
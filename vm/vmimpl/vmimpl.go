@@ -70,6 +70,15 @@ type Infoer interface {
 	Info() ([]byte, error)
 }
 
+// SnapshotPool is an optional interface that can be implemented by Pool.
+// It allows to boot separate instances with snapshot support (see Env.Snapshot).
+type SnapshotPool interface {
+	// SnapshotSupported says if CreateSnapshot can be used with the current configuration.
+	SnapshotSupported() bool
+	// CreateSnapshot creates and boots a new VM instance with snapshot support.
+	CreateSnapshot(workdir string, index int) (Instance, error)
+}
+
 // Env contains global constant parameters for a pool of VMs.
 type Env struct {
 	// Unique name